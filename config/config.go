@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +15,10 @@ type Config struct {
 	Redis    RedisConfig
 	GRPC     GRPCConfig
 	Logger   LoggerConfig
+	Capture  CaptureConfig
+	Check    CheckConfig
+	Blob     BlobConfig
+	Share    ShareConfig
 }
 
 type LoggerConfig struct {
@@ -21,6 +28,14 @@ type LoggerConfig struct {
 type ServerConfig struct {
 	Host string
 	Port string
+	// APIKey is the bearer token of the REST API, which is off without it.
+	APIKey string
+	// ContentScripts lets scripts of archived pages run, still sandboxed
+	// away from our origin.
+	ContentScripts bool
+	// ContentSanitize strips scripts and event handlers from archived
+	// pages as they are served.
+	ContentSanitize bool
 }
 
 type RedisConfig struct {
@@ -30,8 +45,67 @@ type RedisConfig struct {
 
 type GRPCConfig struct {
 	BaseURL string
-	Host    string
-	Port    string
+	// ContentURL is the base url of the separate host archived pages are
+	// served from, generated links use BaseURL when it is empty.
+	ContentURL string
+	Host       string
+	Port       string
+}
+
+type CaptureConfig struct {
+	// Workers is the number of pages captured concurrently.
+	Workers int
+	// MaxAttempts is how many times a capture is tried before it fails.
+	MaxAttempts int
+	// Timeout limits a single page download.
+	Timeout time.Duration
+	// Allowlist holds hostnames, ips and cidrs that may be captured even
+	// though they are not public, for trusted intranet deployments.
+	Allowlist []string
+	// InlineAssets embeds stylesheets, images and fonts into saved pages.
+	InlineAssets bool
+	// InlineMaxSize caps the size of a page with inlined assets in bytes,
+	// larger pages are saved without them.
+	InlineMaxSize int64
+	// WARC keeps every capture as a web archive next to the page.
+	WARC bool
+}
+
+type CheckConfig struct {
+	// Interval is how often every saved link is checked, zero disables
+	// the checker.
+	Interval time.Duration
+	// DomainDelay is the least time between two checks on one domain.
+	DomainDelay time.Duration
+	// Workers is the number of links checked concurrently.
+	Workers int
+	// BatchSize is how many due links are claimed at once.
+	BatchSize int
+	// DeadAfter is how many failed checks in a row mark a link dead.
+	DeadAfter int
+}
+
+// BlobConfig selects where page content is kept: "fs" for a directory,
+// "s3" for an S3-compatible bucket, empty for the database.
+type BlobConfig struct {
+	Store string
+	// Path is the directory of the fs store.
+	Path string
+	// S3Endpoint is the base url of the S3 api, e.g. https://s3.amazonaws.com
+	// or http://localhost:9000 for MinIO. Buckets are addressed by path.
+	S3Endpoint  string
+	S3Bucket    string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+type ShareConfig struct {
+	// Key signs share links. A random key is used when it is empty,
+	// then the links stop working on restart.
+	Key string
+	// TTL is how long share links open the page, zero for no limit.
+	TTL time.Duration
 }
 
 type DatabaseConfig struct {
@@ -41,6 +115,7 @@ type DatabaseConfig struct {
 	Username string
 	Password string
 	Driver   string
+	Path     string
 }
 
 func Load() (*Config, error) {
@@ -57,22 +132,90 @@ func Load() (*Config, error) {
 			Host:     os.Getenv("DB_HOST"),
 			Port:     os.Getenv("DB_PORT"),
 			Driver:   os.Getenv("DB_DRIVER"),
+			Path:     os.Getenv("DB_PATH"),
 		},
 		Server: ServerConfig{
-			Host: os.Getenv("S_HOST"),
-			Port: os.Getenv("S_PORT"),
+			Host:            os.Getenv("S_HOST"),
+			Port:            os.Getenv("S_PORT"),
+			APIKey:          os.Getenv("API_KEY"),
+			ContentScripts:  getBool("CONTENT_SCRIPTS", false),
+			ContentSanitize: getBool("CONTENT_SANITIZE", false),
 		},
 		Redis: RedisConfig{
 			Host: os.Getenv("R_HOST"),
 			Port: os.Getenv("R_PORT"),
 		},
 		GRPC: GRPCConfig{
-			BaseURL: os.Getenv("BASE_URL"),
-			Host:    os.Getenv("GRPC_HOST"),
-			Port:    os.Getenv("GRPC_PORT"),
+			BaseURL:    os.Getenv("BASE_URL"),
+			ContentURL: os.Getenv("CONTENT_URL"),
+			Host:       os.Getenv("GRPC_HOST"),
+			Port:       os.Getenv("GRPC_PORT"),
 		},
 		Logger: LoggerConfig{
 			Level: os.Getenv("LOGGER_LEVEL"),
 		},
+		Capture: CaptureConfig{
+			Workers:       int(getInt64("CAPTURE_WORKERS", 4)),
+			MaxAttempts:   int(getInt64("CAPTURE_MAX_ATTEMPTS", 5)),
+			Timeout:       getDuration("CAPTURE_TIMEOUT", 30*time.Second),
+			Allowlist:     getList("CAPTURE_ALLOWLIST"),
+			InlineAssets:  getBool("CAPTURE_INLINE_ASSETS", false),
+			InlineMaxSize: getInt64("CAPTURE_INLINE_MAX_SIZE", 10<<20),
+			WARC:          getBool("CAPTURE_WARC", true),
+		},
+		Check: CheckConfig{
+			Interval:    getDuration("LINK_CHECK_INTERVAL", 24*time.Hour),
+			DomainDelay: getDuration("LINK_CHECK_DOMAIN_DELAY", 10*time.Second),
+			Workers:     int(getInt64("LINK_CHECK_WORKERS", 4)),
+			BatchSize:   int(getInt64("LINK_CHECK_BATCH_SIZE", 100)),
+			DeadAfter:   int(getInt64("LINK_CHECK_DEAD_AFTER", 3)),
+		},
+		Blob: BlobConfig{
+			Store:       os.Getenv("BLOB_STORE"),
+			Path:        os.Getenv("BLOB_PATH"),
+			S3Endpoint:  os.Getenv("S3_ENDPOINT"),
+			S3Bucket:    os.Getenv("S3_BUCKET"),
+			S3Region:    os.Getenv("S3_REGION"),
+			S3AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			S3SecretKey: os.Getenv("S3_SECRET_KEY"),
+		},
+		Share: ShareConfig{
+			Key: os.Getenv("SHARE_KEY"),
+			TTL: getDuration("SHARE_TTL", 24*time.Hour),
+		},
 	}, nil
 }
+
+func getBool(key string, def bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func getInt64(key string, def int64) int64 {
+	v, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func getDuration(key string, def time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func getList(key string) []string {
+	var list []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
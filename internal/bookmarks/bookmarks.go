@@ -0,0 +1,184 @@
+// Package bookmarks reads the Netscape bookmark file, the html format
+// browsers like Chrome and Firefox use to export bookmarks.
+package bookmarks
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// ErrNotBookmarkFile means the input has no bookmark list at all.
+	ErrNotBookmarkFile = errors.New("not a netscape bookmark file")
+)
+
+type Bookmark struct {
+	URL   string
+	Title string
+	// AddDate is zero when the browser did not export it
+	AddDate time.Time
+	// Folders is the path of folders from the top, the root folders
+	// of the browser like the bookmarks toolbar are left out
+	Folders []string
+	// Tags are set by Firefox, as is, without normalization
+	Tags []string
+}
+
+// folder is an open <DL> list.
+type folder struct {
+	name string
+	// root folders are not included in the path of bookmarks
+	root bool
+}
+
+// Parse reads all bookmarks of the file in order. The format is loose
+// html with unclosed <DT> and <p> tags, so it is tokenized instead of
+// being parsed into a tree.
+func Parse(r io.Reader) ([]*Bookmark, error) {
+	z := html.NewTokenizer(r)
+
+	var (
+		bookmarks []*Bookmark
+		stack     []folder
+		// heading is the <H3> folder title waiting for its <DL>
+		heading *folder
+		// text collects the title of the current <A> or <H3>
+		text     *strings.Builder
+		current  *Bookmark
+		seenList bool
+	)
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return nil, err
+			}
+			if !seenList {
+				return nil, ErrNotBookmarkFile
+			}
+			return bookmarks, nil
+
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			attrs := readAttrs(z, hasAttr)
+
+			switch atom.Lookup(name) {
+			case atom.Dl:
+				seenList = true
+				// the first list holds the whole file and has no heading
+				f := folder{root: true}
+				if heading != nil {
+					f = *heading
+				}
+				stack = append(stack, f)
+				heading = nil
+			case atom.H3:
+				heading = &folder{
+					root: attrs["personal_toolbar_folder"] == "true" || attrs["unfiled_bookmarks_folder"] == "true",
+				}
+				text = &strings.Builder{}
+			case atom.A:
+				href := strings.TrimSpace(attrs["href"])
+				if href == "" {
+					continue
+				}
+				current = &Bookmark{
+					URL:     href,
+					AddDate: parseDate(attrs["add_date"]),
+					Folders: path(stack),
+					Tags:    splitTags(attrs["tags"]),
+				}
+				text = &strings.Builder{}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+
+			switch atom.Lookup(name) {
+			case atom.Dl:
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			case atom.H3:
+				if heading != nil && text != nil {
+					heading.name = collapseSpace(text.String())
+				}
+				text = nil
+			case atom.A:
+				if current != nil {
+					current.Title = collapseSpace(text.String())
+					bookmarks = append(bookmarks, current)
+				}
+				current = nil
+				text = nil
+			}
+
+		case html.TextToken:
+			if text != nil {
+				text.Write(z.Text())
+			}
+		}
+	}
+}
+
+// readAttrs returns the attributes of the current tag with lowercase keys.
+func readAttrs(z *html.Tokenizer, more bool) map[string]string {
+	attrs := make(map[string]string)
+	for more {
+		var key, val []byte
+		key, val, more = z.TagAttr()
+		attrs[string(key)] = string(val)
+	}
+	return attrs
+}
+
+// path returns the names of the open folders, without the root ones.
+func path(stack []folder) []string {
+	var names []string
+	for _, f := range stack {
+		if !f.root && f.name != "" {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// parseDate reads ADD_DATE, which is in seconds since the epoch.
+// Some exporters write milliseconds or microseconds instead.
+func parseDate(s string) time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+
+	switch {
+	case n > 1e14:
+		return time.UnixMicro(n).UTC()
+	case n > 1e11:
+		return time.UnixMilli(n).UTC()
+	default:
+		return time.Unix(n, 0).UTC()
+	}
+}
+
+func splitTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
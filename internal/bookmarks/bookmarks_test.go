@@ -0,0 +1,150 @@
+package bookmarks
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// firefox is a trimmed export of Firefox, Chrome writes the same
+// structure without TAGS.
+const firefox = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<meta http-equiv="Content-Security-Policy"
+      content="default-src 'self'; script-src 'none'; img-src data: *; object-src 'none'"></meta>
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks Menu</H1>
+
+<DL><p>
+    <DT><A HREF="https://go.dev/doc/" ADD_DATE="1600000000" LAST_MODIFIED="1600000001" TAGS="go,Docs">Go &amp; docs</A>
+    <DT><H3 ADD_DATE="1600000000" LAST_MODIFIED="1600000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks Toolbar</H3>
+    <DL><p>
+        <DT><A HREF="https://example.com/toolbar" ADD_DATE="1600000100">On the toolbar</A>
+        <DT><H3 ADD_DATE="1600000000">Reading   list</H3>
+        <DL><p>
+            <DT><A HREF="https://example.com/article" ADD_DATE="1600000200000">An
+                article</A>
+            <DD>Notes are skipped
+            <DT><H3>Later</H3>
+            <DL><p>
+                <DT><A HREF="https://example.com/later">Later</A>
+            </DL><p>
+        </DL><p>
+        <DT><A HREF="https://example.com/after">After folder</A>
+    </DL><p>
+    <DT><A HREF="place:sort=8&maxResults=10">Recent</A>
+    <DT><A>No link</A>
+</DL>
+`
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.NewReader(firefox))
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+
+	want := []*Bookmark{
+		{
+			URL:     "https://go.dev/doc/",
+			Title:   "Go & docs",
+			AddDate: time.Unix(1600000000, 0).UTC(),
+			Tags:    []string{"go", "Docs"},
+		},
+		{
+			URL:     "https://example.com/toolbar",
+			Title:   "On the toolbar",
+			AddDate: time.Unix(1600000100, 0).UTC(),
+		},
+		{
+			URL:     "https://example.com/article",
+			Title:   "An article",
+			AddDate: time.Unix(1600000200, 0).UTC(),
+			Folders: []string{"Reading list"},
+		},
+		{
+			URL:     "https://example.com/later",
+			Title:   "Later",
+			Folders: []string{"Reading list", "Later"},
+		},
+		{
+			URL:   "https://example.com/after",
+			Title: "After folder",
+		},
+		{
+			URL:   "place:sort=8&maxResults=10",
+			Title: "Recent",
+		},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Parse() returned %d bookmarks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("bookmark %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseNotBookmarkFile(t *testing.T) {
+	_, err := Parse(strings.NewReader("<html><body><a href=\"https://example.com\">x</a></body></html>"))
+	if !errors.Is(err, ErrNotBookmarkFile) {
+		t.Fatalf("Parse() error = %v, want ErrNotBookmarkFile", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"", time.Time{}},
+		{"junk", time.Time{}},
+		{"0", time.Time{}},
+		{"1600000000", time.Unix(1600000000, 0).UTC()},
+		{"1600000000123", time.UnixMilli(1600000000123).UTC()},
+		{"1600000000123456", time.UnixMicro(1600000000123456).UTC()},
+	}
+
+	for _, tt := range tests {
+		if got := parseDate(tt.in); !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteParse(t *testing.T) {
+	list := []*Bookmark{
+		{URL: "https://example.com/?a=1&b=2", Title: "Top <level>", AddDate: time.Unix(1600000000, 0).UTC()},
+		{URL: "https://example.com/a", Title: "A", Folders: []string{"Reading", "Later"}, Tags: []string{"go", "docs"}},
+		{URL: "https://example.com/b", Title: "B", Folders: []string{"Reading"}},
+		{URL: "https://example.com/c", Title: "C", Folders: []string{"Work"}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, list); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse(): %v", err)
+	}
+
+	// bookmarks of a folder come before its subfolders
+	want := []*Bookmark{list[0], list[2], list[1], list[3]}
+	if len(got) != len(want) {
+		t.Fatalf("Parse() returned %d bookmarks, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("bookmark %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package bookmarks
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const header = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+`
+
+// node is a folder of the written file.
+type node struct {
+	name      string
+	children  []*node
+	bookmarks []*Bookmark
+}
+
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &node{name: name}
+	n.children = append(n.children, c)
+	return c
+}
+
+// Write writes bookmarks as a Netscape bookmark file that browsers import,
+// Folders become nested folders in the order they first appear.
+func Write(w io.Writer, list []*Bookmark) error {
+	root := &node{}
+	for _, b := range list {
+		n := root
+		for _, name := range b.Folders {
+			n = n.child(name)
+		}
+		n.bookmarks = append(n.bookmarks, b)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(header)
+	writeNode(bw, root, 0)
+	return bw.Flush()
+}
+
+func writeNode(w *bufio.Writer, n *node, depth int) {
+	indent := strings.Repeat("    ", depth)
+
+	w.WriteString(indent + "<DL><p>\n")
+	for _, b := range n.bookmarks {
+		w.WriteString(indent + `    <DT><A HREF="` + html.EscapeString(b.URL) + `"`)
+		if !b.AddDate.IsZero() {
+			w.WriteString(` ADD_DATE="` + strconv.FormatInt(b.AddDate.Unix(), 10) + `"`)
+		}
+		if len(b.Tags) > 0 {
+			w.WriteString(` TAGS="` + html.EscapeString(strings.Join(b.Tags, ",")) + `"`)
+		}
+		w.WriteString(">" + html.EscapeString(b.Title) + "</A>\n")
+	}
+	for _, c := range n.children {
+		w.WriteString(indent + "    <DT><H3>" + html.EscapeString(c.name) + "</H3>\n")
+		writeNode(w, c, depth+1)
+	}
+	w.WriteString(indent + "</DL><p>\n")
+}
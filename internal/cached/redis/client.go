@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -17,11 +18,6 @@ type Redis struct {
 	client *redis.Client
 }
 
-type RedisLink struct {
-	ID   string
-	Link string
-}
-
 func New(cfg config.RedisConfig) *Redis {
 	client := redis.NewClient(
 		&redis.Options{
@@ -33,90 +29,200 @@ func New(cfg config.RedisConfig) *Redis {
 	}
 }
 
-func (r *Redis) SaveLink(ctx context.Context, userId int64, url, originalURL string, urlID int32) error {
-	key := fmt.Sprintf("links:%d:%s", userId, originalURL)
-	value := fmt.Sprintf("%d:%s", urlID, url)
+// Links generated before share links were signed are kept under these
+// keys until their TTL runs out, new links are not saved there.
+
+func linkKey(userId int64, originalURL string) string {
+	return fmt.Sprintf("links:%d:%s", userId, originalURL)
+}
+
+func urlsKey(userId int64) string {
+	return fmt.Sprintf("links:%d:urls", userId)
+}
+
+// tokenKey is the reverse index key: generated link -> original url.
+func tokenKey(userId int64, generatedLink string) string {
+	return fmt.Sprintf("tokens:%d:%s", userId, generatedLink)
+}
+
+// shareKey is the key of the share token a generated link redirects to.
+func shareKey(userId int64, generatedLink string) string {
+	return fmt.Sprintf("shares:%d:%s", userId, generatedLink)
+}
+
+// importKey is the key of a one-time bookmark upload page.
+func importKey(userId int64, token string) string {
+	return fmt.Sprintf("imports:%d:%s", userId, token)
+}
+
+// exportKey is the key of an archive download link.
+func exportKey(userId int64, token string) string {
+	return fmt.Sprintf("exports:%d:%s", userId, token)
+}
 
-	err := r.client.SetEx(ctx, key, value, 24*time.Hour).Err()
+func (r *Redis) SaveImportToken(ctx context.Context, userId int64, token string, ttl time.Duration) error {
+	err := r.client.SetEx(ctx, importKey(userId, token), "1", ttl).Err()
 	if err != nil {
-		return wrap.E(pkg, "failed to SetEx() key:val", err)
+		return wrap.E(pkg, "failed to SetEx() import key", err)
 	}
 
-	globalKey := fmt.Sprintf("links:%d:urls", userId)
+	return nil
+}
+
+func (r *Redis) ImportTokenExists(ctx context.Context, userId int64, token string) (bool, error) {
+	n, err := r.client.Exists(ctx, importKey(userId, token)).Result()
+	if err != nil {
+		return false, wrap.E(pkg, "failed to Exists() import key", err)
+	}
+
+	return n == 1, nil
+}
 
-	_, err = r.client.SAdd(ctx, globalKey, originalURL).Result()
+// TakeImportToken deletes the token and reports whether it was there,
+// so of concurrent uploads with the same token only one gets it.
+func (r *Redis) TakeImportToken(ctx context.Context, userId int64, token string) (bool, error) {
+	n, err := r.client.Del(ctx, importKey(userId, token)).Result()
 	if err != nil {
-		return wrap.E(pkg, "failed to SAdd() globalKey:url", err)
+		return false, wrap.E(pkg, "failed to Del() import key", err)
+	}
+
+	return n == 1, nil
+}
+
+func (r *Redis) SaveExportToken(ctx context.Context, userId int64, token string, ttl time.Duration) error {
+	err := r.client.SetEx(ctx, exportKey(userId, token), "1", ttl).Err()
+	if err != nil {
+		return wrap.E(pkg, "failed to SetEx() export key", err)
 	}
 
 	return nil
 }
 
-func (r *Redis) GetOriginalURL(ctx context.Context, userId int64, generatedLink string) (string, error) {
-	globalKey := fmt.Sprintf("links:%d:urls", userId)
+func (r *Redis) ExportTokenExists(ctx context.Context, userId int64, token string) (bool, error) {
+	n, err := r.client.Exists(ctx, exportKey(userId, token)).Result()
+	if err != nil {
+		return false, wrap.E(pkg, "failed to Exists() export key", err)
+	}
+
+	return n == 1, nil
+}
 
-	urls, err := r.client.SMembers(ctx, globalKey).Result()
+// GetOriginalURL resolves a generated link to the original url and how
+// long the link has left. It returns an error wrapping redis.Nil when
+// the link is unknown or expired.
+func (r *Redis) GetOriginalURL(ctx context.Context, userId int64, generatedLink string) (string, time.Duration, error) {
+	key := tokenKey(userId, generatedLink)
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		ttl = pipe.TTL(ctx, key)
+		return nil
+	})
+	if err == nil {
+		return get.Val(), ttl.Val(), nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return "", 0, wrap.E(pkg, "failed to Get() token key", err)
+	}
+
+	// links saved before the reverse index existed have no token key,
+	// so fall back to the old lookup and backfill the index on a hit.
+	return r.getOriginalURLByScan(ctx, userId, generatedLink)
+}
+
+func (r *Redis) getOriginalURLByScan(ctx context.Context, userId int64, generatedLink string) (string, time.Duration, error) {
+	urls, err := r.client.SMembers(ctx, urlsKey(userId)).Result()
 	if err != nil {
-		if err == redis.Nil {
-			return "", wrap.E(pkg, "no links in redis", err)
-		}
-		return "", wrap.E(pkg, "failed to SMembers() globalkey:urls", err)
+		return "", 0, wrap.E(pkg, "failed to SMembers() globalkey:urls", err)
 	}
 
 	for _, originalURL := range urls {
-		key := fmt.Sprintf("links:%d:%s", userId, originalURL)
+		key := linkKey(userId, originalURL)
 
 		value, err := r.client.Get(ctx, key).Result()
 		if err != nil {
-			if err == redis.Nil {
+			if errors.Is(err, redis.Nil) {
 				continue
 			}
-			return "", wrap.E(pkg, "failed to Get() key:url", err)
+			return "", 0, wrap.E(pkg, "failed to Get() key:url", err)
 		}
 
 		parts := strings.SplitN(value, ":", 2)
 		if len(parts) == 2 && parts[1] == generatedLink {
-			return originalURL, nil
+			ttl, err := r.backfillToken(ctx, userId, generatedLink, originalURL, key)
+			if err != nil {
+				return "", 0, err
+			}
+			return originalURL, ttl, nil
 		}
 	}
 
-	return "", wrap.E(pkg, "no links in redis", nil)
+	return "", 0, wrap.E(pkg, "no links in redis", redis.Nil)
 }
 
-func (r *Redis) GetLink(ctx context.Context, userId int64, originalURL string) (*RedisLink, error) {
-	key := fmt.Sprintf("links:%d:%s", userId, originalURL)
+// backfillToken creates the reverse index entry for a link key that
+// was saved without one. The token key expires together with the link key.
+func (r *Redis) backfillToken(ctx context.Context, userId int64, generatedLink, originalURL, key string) (time.Duration, error) {
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to TTL() key:url", err)
+	}
+	if ttl <= 0 {
+		// the link key expired meanwhile or never expires
+		return 0, wrap.E(pkg, "no links in redis", redis.Nil)
+	}
+
+	err = r.client.SetEx(ctx, tokenKey(userId, generatedLink), originalURL, ttl).Err()
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to SetEx() token key", err)
+	}
 
-	value, err := r.client.Get(ctx, key).Result()
+	return ttl, nil
+}
+
+// SaveShareToken keeps the share token a generated link redirects to,
+// until the generated link expires.
+func (r *Redis) SaveShareToken(ctx context.Context, userId int64, generatedLink, token string, ttl time.Duration) error {
+	err := r.client.SetEx(ctx, shareKey(userId, generatedLink), token, ttl).Err()
 	if err != nil {
-		if err == redis.Nil {
-			return nil, wrap.E(pkg, "no link found by key", err)
-		}
-		return nil, wrap.E(pkg, "internal error in Get()", err)
+		return wrap.E(pkg, "failed to SetEx() share key", err)
 	}
 
-	parts := strings.SplitN(value, ":", 2)
-	if len(parts) < 2 {
-		return nil, wrap.E(pkg, "invalid value format stores", err)
+	return nil
+}
+
+// GetShareToken returns the share token saved for a generated link, an
+// empty one when there is none yet.
+func (r *Redis) GetShareToken(ctx context.Context, userId int64, generatedLink string) (string, error) {
+	token, err := r.client.Get(ctx, shareKey(userId, generatedLink)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", wrap.E(pkg, "failed to Get() share key", err)
 	}
 
-	return &RedisLink{
-		ID:   parts[0],
-		Link: parts[1],
-	}, nil
+	return token, nil
 }
 
+// DeleteLink removes the generated link of a deleted page.
 func (r *Redis) DeleteLink(ctx context.Context, userId int64, originalURL string) error {
-	key := fmt.Sprintf("links:%d:%s", userId, originalURL)
+	key := linkKey(userId, originalURL)
 
-	err := r.client.Del(ctx, key).Err()
-	if err != nil {
-		return wrap.E(pkg, "failed to Del() key", err)
+	value, err := r.client.Get(ctx, key).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return wrap.E(pkg, "failed to Get() key", err)
 	}
 
-	globalKey := fmt.Sprintf("links:%d:urls", userId)
-	_, err = r.client.SRem(ctx, globalKey, originalURL).Result()
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if parts := strings.SplitN(value, ":", 2); len(parts) == 2 {
+			pipe.Del(ctx, tokenKey(userId, parts[1]), shareKey(userId, parts[1]))
+		}
+		pipe.SRem(ctx, urlsKey(userId), originalURL)
+		return nil
+	})
 	if err != nil {
-		return wrap.E(pkg, "failed to SRem() globalKey:url", err)
+		return wrap.E(pkg, "failed to delete link key, token key and url", err)
 	}
 
 	return nil
@@ -0,0 +1,129 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return &Redis{client: client}, mr
+}
+
+// saveLegacyLink saves a generated link the way it was saved before
+// share links were signed, with the token key when withToken is set.
+func saveLegacyLink(t *testing.T, mr *miniredis.Miniredis, userId int64, generated, originalURL string, ttl time.Duration, withToken bool) {
+	t.Helper()
+
+	mr.Set(linkKey(userId, originalURL), "7:"+generated)
+	mr.SetTTL(linkKey(userId, originalURL), ttl)
+	mr.SAdd(urlsKey(userId), originalURL)
+	if withToken {
+		mr.Set(tokenKey(userId, generated), originalURL)
+		mr.SetTTL(tokenKey(userId, generated), ttl)
+	}
+}
+
+func TestGetOriginalURL(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+
+	saveLegacyLink(t, mr, 1, "abc", "https://example.com/", time.Hour, true)
+
+	got, ttl, err := r.GetOriginalURL(ctx, 1, "abc")
+	if err != nil || got != "https://example.com/" || ttl != time.Hour {
+		t.Errorf("GetOriginalURL() = %q, %s, %v, want the original url for an hour", got, ttl, err)
+	}
+	if _, _, err := r.GetOriginalURL(ctx, 2, "abc"); !errors.Is(err, redis.Nil) {
+		t.Errorf("GetOriginalURL() of another user err = %v, want redis.Nil", err)
+	}
+	if _, _, err := r.GetOriginalURL(ctx, 1, "other"); !errors.Is(err, redis.Nil) {
+		t.Errorf("GetOriginalURL() of an unknown link err = %v, want redis.Nil", err)
+	}
+
+	mr.FastForward(time.Hour)
+	if _, _, err := r.GetOriginalURL(ctx, 1, "abc"); !errors.Is(err, redis.Nil) {
+		t.Errorf("GetOriginalURL() of an expired link err = %v, want redis.Nil", err)
+	}
+}
+
+// TestGetOriginalURLBackfill checks links saved before the token key
+// existed: they are found and get a token key expiring with them.
+func TestGetOriginalURLBackfill(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+
+	saveLegacyLink(t, mr, 1, "old", "https://example.com/old", 30*time.Minute, false)
+	saveLegacyLink(t, mr, 1, "other", "https://example.com/other", time.Hour, false)
+
+	got, ttl, err := r.GetOriginalURL(ctx, 1, "old")
+	if err != nil || got != "https://example.com/old" || ttl != 30*time.Minute {
+		t.Fatalf("GetOriginalURL() = %q, %s, %v, want the original url for 30 minutes", got, ttl, err)
+	}
+	if v, err := mr.Get(tokenKey(1, "old")); err != nil || v != "https://example.com/old" {
+		t.Errorf("token key = %q, %v, want it backfilled", v, err)
+	}
+	if ttl := mr.TTL(tokenKey(1, "old")); ttl != 30*time.Minute {
+		t.Errorf("TTL of the token key = %s, want the TTL of the link key", ttl)
+	}
+}
+
+func TestShareToken(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+
+	if token, err := r.GetShareToken(ctx, 1, "abc"); err != nil || token != "" {
+		t.Errorf("GetShareToken() of a link without one = %q, %v", token, err)
+	}
+	if err := r.SaveShareToken(ctx, 1, "abc", "token", time.Minute); err != nil {
+		t.Fatalf("SaveShareToken(): %v", err)
+	}
+	if token, err := r.GetShareToken(ctx, 1, "abc"); err != nil || token != "token" {
+		t.Errorf("GetShareToken() = %q, %v, want the saved token", token, err)
+	}
+
+	mr.FastForward(time.Minute)
+	if token, err := r.GetShareToken(ctx, 1, "abc"); err != nil || token != "" {
+		t.Errorf("GetShareToken() after the link expired = %q, %v", token, err)
+	}
+}
+
+func TestDeleteLink(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRedis(t)
+
+	saveLegacyLink(t, mr, 1, "abc", "https://example.com/", time.Hour, true)
+	saveLegacyLink(t, mr, 1, "kept", "https://example.com/kept", time.Hour, true)
+	if err := r.SaveShareToken(ctx, 1, "abc", "token", time.Hour); err != nil {
+		t.Fatalf("SaveShareToken(): %v", err)
+	}
+
+	if err := r.DeleteLink(ctx, 1, "https://example.com/"); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	for _, key := range []string{linkKey(1, "https://example.com/"), tokenKey(1, "abc"), shareKey(1, "abc")} {
+		if mr.Exists(key) {
+			t.Errorf("key %q is kept after DeleteLink()", key)
+		}
+	}
+	if ok, _ := mr.SIsMember(urlsKey(1), "https://example.com/"); ok {
+		t.Error("url is kept in the urls set after DeleteLink()")
+	}
+	if _, _, err := r.GetOriginalURL(ctx, 1, "kept"); err != nil {
+		t.Errorf("GetOriginalURL() of another link after DeleteLink() = %v", err)
+	}
+
+	// pages without a generated link have nothing to delete
+	if err := r.DeleteLink(ctx, 1, "https://example.com/none"); err != nil {
+		t.Errorf("DeleteLink() of a page without a generated link = %v", err)
+	}
+}
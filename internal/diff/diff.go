@@ -0,0 +1,267 @@
+// Package diff compares the text of two versions of a page
+// paragraph by paragraph.
+package diff
+
+import "strings"
+
+type Kind int
+
+const (
+	Equal Kind = iota
+	Added
+	Removed
+	// Changed paragraphs are similar on both sides, Words tells the difference.
+	Changed
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return "equal"
+	}
+}
+
+// maxCells bounds the memory of a single comparison,
+// larger inputs are reported as replaced as a whole.
+const maxCells = 4 << 20
+
+// lookahead is how many added paragraphs are tried as
+// the new version of a removed one.
+const lookahead = 20
+
+// similarity is the share of common words that makes a removed and an
+// added paragraph a single changed one.
+const similarity = 0.5
+
+type Paragraph struct {
+	Kind Kind
+	// Text is the new text, the old one for removed paragraphs.
+	Text string
+	// Words are set for changed paragraphs.
+	Words []Word
+	// Skipped is the number of unchanged paragraphs this one stands for, see Collapse.
+	Skipped int
+}
+
+type Word struct {
+	Kind Kind
+	// Text is a run of words of the same kind.
+	Text string
+}
+
+// Stats counts paragraphs by kind.
+type Stats struct {
+	Added   int
+	Removed int
+	Changed int
+}
+
+// Paragraphs compares old and new text split into paragraphs.
+func Paragraphs(old, new []string) []Paragraph {
+	var out []Paragraph
+	var removed, added []string
+
+	// flush turns a run of edits into changed paragraphs where a removed
+	// one is similar to one of the next added, the rest is kept as is
+	flush := func() {
+		j := 0
+		for _, r := range removed {
+			match := -1
+			var words []Word
+			for k := j; k < len(added) && k < j+lookahead; k++ {
+				if w, ok := changedWords(r, added[k]); ok {
+					match, words = k, w
+					break
+				}
+			}
+			if match < 0 {
+				out = append(out, Paragraph{Kind: Removed, Text: r})
+				continue
+			}
+
+			for ; j < match; j++ {
+				out = append(out, Paragraph{Kind: Added, Text: added[j]})
+			}
+			out = append(out, Paragraph{Kind: Changed, Text: added[match], Words: words})
+			j = match + 1
+		}
+		for _, p := range added[j:] {
+			out = append(out, Paragraph{Kind: Added, Text: p})
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	for _, e := range script(old, new) {
+		switch e.kind {
+		case Equal:
+			flush()
+			out = append(out, Paragraph{Kind: Equal, Text: new[e.j]})
+		case Removed:
+			removed = append(removed, old[e.i])
+		case Added:
+			added = append(added, new[e.j])
+		}
+	}
+	flush()
+
+	return out
+}
+
+// changedWords compares two paragraphs word by word,
+// ok is false when they have too little in common.
+func changedWords(old, new string) ([]Word, bool) {
+	a, b := strings.Fields(old), strings.Fields(new)
+
+	var words []Word
+	common := 0
+	for _, e := range script(a, b) {
+		text := ""
+		switch e.kind {
+		case Equal:
+			common++
+			text = b[e.j]
+		case Removed:
+			text = a[e.i]
+		case Added:
+			text = b[e.j]
+		}
+
+		if last := len(words) - 1; last >= 0 && words[last].Kind == e.kind {
+			words[last].Text += " " + text
+		} else {
+			words = append(words, Word{Kind: e.kind, Text: text})
+		}
+	}
+
+	if total := len(a) + len(b); total == 0 || float64(2*common)/float64(total) < similarity {
+		return nil, false
+	}
+	return words, true
+}
+
+// Collapse replaces runs of unchanged paragraphs with a single one,
+// keeping context paragraphs next to the changes.
+func Collapse(ps []Paragraph, context int) []Paragraph {
+	var out []Paragraph
+	for i := 0; i < len(ps); {
+		if ps[i].Kind != Equal {
+			out = append(out, ps[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(ps) && ps[end].Kind == Equal {
+			end++
+		}
+
+		head, tail := context, context
+		if i == 0 {
+			head = 0
+		}
+		if end == len(ps) {
+			tail = 0
+		}
+		if end-i <= head+tail+1 {
+			out = append(out, ps[i:end]...)
+		} else {
+			out = append(out, ps[i:i+head]...)
+			out = append(out, Paragraph{Kind: Equal, Skipped: end - i - head - tail})
+			out = append(out, ps[end-tail:end]...)
+		}
+		i = end
+	}
+
+	return out
+}
+
+// Count returns the stats of ps.
+func Count(ps []Paragraph) Stats {
+	var s Stats
+	for _, p := range ps {
+		switch p.Kind {
+		case Added:
+			s.Added++
+		case Removed:
+			s.Removed++
+		case Changed:
+			s.Changed++
+		}
+	}
+	return s
+}
+
+type edit struct {
+	kind Kind
+	// i is the index in a for equal and removed items,
+	// j is the index in b for equal and added ones
+	i, j int
+}
+
+// script returns the edits turning a into b along a longest common subsequence.
+func script[T comparable](a, b []T) []edit {
+	var edits []edit
+
+	// common ends are cheap to skip and keep the table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		edits = append(edits, edit{kind: Equal, i: prefix, j: prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	a2, b2 := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(a2), len(b2)
+
+	if (n+1)*(m+1) > maxCells {
+		for i := range a2 {
+			edits = append(edits, edit{kind: Removed, i: prefix + i})
+		}
+		for j := range b2 {
+			edits = append(edits, edit{kind: Added, j: prefix + j})
+		}
+	} else {
+		// lcs[i*(m+1)+j] is the length of the lcs of a2[i:] and b2[j:]
+		lcs := make([]int32, (n+1)*(m+1))
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if a2[i] == b2[j] {
+					lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+				} else {
+					lcs[i*(m+1)+j] = max(lcs[(i+1)*(m+1)+j], lcs[i*(m+1)+j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && a2[i] == b2[j]:
+				edits = append(edits, edit{kind: Equal, i: prefix + i, j: prefix + j})
+				i++
+				j++
+			case j == m || (i < n && lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]):
+				edits = append(edits, edit{kind: Removed, i: prefix + i})
+				i++
+			default:
+				edits = append(edits, edit{kind: Added, j: prefix + j})
+				j++
+			}
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		edits = append(edits, edit{kind: Equal, i: len(a) - k, j: len(b) - k})
+	}
+
+	return edits
+}
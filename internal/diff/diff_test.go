@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParagraphs(t *testing.T) {
+	old := []string{
+		"Intro",
+		"The price is 10 dollars per month",
+		"Old footer",
+		"Contact us",
+	}
+	new := []string{
+		"Intro",
+		"Breaking news",
+		"The price is 12 dollars per month",
+		"Contact us",
+	}
+
+	got := Paragraphs(old, new)
+	want := []Paragraph{
+		{Kind: Equal, Text: "Intro"},
+		{Kind: Added, Text: "Breaking news"},
+		{Kind: Changed, Text: "The price is 12 dollars per month", Words: []Word{
+			{Kind: Equal, Text: "The price is"},
+			{Kind: Removed, Text: "10"},
+			{Kind: Added, Text: "12"},
+			{Kind: Equal, Text: "dollars per month"},
+		}},
+		{Kind: Removed, Text: "Old footer"},
+		{Kind: Equal, Text: "Contact us"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Paragraphs() = %+v\nwant %+v", got, want)
+	}
+
+	if s := Count(got); s != (Stats{Added: 1, Removed: 1, Changed: 1}) {
+		t.Errorf("Count() = %+v", s)
+	}
+}
+
+func TestParagraphsUnrelated(t *testing.T) {
+	got := Paragraphs([]string{"one two three"}, []string{"four five six"})
+	want := []Paragraph{
+		{Kind: Removed, Text: "one two three"},
+		{Kind: Added, Text: "four five six"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Paragraphs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCollapse(t *testing.T) {
+	ps := []Paragraph{
+		{Kind: Equal, Text: "1"},
+		{Kind: Equal, Text: "2"},
+		{Kind: Equal, Text: "3"},
+		{Kind: Added, Text: "new"},
+		{Kind: Equal, Text: "4"},
+		{Kind: Equal, Text: "5"},
+		{Kind: Equal, Text: "6"},
+		{Kind: Equal, Text: "7"},
+		{Kind: Removed, Text: "old"},
+		{Kind: Equal, Text: "8"},
+	}
+
+	got := Collapse(ps, 1)
+	want := []Paragraph{
+		{Kind: Equal, Skipped: 2},
+		{Kind: Equal, Text: "3"},
+		{Kind: Added, Text: "new"},
+		{Kind: Equal, Text: "4"},
+		{Kind: Equal, Skipped: 2},
+		{Kind: Equal, Text: "7"},
+		{Kind: Removed, Text: "old"},
+		{Kind: Equal, Text: "8"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Collapse() = %+v\nwant %+v", got, want)
+	}
+}
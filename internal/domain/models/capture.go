@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	CaptureStatusPending = "pending"
+	CaptureStatusRunning = "running"
+	CaptureStatusDone    = "done"
+	CaptureStatusFailed  = "failed"
+)
+
+type CaptureJob struct {
+	ID          int64  `json:"id" db:"id"`
+	UserID      int64  `json:"user_id" db:"telegram_user_id"`
+	OriginalURL string `json:"original_url" db:"original_url"`
+	Description string `json:"description" db:"description"`
+	Status      string `json:"status" db:"status"`
+	Attempts    int    `json:"attempts" db:"attempts"`
+	Error       string `json:"error" db:"error"`
+	LinkID      int    `json:"link_id" db:"link_id"`
+	// Recapture jobs add a snapshot to LinkID instead of saving a new link
+	Recapture bool `json:"recapture" db:"recapture"`
+	// ImportID is set for jobs of a bookmark import
+	ImportID int64 `json:"import_id" db:"import_id"`
+	// DateAdded, Tags and CollectionID are applied to the saved link,
+	// zero values are left out
+	DateAdded    time.Time `json:"date_added" db:"date_added"`
+	Tags         []string  `json:"tags" db:"tags"`
+	CollectionID int       `json:"collection_id" db:"collection_id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	LinkStatusUnknown = "unknown"
+	LinkStatusOK      = "ok"
+	LinkStatusMoved   = "moved"
+	LinkStatusDead    = "dead"
+)
+
+// LinkCheck is the result of a link-rot check of a saved link.
+type LinkCheck struct {
+	LinkID      int    `json:"link_id" db:"link_id"`
+	OriginalURL string `json:"original_url" db:"original_url"`
+	Description string `json:"description" db:"description"`
+	Status      string `json:"status" db:"status"`
+	// StatusCode is 0 when the server did not answer
+	StatusCode int `json:"status_code" db:"status_code"`
+	// Location is where a moved link redirects to
+	Location string `json:"location" db:"location"`
+	Error    string `json:"error" db:"error"`
+	// Failures is the number of failed checks in a row
+	Failures  int       `json:"failures" db:"check_failures"`
+	CheckedAt time.Time `json:"checked_at" db:"checked_at"`
+	// LastOKAt is zero if the link never answered with success
+	LastOKAt time.Time `json:"last_ok_at" db:"last_ok_at"`
+}
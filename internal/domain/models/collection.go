@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Collection struct {
+	ID     int   `json:"id" db:"id"`
+	UserID int64 `json:"user_id" db:"telegram_user_id"`
+	// ParentID is 0 for top level collections
+	ParentID  int       `json:"parent_id" db:"parent_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Import is a bookmark file queued for capture. Queued links become
+// capture jobs, the progress is counted from their status.
+type Import struct {
+	ID     int64 `json:"id" db:"id"`
+	UserID int64 `json:"user_id" db:"telegram_user_id"`
+	// Total is the number of bookmarks in the file
+	Total      int       `json:"total" db:"total"`
+	Queued     int       `json:"queued" db:"queued"`
+	Duplicates int       `json:"duplicates" db:"duplicates"`
+	Invalid    int       `json:"invalid" db:"invalid"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	Pending int `json:"pending"`
+	Running int `json:"running"`
+	Done    int `json:"done"`
+	Failed  int `json:"failed"`
+}
@@ -8,5 +8,18 @@ type Link struct {
 	UserID      int64     `json:"user_id" db:"telegram_user_id" required:"true"`
 	Description string    `json:"description" db:"description" required:"true"`
 	Content     []byte    `db:"content"`
+	ContentText string    `db:"content_text"`
+	Title       string    `json:"title" db:"title"`
+	Byline      string    `json:"byline" db:"byline"`
+	LeadImage   string    `json:"lead_image" db:"lead_image"`
+	WordCount   int       `json:"word_count" db:"word_count"`
+	Article     string    `db:"article"`
 	DateAdded   time.Time `json:"date_added" db:"date_added"`
+	// CapturedAt is the time of the snapshot the content comes from
+	CapturedAt time.Time `json:"captured_at" db:"captured_at"`
+	// WARC is the capture as a web archive, empty if it was not recorded
+	WARC []byte `db:"warc"`
+	// Tags and CollectionID are set only by the export listing
+	Tags         []string `json:"tags"`
+	CollectionID int      `json:"collection_id" db:"collection_id"`
 }
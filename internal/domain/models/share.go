@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Share is a share link of a saved page. The link itself is a signed
+// token, the share is kept to revoke it.
+type Share struct {
+	ID     int64 `json:"id" db:"id"`
+	LinkID int   `json:"link_id" db:"link_id"`
+	// UserID and OriginalURL are of the shared link
+	UserID      int64  `json:"user_id" db:"telegram_user_id"`
+	OriginalURL string `json:"original_url" db:"original_url"`
+	// ExpiresAt is zero for links that never expire
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+	// RevokedAt is zero unless the link was revoked
+	RevokedAt time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	// OneTime shares stop working after the first view, at UsedAt
+	OneTime bool      `json:"one_time" db:"one_time"`
+	UsedAt  time.Time `json:"used_at" db:"used_at"`
+	// PasswordHash is the bcrypt hash of the password, empty for shares without one
+	PasswordHash string `json:"-" db:"password_hash"`
+}
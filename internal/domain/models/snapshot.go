@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Snapshot is a single capture of a link, a link has one
+// for every time its page was saved.
+type Snapshot struct {
+	ID          int       `json:"id" db:"id"`
+	LinkID      int       `json:"link_id" db:"link_id"`
+	Content     []byte    `db:"content"`
+	ContentText string    `db:"content_text"`
+	Title       string    `json:"title" db:"title"`
+	Byline      string    `json:"byline" db:"byline"`
+	LeadImage   string    `json:"lead_image" db:"lead_image"`
+	WordCount   int       `json:"word_count" db:"word_count"`
+	Article     string    `db:"article"`
+	CapturedAt  time.Time `json:"captured_at" db:"captured_at"`
+	// WARC is the capture as a web archive, empty if it was not recorded
+	WARC []byte `db:"warc"`
+}
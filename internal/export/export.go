@@ -0,0 +1,217 @@
+// Package export writes the library of a user as a zip archive: the
+// archived pages, a manifest in JSON and CSV and a bookmark file.
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/bookmarks"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+var pkg = "export"
+
+// Names of the files in the archive, pages are pages/<link id>.html.
+const (
+	ManifestJSON  = "links.json"
+	ManifestCSV   = "links.csv"
+	BookmarksFile = "bookmarks.html"
+)
+
+// Record is a link in the manifest.
+type Record struct {
+	ID          int      `json:"id"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Title       string   `json:"title"`
+	Tags        []string `json:"tags"`
+	// Collection is the path of collection names from the top,
+	// empty for links out of collections
+	Collection []string  `json:"collection"`
+	DateAdded  time.Time `json:"date_added"`
+	// Page is the file of the archived page, empty if there is none
+	Page string `json:"page"`
+}
+
+// OpenFunc opens the archived page of the link,
+// a nil reader means the link has no page.
+type OpenFunc func(l *models.Link) (io.ReadCloser, error)
+
+// Folders returns the path of names of every collection by its id.
+func Folders(collections []*gen.Collection) map[int][]string {
+	byID := make(map[int32]*gen.Collection, len(collections))
+	for _, c := range collections {
+		byID[c.CollectionId] = c
+	}
+
+	folders := make(map[int][]string, len(collections))
+	for _, c := range collections {
+		var path []string
+		// parents always exist, the depth limit guards against a broken tree
+		for p := c; p != nil && len(path) <= len(collections); p = byID[p.ParentId] {
+			path = append([]string{p.Name}, path...)
+		}
+		folders[int(c.CollectionId)] = path
+	}
+	return folders
+}
+
+// Write writes the archive of links to w, folders are collection paths
+// as returned by Folders. Pages are copied one at a time as they are opened.
+func Write(w io.Writer, links []*models.Link, folders map[int][]string, open OpenFunc) error {
+	zw := zip.NewWriter(w)
+
+	records := make([]*Record, 0, len(links))
+	for _, l := range links {
+		r := &Record{
+			ID:          l.ID,
+			URL:         l.OriginalURL,
+			Description: l.Description,
+			Title:       l.Title,
+			Tags:        l.Tags,
+			Collection:  folders[l.CollectionID],
+			DateAdded:   l.DateAdded.UTC(),
+		}
+		if r.Tags == nil {
+			r.Tags = []string{}
+		}
+		if r.Collection == nil {
+			r.Collection = []string{}
+		}
+
+		page, err := writePage(zw, l, open)
+		if err != nil {
+			return err
+		}
+		r.Page = page
+
+		records = append(records, r)
+	}
+
+	if err := writeJSON(zw, records); err != nil {
+		return err
+	}
+	if err := writeCSV(zw, records); err != nil {
+		return err
+	}
+	if err := writeBookmarks(zw, records); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return wrap.E(pkg, "failed to finish archive", err)
+	}
+	return nil
+}
+
+func create(zw *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to create "+name, err)
+	}
+	return f, nil
+}
+
+// writePage copies the page of l into the archive and returns its name.
+func writePage(zw *zip.Writer, l *models.Link, open OpenFunc) (string, error) {
+	page, err := open(l)
+	if err != nil {
+		return "", wrap.E(pkg, "failed to open page of link "+strconv.Itoa(l.ID), err)
+	}
+	if page == nil {
+		return "", nil
+	}
+	defer page.Close()
+
+	name := "pages/" + strconv.Itoa(l.ID) + ".html"
+	f, err := create(zw, name, l.DateAdded)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, page); err != nil {
+		return "", wrap.E(pkg, "failed to write "+name, err)
+	}
+
+	return name, nil
+}
+
+func writeJSON(zw *zip.Writer, records []*Record) error {
+	f, err := create(zw, ManifestJSON, time.Now())
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(records); err != nil {
+		return wrap.E(pkg, "failed to write "+ManifestJSON, err)
+	}
+	return nil
+}
+
+func writeCSV(zw *zip.Writer, records []*Record) error {
+	f, err := create(zw, ManifestCSV, time.Now())
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(f)
+	cw.Write([]string{"id", "url", "description", "title", "tags", "collection", "date_added", "page"})
+	for _, r := range records {
+		cw.Write([]string{
+			strconv.Itoa(r.ID),
+			r.URL,
+			r.Description,
+			r.Title,
+			// tag names have no spaces
+			strings.Join(r.Tags, " "),
+			strings.Join(r.Collection, "/"),
+			r.DateAdded.Format(time.RFC3339),
+			r.Page,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return wrap.E(pkg, "failed to write "+ManifestCSV, err)
+	}
+	return nil
+}
+
+// writeBookmarks writes the links as a bookmark file to import them
+// into a browser, collections become folders.
+func writeBookmarks(zw *zip.Writer, records []*Record) error {
+	f, err := create(zw, BookmarksFile, time.Now())
+	if err != nil {
+		return err
+	}
+
+	list := make([]*bookmarks.Bookmark, 0, len(records))
+	for _, r := range records {
+		// the description names the link for the user, and it is
+		// what the import reads back from the title
+		list = append(list, &bookmarks.Bookmark{
+			URL:     r.URL,
+			Title:   r.Description,
+			AddDate: r.DateAdded,
+			Folders: r.Collection,
+			Tags:    r.Tags,
+		})
+	}
+
+	if err := bookmarks.Write(f, list); err != nil {
+		return wrap.E(pkg, "failed to write "+BookmarksFile, err)
+	}
+	return nil
+}
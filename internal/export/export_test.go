@@ -0,0 +1,139 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/bookmarks"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+func TestFolders(t *testing.T) {
+	folders := Folders([]*gen.Collection{
+		{CollectionId: 3, ParentId: 1, Name: "Later"},
+		{CollectionId: 1, Name: "Reading"},
+		{CollectionId: 2, Name: "Work"},
+	})
+
+	want := map[int][]string{
+		1: {"Reading"},
+		2: {"Work"},
+		3: {"Reading", "Later"},
+	}
+	if !reflect.DeepEqual(folders, want) {
+		t.Errorf("Folders() = %v, want %v", folders, want)
+	}
+}
+
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader(): %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s): %v", f.Name, err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("ReadAll(%s): %v", f.Name, err)
+		}
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+func TestWrite(t *testing.T) {
+	added := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	links := []*models.Link{
+		{ID: 1, OriginalURL: "https://a.example.com", Description: "a, quoted \"", Title: "Page A",
+			Tags: []string{"docs", "go"}, CollectionID: 3, DateAdded: added},
+		{ID: 2, OriginalURL: "https://b.example.com", Description: "b", DateAdded: added},
+	}
+	folders := map[int][]string{3: {"Reading", "Later"}}
+
+	var buf bytes.Buffer
+	err := Write(&buf, links, folders, func(l *models.Link) (io.ReadCloser, error) {
+		if l.ID == 2 {
+			return nil, nil
+		}
+		return io.NopCloser(strings.NewReader("<html>a</html>")), nil
+	})
+	if err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+
+	files := readArchive(t, buf.Bytes())
+	if len(files) != 4 {
+		t.Errorf("archive has %d files, want a page and 3 manifests", len(files))
+	}
+	if files["pages/1.html"] != "<html>a</html>" {
+		t.Errorf("pages/1.html = %q", files["pages/1.html"])
+	}
+
+	var records []*Record
+	if err := json.Unmarshal([]byte(files[ManifestJSON]), &records); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	want := []*Record{
+		{ID: 1, URL: "https://a.example.com", Description: "a, quoted \"", Title: "Page A",
+			Tags: []string{"docs", "go"}, Collection: []string{"Reading", "Later"}, DateAdded: added, Page: "pages/1.html"},
+		{ID: 2, URL: "https://b.example.com", Description: "b", Tags: []string{}, Collection: []string{}, DateAdded: added},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("%s = %s", ManifestJSON, files[ManifestJSON])
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(files[ManifestCSV])).ReadAll()
+	if err != nil {
+		t.Fatalf("csv.ReadAll(): %v", err)
+	}
+	wantRows := [][]string{
+		{"id", "url", "description", "title", "tags", "collection", "date_added", "page"},
+		{"1", "https://a.example.com", "a, quoted \"", "Page A", "docs go", "Reading/Later", "2020-09-13T12:26:40Z", "pages/1.html"},
+		{"2", "https://b.example.com", "b", "", "", "", "2020-09-13T12:26:40Z", ""},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("%s = %q, want %q", ManifestCSV, rows, wantRows)
+	}
+
+	// the bookmark file imports back into a browser or this service
+	list, err := bookmarks.Parse(strings.NewReader(files[BookmarksFile]))
+	if err != nil {
+		t.Fatalf("bookmarks.Parse(): %v", err)
+	}
+	wantList := []*bookmarks.Bookmark{
+		{URL: "https://b.example.com", Title: "b", AddDate: added},
+		{URL: "https://a.example.com", Title: "a, quoted \"", AddDate: added, Folders: []string{"Reading", "Later"}, Tags: []string{"docs", "go"}},
+	}
+	if !reflect.DeepEqual(list, wantList) {
+		t.Errorf("bookmarks = %+v, want %+v", list, wantList)
+	}
+}
+
+func TestWriteOpenError(t *testing.T) {
+	errOpen := errors.New("store is down")
+	links := []*models.Link{{ID: 1, OriginalURL: "https://a.example.com", Description: "a"}}
+
+	err := Write(io.Discard, links, nil, func(l *models.Link) (io.ReadCloser, error) {
+		return nil, errOpen
+	})
+	if !errors.Is(err, errOpen) {
+		t.Fatalf("Write() err = %v, want the open error", err)
+	}
+}
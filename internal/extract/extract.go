@@ -0,0 +1,162 @@
+// Package extract turns archived pages into readable articles.
+package extract
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"github.com/0x0FACED/link-saver-api/internal/sanitize"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	readability "github.com/go-shiori/go-readability"
+	"golang.org/x/net/html"
+)
+
+var pkg = "extract"
+
+// Article is the main content of a page without navigation, ads and scripts.
+type Article struct {
+	Title     string
+	Byline    string
+	LeadImage string
+	// Content is the cleaned article html, without scripts.
+	Content string
+	// Text is the visible article text, whitespace collapsed.
+	Text      string
+	WordCount int
+}
+
+// FromHTML finds the main article of the page at pageURL.
+// When no article can be detected, Text falls back to
+// the visible text of the whole page and Content stays empty.
+func FromHTML(body []byte, pageURL *url.URL) (*Article, error) {
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to Parse()", err)
+	}
+
+	a := &Article{}
+
+	parsed, err := readability.FromDocument(root, pageURL)
+	if err == nil && strings.TrimSpace(parsed.TextContent) != "" {
+		a.Title = parsed.Title
+		a.Byline = parsed.Byline
+		a.LeadImage = parsed.Image
+		// readability keeps event handlers and javascript: urls
+		if a.Content, err = sanitize.Fragment(parsed.Content); err != nil {
+			return nil, wrap.E(pkg, "failed to sanitize article", err)
+		}
+		a.Text = collapse(parsed.TextContent)
+	} else {
+		a.Title = title(root)
+		a.Text = Text(root)
+	}
+	a.WordCount = len(strings.Fields(a.Text))
+
+	return a, nil
+}
+
+// Text returns the visible text of the document.
+func Text(root *html.Node) string {
+	var words []string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "template":
+				return
+			}
+		}
+		if n.Type == html.TextNode {
+			words = append(words, strings.Fields(n.Data)...)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	return strings.Join(words, " ")
+}
+
+// Paragraphs returns the visible text of the document
+// split at block elements, empty paragraphs are dropped.
+func Paragraphs(root *html.Node) []string {
+	var paragraphs []string
+	var words []string
+
+	flush := func() {
+		if len(words) > 0 {
+			paragraphs = append(paragraphs, strings.Join(words, " "))
+			words = words[:0]
+		}
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		block := false
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "template", "head":
+				return
+			case "br", "hr":
+				flush()
+				return
+			}
+			block = blockElements[n.Data]
+		}
+		if block {
+			flush()
+		}
+		if n.Type == html.TextNode {
+			words = append(words, strings.Fields(n.Data)...)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			flush()
+		}
+	}
+	walk(root)
+	flush()
+
+	return paragraphs
+}
+
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true,
+	"dd": true, "details": true, "dialog": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "li": true, "main": true, "nav": true, "ol": true, "p": true,
+	"pre": true, "section": true, "summary": true, "table": true, "td": true,
+	"th": true, "tr": true, "ul": true,
+}
+
+func title(root *html.Node) string {
+	var walk func(n *html.Node) string
+	walk = func(n *html.Node) string {
+		if n.Type == html.ElementNode && n.Data == "title" {
+			var b strings.Builder
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.TextNode {
+					b.WriteString(c.Data)
+				}
+			}
+			return collapse(b.String())
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if t := walk(c); t != "" {
+				return t
+			}
+		}
+		return ""
+	}
+	return walk(root)
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,105 @@
+package extract
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+var pageURL, _ = url.Parse("https://example.com/post")
+
+// articlePage has a main article with scripts in it between navigation
+// and a footer.
+var articlePage = `<!DOCTYPE html><html><head><title>Saving pages | Blog</title></head><body>
+<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+<article>
+<h1>Saving pages</h1>
+<p class="byline">By Jane Doe</p>
+<p>` + strings.Repeat("Pages on the web change and disappear, so a copy of the page keeps what was read. ", 8) + `</p>
+<p>` + strings.Repeat("A saved copy is only useful when it can be read later without the original site. ", 8) + `</p>
+<p><img src="/chart.png" onerror="steal()"> <a href="javascript:steal()">see more</a><script>steal()</script></p>
+</article>
+<footer>Copyright</footer>
+</body></html>`
+
+func TestFromHTML(t *testing.T) {
+	a, err := FromHTML([]byte(articlePage), pageURL)
+	if err != nil {
+		t.Fatalf("FromHTML(): %v", err)
+	}
+
+	if !strings.Contains(a.Title, "Saving pages") {
+		t.Errorf("Title = %q, want the title of the article", a.Title)
+	}
+	if !strings.Contains(a.Content, "Pages on the web change") || !strings.Contains(a.Content, `src="https://example.com/chart.png"`) {
+		t.Errorf("Content = %q, want the paragraphs of the article", a.Content)
+	}
+	for _, bad := range []string{"steal", "<script", "onerror", "Copyright"} {
+		if strings.Contains(a.Content, bad) {
+			t.Errorf("Content = %q has %q", a.Content, bad)
+		}
+	}
+	if !strings.Contains(a.Text, "Pages on the web change and disappear, so a copy") {
+		t.Errorf("Text = %q, want the text of the article", a.Text)
+	}
+	if strings.Contains(a.Text, "  ") || strings.Contains(a.Text, "\n") {
+		t.Errorf("Text = %q, want whitespace collapsed", a.Text)
+	}
+	if a.WordCount != len(strings.Fields(a.Text)) || a.WordCount < 100 {
+		t.Errorf("WordCount = %d for %d words of text", a.WordCount, len(strings.Fields(a.Text)))
+	}
+}
+
+func TestFromHTMLNoArticle(t *testing.T) {
+	a, err := FromHTML([]byte(`<html><head><title>  Photo
+		page </title><script>x()</script></head><body><img src="photo.jpg"></body></html>`), pageURL)
+	if err != nil {
+		t.Fatalf("FromHTML(): %v", err)
+	}
+	if a.Content != "" {
+		t.Errorf("Content = %q of a page without text, want none", a.Content)
+	}
+	if a.Title != "Photo page" {
+		t.Errorf("Title = %q, want the collapsed <title>", a.Title)
+	}
+	if a.Text != "Photo page" || a.WordCount != 2 {
+		t.Errorf("Text = %q with %d words, want the visible text of the page", a.Text, a.WordCount)
+	}
+}
+
+func TestFromHTMLEmpty(t *testing.T) {
+	for _, body := range []string{"", "<html><body></body></html>"} {
+		a, err := FromHTML([]byte(body), pageURL)
+		if err != nil {
+			t.Fatalf("FromHTML(%q): %v", body, err)
+		}
+		if *a != (Article{}) {
+			t.Errorf("FromHTML(%q) = %+v, want an empty article", body, a)
+		}
+	}
+
+	// a page that is no html at all still gives its text
+	a, err := FromHTML([]byte("\x00\x01\xff\xfe not html"), pageURL)
+	if err != nil {
+		t.Fatalf("FromHTML() of binary data: %v", err)
+	}
+	if !strings.Contains(a.Text, "not html") || a.WordCount != len(strings.Fields(a.Text)) {
+		t.Errorf("FromHTML() of binary data = %+v", a)
+	}
+}
+
+func TestParagraphs(t *testing.T) {
+	root, err := html.Parse(strings.NewReader(`<html><head><title>t</title></head><body>
+		<h1>Title</h1><p>first <b>paragraph</b></p><div>second<br>third</div><p> </p><style>p{}</style></body></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse(): %v", err)
+	}
+
+	got := Paragraphs(root)
+	want := []string{"Title", "first paragraph", "second", "third"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Paragraphs() = %q, want %q", got, want)
+	}
+}
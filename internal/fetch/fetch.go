@@ -0,0 +1,242 @@
+// Package fetch downloads pages for capture. Every call gets its own
+// collector, so concurrent fetches never see each other's results.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/gocolly/colly"
+)
+
+var pkg = "fetch"
+
+const maxRedirects = 10
+
+// ErrInvalidURL is returned when the request could not even be made.
+var ErrInvalidURL = errors.New("invalid url")
+
+// StatusError is returned when the server answered with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d: %v", e.StatusCode, e.Err)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// Page is the result of a single fetch.
+type Page struct {
+	// URL is the address the page was loaded from after redirects.
+	URL         *url.URL
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	// Method, RequestHeader and Header describe the final request and
+	// its response, for web archives.
+	Method        string
+	RequestHeader http.Header
+	Header        http.Header
+	// Date is when the response arrived.
+	Date time.Time
+}
+
+// IsHTML reports whether the page is an html document.
+func (p *Page) IsHTML() bool {
+	return strings.Contains(strings.ToLower(p.ContentType), "html")
+}
+
+type Fetcher struct {
+	// collector is never used directly, only cloned
+	collector *colly.Collector
+	guard     *guard
+	// transport is the guarded backend, shared with Probe
+	transport *http.Transport
+	timeout   time.Duration
+}
+
+// New returns a fetcher that visits only public hosts and the ones
+// from cfg.Allowlist, and gives up on requests longer than cfg.Timeout.
+func New(cfg config.CaptureConfig) (*Fetcher, error) {
+	g, err := newGuard(cfg.Allowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	c := colly.NewCollector(colly.AllowURLRevisit())
+	// clones share the http backend, so it is configured once here.
+	// No proxy is used, it would connect past the guard.
+	t := &http.Transport{
+		DialContext:           g.dialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	c.WithTransport(t)
+	c.SetRequestTimeout(cfg.Timeout)
+	c.RedirectHandler = checkRedirect
+
+	return &Fetcher{
+		collector: c,
+		guard:     g,
+		transport: t,
+		timeout:   cfg.Timeout,
+	}, nil
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return ErrUnsupportedScheme
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
+}
+
+// Check tells whether rawURL may be fetched without fetching it.
+func (f *Fetcher) Check(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return wrap.E(pkg, "failed to parse "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+
+	return f.guard.checkURL(ctx, u)
+}
+
+// Fetch loads rawURL. It is safe for concurrent use.
+func (f *Fetcher) Fetch(rawURL string) (*Page, error) {
+	return f.FetchLimited(rawURL, 0)
+}
+
+// FetchLimited is Fetch that reads at most maxSize bytes of the body,
+// zero keeps the colly default.
+func (f *Fetcher) FetchLimited(rawURL string, maxSize int64) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, ErrUnsupportedScheme
+	}
+
+	c := f.collector.Clone()
+	if maxSize > 0 {
+		c.MaxBodySize = int(min(maxSize, int64(^uint(0)>>1)))
+	}
+
+	var page *Page
+	var fetchErr error
+	statusCode := 0
+	handled := false
+
+	c.OnResponse(func(r *colly.Response) {
+		page = &Page{
+			URL:         r.Request.URL,
+			StatusCode:  r.StatusCode,
+			ContentType: r.Headers.Get("Content-Type"),
+			Body:        r.Body,
+			Method:      r.Request.Method,
+			Header:      *r.Headers,
+			Date:        time.Now(),
+		}
+		if r.Request.Headers != nil {
+			page.RequestHeader = *r.Request.Headers
+		}
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		handled = true
+		statusCode = r.StatusCode
+		fetchErr = err
+	})
+
+	err = c.Visit(rawURL)
+	switch {
+	case handled && statusCode > 0:
+		return nil, &StatusError{StatusCode: statusCode, Err: fetchErr}
+	case handled && (errors.Is(fetchErr, ErrForbiddenAddress) || errors.Is(fetchErr, ErrUnsupportedScheme)):
+		// a redirect led to a forbidden host, there is nothing to retry
+		return nil, wrap.E(pkg, "failed to fetch "+rawURL, errors.Join(ErrInvalidURL, fetchErr))
+	case handled:
+		return nil, wrap.E(pkg, "failed to fetch "+rawURL, fetchErr)
+	case err != nil:
+		// colly rejected the request before sending it
+		return nil, wrap.E(pkg, "failed to visit "+rawURL, errors.Join(ErrInvalidURL, err))
+	case page == nil:
+		return nil, wrap.E(pkg, "no response from "+rawURL, ErrInvalidURL)
+	}
+
+	return page, nil
+}
+
+// FetchContext is FetchLimited that gives up when ctx is done, so a
+// caller can bound a series of fetches with a single deadline.
+func (f *Fetcher) FetchContext(ctx context.Context, rawURL string, maxSize int64) (*Page, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, ErrUnsupportedScheme
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to create request to "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+
+	client := &http.Client{
+		Transport:     f.transport,
+		Timeout:       f.timeout,
+		CheckRedirect: checkRedirect,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrForbiddenAddress) || errors.Is(err, ErrUnsupportedScheme) {
+			return nil, wrap.E(pkg, "failed to fetch "+rawURL, errors.Join(ErrInvalidURL, err))
+		}
+		return nil, wrap.E(pkg, "failed to fetch "+rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Err: errors.New(http.StatusText(resp.StatusCode))}
+	}
+
+	var body io.Reader = resp.Body
+	if maxSize > 0 {
+		body = io.LimitReader(resp.Body, maxSize)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to read "+rawURL, err)
+	}
+
+	return &Page{
+		URL:           resp.Request.URL,
+		StatusCode:    resp.StatusCode,
+		ContentType:   resp.Header.Get("Content-Type"),
+		Body:          data,
+		Method:        resp.Request.Method,
+		RequestHeader: resp.Request.Header,
+		Header:        resp.Header,
+		Date:          time.Now(),
+	}, nil
+}
@@ -0,0 +1,180 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"255.255.255.255", false},
+		{"::1", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestFetchGuard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://[::1]:1/", http.StatusFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	blocked, err := New(config.CaptureConfig{Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	if _, err := blocked.Fetch(srv.URL); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Fetch(%s) = %v, want ErrForbiddenAddress", srv.URL, err)
+	}
+	if err := blocked.Check(context.Background(), "ftp://example.com/"); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Errorf("Check(ftp) = %v, want ErrUnsupportedScheme", err)
+	}
+
+	allowed, err := New(config.CaptureConfig{Timeout: time.Second, Allowlist: []string{"127.0.0.0/8"}})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	page, err := allowed.Fetch(srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch(%s): %v", srv.URL, err)
+	}
+	if string(page.Body) != "ok" {
+		t.Errorf("Fetch(%s) body = %q, want %q", srv.URL, page.Body, "ok")
+	}
+
+	// redirects are checked like the first request
+	if _, err := allowed.Fetch(srv.URL + "/redirect"); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("Fetch(redirect) = %v, want ErrInvalidURL", err)
+	}
+}
+
+func TestFetchContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		default:
+			w.Header().Set("Content-Type", "text/css")
+			w.Write([]byte("body { color: red }"))
+		}
+	}))
+	defer srv.Close()
+
+	f, err := New(config.CaptureConfig{Timeout: 5 * time.Second, Allowlist: []string{"127.0.0.0/8"}})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+
+	page, err := f.FetchContext(context.Background(), srv.URL+"/style.css", 4)
+	if err != nil {
+		t.Fatalf("FetchContext(): %v", err)
+	}
+	if string(page.Body) != "body" || page.ContentType != "text/css" {
+		t.Errorf("FetchContext() = %q %s, want %q text/css", page.Body, page.ContentType, "body")
+	}
+
+	var statusErr *StatusError
+	if _, err := f.FetchContext(context.Background(), srv.URL+"/gone", 0); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("FetchContext(gone) = %v, want status 404", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := f.FetchContext(ctx, srv.URL+"/slow", 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FetchContext(slow) = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/found":
+			http.Redirect(w, r, "/moved", http.StatusFound)
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte("ok"))
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	f, err := New(config.CaptureConfig{Timeout: time.Second, Allowlist: []string{"127.0.0.0/8"}})
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+
+	tests := []struct {
+		path      string
+		code      int
+		final     string
+		permanent bool
+	}{
+		{"/", http.StatusOK, "/", false},
+		{"/gone", http.StatusNotFound, "/gone", false},
+		{"/nohead", http.StatusOK, "/nohead", false},
+		{"/moved", http.StatusOK, "/new", true},
+		{"/found", http.StatusOK, "/new", false},
+	}
+
+	for _, tt := range tests {
+		p, err := f.Probe(context.Background(), srv.URL+tt.path)
+		if err != nil {
+			t.Errorf("Probe(%s): %v", tt.path, err)
+			continue
+		}
+		if p.StatusCode != tt.code || p.URL.Path != tt.final || p.Permanent != tt.permanent {
+			t.Errorf("Probe(%s) = %d %s permanent=%v, want %d %s permanent=%v",
+				tt.path, p.StatusCode, p.URL.Path, p.Permanent, tt.code, tt.final, tt.permanent)
+		}
+	}
+
+	if _, err := f.Probe(context.Background(), "http://[::1]:1/"); err == nil {
+		t.Errorf("Probe(unreachable) = nil, want error")
+	}
+}
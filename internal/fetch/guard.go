@@ -0,0 +1,172 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+var (
+	// ErrUnsupportedScheme is returned for urls that are not http or https.
+	ErrUnsupportedScheme = errors.New("only http and https links are supported")
+	// ErrForbiddenAddress is returned when a host resolves to a non-public address.
+	ErrForbiddenAddress = errors.New("host resolves to a non-public address")
+)
+
+// nonPublic lists special purpose ranges that netip has no predicate for.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// guard decides which hosts the fetcher may connect to.
+type guard struct {
+	// hosts and prefixes are trusted even when they are not public
+	hosts    map[string]bool
+	prefixes []netip.Prefix
+	resolver *net.Resolver
+	dialer   *net.Dialer
+}
+
+// newGuard parses the allowlist, every entry is a hostname, an ip or a cidr.
+func newGuard(allowlist []string) (*guard, error) {
+	g := &guard{
+		hosts:    make(map[string]bool),
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{},
+	}
+
+	for _, entry := range allowlist {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case strings.Contains(entry, "/"):
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, wrap.E(pkg, "invalid allowlist entry "+entry, err)
+			}
+			g.prefixes = append(g.prefixes, prefix.Masked())
+		default:
+			if addr, err := netip.ParseAddr(entry); err == nil {
+				g.prefixes = append(g.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+				continue
+			}
+			g.hosts[strings.TrimSuffix(entry, ".")] = true
+		}
+	}
+
+	return g, nil
+}
+
+// checkURL rejects urls the fetcher must not visit. The addresses are
+// checked again on every dial, so redirects and rebinding are covered too.
+func (g *guard) checkURL(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrUnsupportedScheme
+	}
+	if u.Hostname() == "" {
+		return wrap.E(pkg, "no host in "+u.String(), ErrInvalidURL)
+	}
+
+	_, err := g.resolve(ctx, u.Hostname())
+	return err
+}
+
+// dialContext connects only to the addresses it has checked itself,
+// so a host can't resolve to another address between check and dial.
+func (g *guard) dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, wrap.E(pkg, "invalid address "+addr, err)
+	}
+
+	addrs, err := g.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialErr error
+	for _, a := range addrs {
+		conn, err := g.dialer.DialContext(ctx, network, net.JoinHostPort(a.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+
+	return nil, dialErr
+}
+
+// resolve returns the addresses of host, it fails if any of them is not allowed.
+func (g *guard) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		addrs, err = g.resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return nil, wrap.E(pkg, "failed to resolve "+host, err)
+		}
+	}
+
+	if g.hosts[host] {
+		return addrs, nil
+	}
+	for _, addr := range addrs {
+		if !g.allowed(addr) {
+			return nil, wrap.E(pkg, host+" -> "+addr.String(), ErrForbiddenAddress)
+		}
+	}
+
+	return addrs, nil
+}
+
+func (g *guard) allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range g.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return isPublic(addr)
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}) {
+		return false
+	}
+	for _, prefix := range nonPublic {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+// Probe is the result of checking that a page is still there.
+type Probe struct {
+	StatusCode int
+	// URL is the address the answer came from after redirects.
+	URL *url.URL
+	// Permanent is true when every redirect on the way was a permanent
+	// one (301 or 308), so the page has moved to URL for good.
+	Permanent bool
+}
+
+// Probe asks for rawURL without downloading the page. It sends a HEAD
+// request and falls back to GET for servers that refuse HEAD. Any status
+// is a result, only a failure to get an answer is an error.
+func (f *Fetcher) Probe(ctx context.Context, rawURL string) (*Probe, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, ErrUnsupportedScheme
+	}
+
+	p, err := f.probe(ctx, http.MethodHead, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if p.StatusCode == http.StatusMethodNotAllowed || p.StatusCode == http.StatusNotImplemented {
+		return f.probe(ctx, http.MethodGet, rawURL)
+	}
+
+	return p, nil
+}
+
+func (f *Fetcher) probe(ctx context.Context, method, rawURL string) (*Probe, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to create request to "+rawURL, errors.Join(ErrInvalidURL, err))
+	}
+
+	permanent := true
+	redirected := false
+	client := &http.Client{
+		Transport: f.transport,
+		Timeout:   f.timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			redirected = true
+			if code := req.Response.StatusCode; code != http.StatusMovedPermanently && code != http.StatusPermanentRedirect {
+				permanent = false
+			}
+			return checkRedirect(req, via)
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrForbiddenAddress) || errors.Is(err, ErrUnsupportedScheme) {
+			return nil, wrap.E(pkg, "failed to probe "+rawURL, errors.Join(ErrInvalidURL, err))
+		}
+		return nil, wrap.E(pkg, "failed to probe "+rawURL, err)
+	}
+	// the body is never read, closing drops the connection for a GET
+	resp.Body.Close()
+
+	return &Probe{
+		StatusCode: resp.StatusCode,
+		URL:        resp.Request.URL,
+		Permanent:  redirected && permanent,
+	}, nil
+}
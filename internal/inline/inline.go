@@ -0,0 +1,311 @@
+// Package inline turns a saved page into a single self-contained
+// html document by embedding its stylesheets, images and fonts.
+package inline
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var pkg = "inline"
+
+// ErrTooLarge is returned when the page with its assets exceeds the size cap.
+var ErrTooLarge = errors.New("page with assets exceeds the size cap")
+
+// maxImportDepth limits nested css @import chains.
+const maxImportDepth = 3
+
+var cssURLRe = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+?)(['"]?)\s*\)`)
+var cssImportRe = regexp.MustCompile(`@import\s+(?:url\(\s*)?['"]?([^'")\s;]+)['"]?\s*\)?([^;]*);`)
+
+type Inliner struct {
+	fetcher *fetch.Fetcher
+	maxSize int64
+}
+
+// New returns an inliner that loads assets with fetcher
+// and gives up on documents larger than maxSize bytes.
+func New(fetcher *fetch.Fetcher, maxSize int64) *Inliner {
+	return &Inliner{
+		fetcher: fetcher,
+		maxSize: maxSize,
+	}
+}
+
+// Inline embeds the assets of page, which was loaded from pageURL.
+// Assets that fail to load keep their original urls. When ctx is done
+// before all assets are loaded, Inline gives up with the ctx error.
+func (i *Inliner) Inline(ctx context.Context, page []byte, pageURL *url.URL) ([]byte, error) {
+	if int64(len(page)) > i.maxSize {
+		// no budget is left for assets
+		return nil, ErrTooLarge
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse page", err)
+	}
+
+	base := pageURL
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := pageURL.Parse(href); err == nil {
+			base = u
+		}
+	}
+
+	c := &capture{
+		ctx:     ctx,
+		fetcher: i.fetcher,
+		budget:  i.maxSize - int64(len(page)),
+		cache:   make(map[string]string),
+	}
+
+	doc.Find("style").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		setRawText(s.Nodes[0], c.inlineCSS(s.Text(), base, 0))
+		return c.err == nil
+	})
+
+	doc.Find("[style]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		s.SetAttr("style", c.inlineCSS(s.AttrOr("style", ""), base, 0))
+		return c.err == nil
+	})
+
+	// stylesheets go after <style> elements, their css is inlined on the way
+	doc.Find("link[href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		rel := strings.Fields(strings.ToLower(s.AttrOr("rel", "")))
+		switch {
+		case contains(rel, "stylesheet"):
+			c.inlineStylesheet(s, base)
+		case contains(rel, "icon"):
+			c.inlineAttr(s, "href", base)
+		case contains(rel, "preload"), contains(rel, "prefetch"), contains(rel, "modulepreload"):
+			s.Remove()
+		}
+		return c.err == nil
+	})
+
+	doc.Find("img[src], input[type=image][src], video[poster]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if s.Is("video") {
+			c.inlineAttr(s, "poster", base)
+		} else {
+			c.inlineAttr(s, "src", base)
+			// src is embedded, so the browser must not pick a remote candidate
+			s.RemoveAttr("srcset")
+			s.RemoveAttr("sizes")
+		}
+		return c.err == nil
+	})
+	doc.Find("picture source[srcset]").Remove()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	out, err := doc.Html()
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to render page", err)
+	}
+	if int64(len(out)) > i.maxSize {
+		return nil, ErrTooLarge
+	}
+
+	return []byte(out), nil
+}
+
+// capture holds the state of a single Inline call.
+type capture struct {
+	ctx     context.Context
+	fetcher *fetch.Fetcher
+	budget  int64
+	// cache maps asset urls to their data uris
+	cache map[string]string
+	err   error
+}
+
+func (c *capture) inlineStylesheet(s *goquery.Selection, base *url.URL) {
+	u, err := base.Parse(s.AttrOr("href", ""))
+	if err != nil {
+		return
+	}
+
+	css, _, err := c.fetch(u)
+	if err != nil || !c.spend(int64(len(css))) {
+		return
+	}
+
+	style := &html.Node{
+		Type:     html.ElementNode,
+		Data:     "style",
+		DataAtom: atom.Style,
+	}
+	if media, ok := s.Attr("media"); ok {
+		style.Attr = append(style.Attr, html.Attribute{Key: "media", Val: media})
+	}
+	setRawText(style, c.inlineCSS(string(css), u, 0))
+
+	s.ReplaceWithNodes(style)
+}
+
+// setRawText replaces the children of n with css text, which is not
+// escaped when rendered inside <style>. "</" would end the element early,
+// "<\/" is the same in css.
+func setRawText(n *html.Node, text string) {
+	for n.FirstChild != nil {
+		n.RemoveChild(n.FirstChild)
+	}
+	n.AppendChild(&html.Node{
+		Type: html.TextNode,
+		Data: strings.ReplaceAll(text, "</", `<\/`),
+	})
+}
+
+func (c *capture) inlineAttr(s *goquery.Selection, attr string, base *url.URL) {
+	raw, _ := s.Attr(attr)
+	if raw == "" || strings.HasPrefix(raw, "data:") {
+		return
+	}
+
+	u, err := base.Parse(raw)
+	if err != nil {
+		return
+	}
+
+	if data, ok := c.dataURI(u); ok {
+		s.SetAttr(attr, data)
+	}
+}
+
+// inlineCSS embeds the resources referenced by css loaded from base.
+func (c *capture) inlineCSS(css string, base *url.URL, depth int) string {
+	if depth < maxImportDepth {
+		css = cssImportRe.ReplaceAllStringFunc(css, func(m string) string {
+			if c.err != nil {
+				return m
+			}
+			sub := cssImportRe.FindStringSubmatch(m)
+			u, err := base.Parse(sub[1])
+			if err != nil {
+				return m
+			}
+			imported, _, err := c.fetch(u)
+			if err != nil || !c.spend(int64(len(imported))) {
+				return m
+			}
+			nested := c.inlineCSS(string(imported), u, depth+1)
+			if media := strings.TrimSpace(sub[2]); media != "" {
+				return "@media " + media + "{" + nested + "}"
+			}
+			return nested
+		})
+	}
+
+	return cssURLRe.ReplaceAllStringFunc(css, func(m string) string {
+		if c.err != nil {
+			return m
+		}
+		sub := cssURLRe.FindStringSubmatch(m)
+		ref := strings.TrimSpace(sub[2])
+		if ref == "" || strings.HasPrefix(ref, "data:") || strings.HasPrefix(ref, "#") {
+			return m
+		}
+		u, err := base.Parse(ref)
+		if err != nil {
+			return m
+		}
+		data, ok := c.dataURI(u)
+		if !ok {
+			return m
+		}
+		return `url("` + data + `")`
+	})
+}
+
+func (c *capture) dataURI(u *url.URL) (string, bool) {
+	key := u.String()
+	if data, ok := c.cache[key]; ok {
+		return data, true
+	}
+
+	body, contentType, err := c.fetch(u)
+	if err != nil {
+		return "", false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(path.Ext(u.Path)); byExt != "" {
+			mediaType = byExt
+		} else {
+			mediaType = http.DetectContentType(body)
+		}
+	}
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+
+	data := "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(body)
+	if !c.spend(int64(len(data))) {
+		return "", false
+	}
+	c.cache[key] = data
+
+	return data, true
+}
+
+func (c *capture) spend(n int64) bool {
+	c.budget -= n
+	if c.budget < 0 {
+		c.err = ErrTooLarge
+		return false
+	}
+	return true
+}
+
+// fetch loads a single asset, it never reads more than the remaining budget.
+func (c *capture) fetch(u *url.URL) ([]byte, string, error) {
+	if c.err != nil {
+		return nil, "", c.err
+	}
+	if err := c.ctx.Err(); err != nil {
+		c.err = wrap.E(pkg, "gave up on assets", err)
+		return nil, "", c.err
+	}
+
+	page, err := c.fetcher.FetchContext(c.ctx, u.String(), c.budget+1)
+	if err != nil {
+		if ctxErr := c.ctx.Err(); ctxErr != nil {
+			// the deadline is gone, the other assets would fail the same way
+			c.err = wrap.E(pkg, "gave up on assets", ctxErr)
+			return nil, "", c.err
+		}
+		return nil, "", wrap.E(pkg, "failed to fetch "+u.String(), err)
+	}
+	body := page.Body
+	if int64(len(body)) > c.budget {
+		c.err = ErrTooLarge
+		return nil, "", c.err
+	}
+
+	return body, page.ContentType, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package inline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"golang.org/x/net/html"
+)
+
+// assetServer serves a stylesheet, an image and a large image, and counts
+// the requests.
+func assetServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/style.css":
+			w.Header().Set("Content-Type", "text/css")
+			w.Write([]byte(`body { background: url("dot.png") } a::after { content: "</style><script>alert(1)</script>" }`))
+		case "/dot.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG dot"))
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte(strings.Repeat("x", 4096)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newTestInliner(t *testing.T, maxSize int64) *Inliner {
+	t.Helper()
+
+	fetcher, err := fetch.New(config.CaptureConfig{
+		Timeout: 5 * time.Second,
+		// httptest servers listen on loopback
+		Allowlist: []string{"127.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("fetch.New(): %v", err)
+	}
+	return New(fetcher, maxSize)
+}
+
+func TestInline(t *testing.T) {
+	srv, _ := assetServer(t)
+	pageURL, _ := url.Parse(srv.URL + "/page.html")
+	page := `<html><head><link rel="stylesheet" href="style.css"></head>` +
+		`<body><img src="dot.png" srcset="dot.png 2x"><img src="missing.png"></body></html>`
+
+	out, err := newTestInliner(t, 1<<20).Inline(context.Background(), []byte(page), pageURL)
+	if err != nil {
+		t.Fatalf("Inline(): %v", err)
+	}
+	got := string(out)
+
+	for _, want := range []string{
+		`<style>body { background: url("data:image/png;base64,`,
+		`<img src="data:image/png;base64,iVBORyBkb3Q="/>`,
+		`<img src="missing.png"/>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Inline() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "stylesheet") || strings.Contains(got, "srcset") {
+		t.Errorf("Inline() = %q keeps the remote stylesheet or srcset", got)
+	}
+}
+
+// TestInlineStyleEnd checks that a stylesheet with "</style>" stays in
+// its element.
+func TestInlineStyleEnd(t *testing.T) {
+	srv, _ := assetServer(t)
+	pageURL, _ := url.Parse(srv.URL + "/")
+	page := `<html><head><link rel="stylesheet" href="/style.css"></head><body></body></html>`
+
+	out, err := newTestInliner(t, 1<<20).Inline(context.Background(), []byte(page), pageURL)
+	if err != nil {
+		t.Fatalf("Inline(): %v", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(string(out)))
+	if err != nil {
+		t.Fatalf("html.Parse(): %v", err)
+	}
+	var styles int
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "style":
+				styles++
+			case "script", "img":
+				t.Errorf("Inline() = %q, the css ends its <style> and adds a <%s>", out, n.Data)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	if styles != 1 {
+		t.Errorf("Inline() = %q has %d <style> elements, want 1", out, styles)
+	}
+}
+
+func TestInlineTooLarge(t *testing.T) {
+	srv, requests := assetServer(t)
+	pageURL, _ := url.Parse(srv.URL + "/")
+
+	// the page alone is over the cap, nothing is fetched for it
+	page := `<html><body><img src="/dot.png">` + strings.Repeat("x", 2048) + `</body></html>`
+	if _, err := newTestInliner(t, 1024).Inline(context.Background(), []byte(page), pageURL); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Inline() of a page over the cap err = %v, want ErrTooLarge", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("Inline() of a page over the cap made %d requests, want none", n)
+	}
+
+	// the assets are over what is left of the cap
+	page = `<html><body><img src="/large.png"></body></html>`
+	if _, err := newTestInliner(t, 1024).Inline(context.Background(), []byte(page), pageURL); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Inline() with assets over the cap err = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestInlineDeadline(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG dot"))
+	}))
+	t.Cleanup(srv.Close)
+	pageURL, _ := url.Parse(srv.URL + "/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	page := `<html><body><img src="/1.png"><img src="/2.png"><img src="/3.png"></body></html>`
+	start := time.Now()
+	_, err := newTestInliner(t, 1<<20).Inline(ctx, []byte(page), pageURL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Inline() past the deadline err = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("Inline() past the deadline took %v, want it to stop at the deadline", d)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Inline() past the deadline made %d requests, want 1", n)
+	}
+}
@@ -73,6 +73,13 @@ func New(cfg config.LoggerConfig) *ZapLogger {
 	}
 }
 
+// NewNop returns a logger that discards everything.
+func NewNop() *ZapLogger {
+	return &ZapLogger{
+		log: zap.NewNop(),
+	}
+}
+
 func level(lvl string) (int8, error) {
 	parsedInt, err := strconv.ParseInt(lvl, 10, 8) // 10 - основание, 8 - разрядность
 	if err != nil {
@@ -0,0 +1,249 @@
+// Package rewrite makes archived html work away from where it was saved.
+// Relative urls of the page would resolve against the host it is served
+// from, so they are made absolute against the original url as the page
+// is copied, and a banner can be put at the top of the body. Tags that
+// need no change are kept byte for byte.
+package rewrite
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	cssURLRe    = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+?)(['"]?)\s*\)`)
+	cssImportRe = regexp.MustCompile(`@import\s+(['"])([^'"]+)(['"])`)
+)
+
+// urlAttrs hold a single url.
+var urlAttrs = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"poster":     true,
+	"background": true,
+	"cite":       true,
+	"xlink:href": true,
+}
+
+// headTags may come before the body without starting it, the banner goes
+// before any other tag when the page has no <body>.
+var headTags = map[atom.Atom]bool{
+	atom.Html:     true,
+	atom.Head:     true,
+	atom.Title:    true,
+	atom.Base:     true,
+	atom.Link:     true,
+	atom.Meta:     true,
+	atom.Style:    true,
+	atom.Script:   true,
+	atom.Noscript: true,
+	atom.Template: true,
+}
+
+// Copy writes the html of src to dst with urls made absolute against
+// base, the url the page was saved from, or the page's own <base>.
+// The banner html is written at the top of the body unless it is nil.
+func Copy(dst io.Writer, src io.Reader, base *url.URL, banner []byte) error {
+	z := html.NewTokenizer(src)
+
+	var (
+		// last is the element of the previous start tag
+		last atom.Atom
+		// bannerDone is set once the banner is written or can't be
+		bannerDone = banner == nil
+	)
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			if !bannerDone {
+				// a page of text only, the banner still shows
+				_, err := dst.Write(banner)
+				return err
+			}
+			return nil
+		}
+
+		prev := last
+		last = 0
+
+		var out []byte
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			raw := append([]byte(nil), z.Raw()...)
+			t := z.Token()
+			last = t.DataAtom
+
+			if !bannerDone {
+				switch {
+				case t.DataAtom == atom.Frameset:
+					// frames replace the body, the banner would hide them
+					bannerDone = true
+				case !headTags[t.DataAtom] && t.DataAtom != atom.Body:
+					if _, err := dst.Write(banner); err != nil {
+						return err
+					}
+					bannerDone = true
+				}
+			}
+
+			if changed := rewriteAttrs(&t, base); changed {
+				out = []byte(t.String())
+			} else {
+				out = raw
+			}
+			if t.DataAtom == atom.Base {
+				// the page's own base applies to the urls after it
+				if href, ok := attr(t.Attr, "href"); ok {
+					if u, err := url.Parse(href); err == nil {
+						base = u
+					}
+				}
+			}
+
+			if !bannerDone && t.DataAtom == atom.Body {
+				out = append(out, banner...)
+				bannerDone = true
+			}
+
+		case html.TextToken:
+			switch prev {
+			case atom.Style:
+				out = []byte(rewriteCSS(string(z.Raw()), base))
+			case atom.Noscript:
+				// noscript is raw text to the tokenizer, but browsers
+				// without scripts show it as markup
+				var buf bytes.Buffer
+				if err := Copy(&buf, bytes.NewReader(z.Raw()), base, nil); err != nil {
+					return err
+				}
+				out = buf.Bytes()
+			default:
+				out = z.Raw()
+			}
+
+		default:
+			out = z.Raw()
+		}
+
+		if _, err := dst.Write(out); err != nil {
+			return err
+		}
+	}
+}
+
+// rewriteAttrs makes the urls in the attributes of t absolute and reports
+// whether any changed.
+func rewriteAttrs(t *html.Token, base *url.URL) bool {
+	changed := false
+	for i, a := range t.Attr {
+		var v string
+		switch {
+		case urlAttrs[a.Key] || (a.Key == "data" && t.DataAtom == atom.Object):
+			v = resolve(a.Val, base)
+		case a.Key == "srcset" || a.Key == "imagesrcset":
+			v = rewriteSrcset(a.Val, base)
+		case a.Key == "style":
+			v = rewriteCSS(a.Val, base)
+		default:
+			continue
+		}
+		if v != a.Val {
+			t.Attr[i].Val = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// resolve returns ref as an absolute url. Links within the page and refs
+// that do not parse are kept.
+func resolve(ref string, base *url.URL) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil || u.IsAbs() {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
+
+// rewriteSrcset resolves every candidate url of a srcset, which are
+// separated by commas but may contain them as well.
+func rewriteSrcset(srcset string, base *url.URL) string {
+	var candidates []string
+	rest := srcset
+	for {
+		rest = strings.TrimLeft(rest, " \t\n\r\f,")
+		if rest == "" {
+			break
+		}
+
+		end := strings.IndexAny(rest, " \t\n\r\f")
+		if end < 0 {
+			end = len(rest)
+		}
+		ref := rest[:end]
+		rest = rest[end:]
+
+		var descriptor string
+		if trimmed := strings.TrimRight(ref, ","); trimmed != ref {
+			// a comma right after the url ends the candidate
+			ref = trimmed
+		} else if i := strings.IndexByte(rest, ','); i >= 0 {
+			descriptor, rest = rest[:i], rest[i+1:]
+		} else {
+			descriptor, rest = rest, ""
+		}
+
+		c := resolve(ref, base)
+		if descriptor = strings.TrimSpace(descriptor); descriptor != "" {
+			c += " " + descriptor
+		}
+		candidates = append(candidates, c)
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// rewriteCSS resolves the url() and @import references of css.
+func rewriteCSS(css string, base *url.URL) string {
+	if !strings.Contains(css, "url(") && !strings.Contains(css, "@import") {
+		return css
+	}
+
+	css = cssImportRe.ReplaceAllStringFunc(css, func(m string) string {
+		sub := cssImportRe.FindStringSubmatch(m)
+		return `@import "` + resolve(sub[2], base) + `"`
+	})
+	return cssURLRe.ReplaceAllStringFunc(css, func(m string) string {
+		sub := cssURLRe.FindStringSubmatch(m)
+		ref := strings.TrimSpace(sub[2])
+		u := resolve(ref, base)
+		if u == ref {
+			return m
+		}
+		return `url("` + u + `")`
+	})
+}
+
+func attr(attrs []html.Attribute, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
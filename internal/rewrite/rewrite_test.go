@@ -0,0 +1,100 @@
+package rewrite
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/post.html?x=1")
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "unchanged",
+			in:   `<!DOCTYPE html><p class=x>text <a href="#top">top</a> <a href="https://other.com/">other</a> <img src="data:image/png;base64,AA=="></p>`,
+			want: `<!DOCTYPE html><p class=x>text <a href="#top">top</a> <a href="https://other.com/">other</a> <img src="data:image/png;base64,AA=="></p>`,
+		},
+		{
+			name: "attributes",
+			in:   `<link rel=stylesheet href="../s.css"><a href="next.html">n</a><img src="/i.png"><form action="?q"></form><script src="//cdn.com/a.js"></script>`,
+			want: `<link rel="stylesheet" href="https://example.com/s.css"><a href="https://example.com/blog/next.html">n</a><img src="https://example.com/i.png"><form action="https://example.com/blog/post.html?q"></form><script src="https://cdn.com/a.js"></script>`,
+		},
+		{
+			name: "srcset",
+			in:   `<img srcset="a.png 1x,b.png 2x, data:image/png;base64,AA==, https://x.com/c,d.png 3x">`,
+			want: `<img srcset="https://example.com/blog/a.png 1x, https://example.com/blog/b.png 2x, data:image/png;base64,AA==, https://x.com/c,d.png 3x">`,
+		},
+		{
+			name: "css",
+			in:   `<style>@import "print.css"; body { background: url(bg.png) } i { background: url('data:image/png;base64,AA==') }</style><div style="background: url(&quot;/d.png&quot;)"></div>`,
+			want: `<style>@import "https://example.com/blog/print.css"; body { background: url("https://example.com/blog/bg.png") } i { background: url('data:image/png;base64,AA==') }</style><div style="background: url(&#34;https://example.com/d.png&#34;)"></div>`,
+		},
+		{
+			name: "own base",
+			in:   `<head><base href="/docs/"></head><a href="a.html">a</a>`,
+			want: `<head><base href="https://example.com/docs/"></head><a href="https://example.com/docs/a.html">a</a>`,
+		},
+		{
+			name: "noscript",
+			in:   `<noscript><img src="p.gif"></noscript>`,
+			want: `<noscript><img src="https://example.com/blog/p.gif"></noscript>`,
+		},
+	}
+
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := Copy(&out, strings.NewReader(tt.in), base, nil); err != nil {
+			t.Fatalf("%s: Copy(): %v", tt.name, err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s: Copy() =\n%q, want\n%q", tt.name, out.String(), tt.want)
+		}
+	}
+}
+
+func TestCopyBanner(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+	banner := []byte(`<div id="banner"></div>`)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "body",
+			in:   `<html><head><title>t</title></head><body class="x"><p>text</p></body></html>`,
+			want: `<html><head><title>t</title></head><body class="x"><div id="banner"></div><p>text</p></body></html>`,
+		},
+		{
+			name: "no body",
+			in:   `<meta charset="utf-8"><p>text</p>`,
+			want: `<meta charset="utf-8"><div id="banner"></div><p>text</p>`,
+		},
+		{
+			name: "text only",
+			in:   `text`,
+			want: `text<div id="banner"></div>`,
+		},
+		{
+			name: "frames",
+			in:   `<html><frameset><frame src="a.html"></frameset></html>`,
+			want: `<html><frameset><frame src="https://example.com/a.html"></frameset></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		var out strings.Builder
+		if err := Copy(&out, strings.NewReader(tt.in), base, banner); err != nil {
+			t.Fatalf("%s: Copy(): %v", tt.name, err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s: Copy() = %q, want %q", tt.name, out.String(), tt.want)
+		}
+	}
+}
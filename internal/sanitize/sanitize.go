@@ -0,0 +1,212 @@
+// Package sanitize strips scripts from archived html as it is served.
+// Pages are parsed into the tree a browser would build and rendered back,
+// a token stream alone misreads markup such as raw text elements inside
+// <svg> and <math>, which browsers turn into live elements.
+package sanitize
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// dropped elements are left out with everything in them.
+var dropped = map[atom.Atom]bool{
+	atom.Script: true,
+	atom.Object: true,
+	atom.Embed:  true,
+	atom.Applet: true,
+}
+
+// rawText elements keep their text as is. In <svg> and <math> the same
+// names hold markup instead, so they are dropped there.
+var rawText = map[atom.Atom]bool{
+	atom.Style:     true,
+	atom.Script:    true,
+	atom.Xmp:       true,
+	atom.Iframe:    true,
+	atom.Noembed:   true,
+	atom.Noframes:  true,
+	atom.Noscript:  true,
+	atom.Plaintext: true,
+}
+
+// urlAttrs hold urls that would run a script with a javascript: scheme.
+var urlAttrs = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"data":       true,
+	"poster":     true,
+	"background": true,
+}
+
+// animateAttrs of svg animations set other attributes, such as href,
+// to the urls they hold.
+var animateAttrs = map[string]bool{
+	"to":     true,
+	"from":   true,
+	"by":     true,
+	"values": true,
+}
+
+// Copy writes the html document of src to dst without scripts, plugins,
+// event handlers, javascript: urls, refresh redirects and comments.
+// Sanitized pages never run scripts, so <noscript> content is shown.
+func Copy(dst io.Writer, src io.Reader) error {
+	doc, err := html.ParseWithOptions(src, html.ParseOptionEnableScripting(false))
+	if err != nil {
+		return err
+	}
+	clean(doc)
+	return html.Render(dst, doc)
+}
+
+// Fragment returns the html fragment s sanitized as with Copy, for
+// markup that goes into the body of another page.
+func Fragment(s string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragmentWithOptions(strings.NewReader(s), body, html.ParseOptionEnableScripting(false))
+	if err != nil {
+		return "", err
+	}
+	for _, n := range nodes {
+		body.AppendChild(n)
+	}
+	clean(body)
+
+	var b strings.Builder
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&b, c); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// clean removes what can run code from the children of n.
+func clean(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+
+		switch {
+		case c.Type == html.CommentNode:
+			n.RemoveChild(c)
+
+		case c.Type != html.ElementNode:
+
+		case isDropped(c):
+			n.RemoveChild(c)
+
+		case c.Namespace == "" && c.DataAtom == atom.Noscript:
+			// the content goes in place of the element
+			clean(c)
+			for gc := c.FirstChild; gc != nil; gc = c.FirstChild {
+				c.RemoveChild(gc)
+				n.InsertBefore(gc, c)
+			}
+			n.RemoveChild(c)
+
+		default:
+			c.Attr = cleanAttrs(c.Attr, c.DataAtom)
+			cleanText(c)
+			clean(c)
+		}
+
+		c = next
+	}
+}
+
+func isDropped(n *html.Node) bool {
+	if n.Namespace != "" {
+		return rawText[n.DataAtom]
+	}
+	return dropped[n.DataAtom] || (n.DataAtom == atom.Meta && isRefresh(n.Attr))
+}
+
+// cleanText makes the text of raw text elements safe to render as is,
+// should a browser put the element elsewhere than this parser did.
+func cleanText(n *html.Node) {
+	if n.Namespace != "" || !rawText[n.DataAtom] {
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Style:
+		// a css escape is the same character in strings and names
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode {
+				c.Data = strings.ReplaceAll(c.Data, "<", `\3c `)
+			}
+		}
+	case atom.Xmp, atom.Plaintext:
+		// text in <pre> is escaped when rendered
+		n.Data, n.DataAtom = "pre", atom.Pre
+	default:
+		// fallback content browsers don't show
+		for c := n.FirstChild; c != nil; c = n.FirstChild {
+			n.RemoveChild(c)
+		}
+	}
+}
+
+// cleanAttrs returns attrs without the ones that run scripts.
+func cleanAttrs(attrs []html.Attribute, a atom.Atom) []html.Attribute {
+	clean := attrs[:0]
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		switch {
+		case strings.HasPrefix(key, "on"), key == "srcdoc":
+			continue
+		case urlAttrs[key] && isScriptURL(attr.Val):
+			continue
+		case key == "src" && (a == atom.Iframe || a == atom.Frame) && hasScheme(attr.Val, "data:"):
+			// a document of its own, which runs its scripts
+			continue
+		case animateAttrs[key] && hasScriptValue(attr.Val):
+			continue
+		}
+		clean = append(clean, attr)
+	}
+	return clean
+}
+
+// isScriptURL reports whether browsers run u as a script.
+func isScriptURL(u string) bool {
+	return hasScheme(u, "javascript:") || hasScheme(u, "vbscript:")
+}
+
+// hasScheme reports whether u has the scheme, browsers ignore whitespace
+// and control characters in it.
+func hasScheme(u, scheme string) bool {
+	u = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, u)
+	return strings.HasPrefix(strings.ToLower(u), scheme)
+}
+
+// hasScriptValue reports whether any of the ; separated animation values
+// is a script url.
+func hasScriptValue(v string) bool {
+	for _, part := range strings.Split(v, ";") {
+		if isScriptURL(part) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRefresh(attrs []html.Attribute) bool {
+	for _, a := range attrs {
+		if strings.EqualFold(a.Key, "http-equiv") && strings.EqualFold(strings.TrimSpace(a.Val), "refresh") {
+			return true
+		}
+	}
+	return false
+}
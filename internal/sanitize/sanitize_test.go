@@ -0,0 +1,143 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestFragment(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "unchanged",
+			in:   `<p class="x">A &amp; B <a href="/next">next</a></p><style>p { color: red }</style>`,
+			want: `<p class="x">A &amp; B <a href="/next">next</a></p><style>p { color: red }</style>`,
+		},
+		{
+			name: "scripts",
+			in:   `<p>a</p><script>alert("</p>")</script><script src="x.js"></script><p>b</p>`,
+			want: `<p>a</p><p>b</p>`,
+		},
+		{
+			name: "plugins",
+			in:   `<object data="x.swf"><param name=a><object><p>fallback</p></object></object><embed src="x.swf"><applet code="x"></applet>end`,
+			want: `end`,
+		},
+		{
+			name: "event handlers",
+			in:   `<img src="a.png" onerror="alert(1)" alt="a"><div ONCLICK="go()">x</div>`,
+			want: `<img src="a.png" alt="a"/><div>x</div>`,
+		},
+		{
+			name: "script urls",
+			in:   `<a href=" JaVa&#x09;script:alert(1)">x</a><a href="javascript:void(0)" title="t">y</a><form action="vbscript:x"></form><iframe srcdoc="<script></script>" src="data:text/html,<script>alert(1)</script>"></iframe>`,
+			want: `<a>x</a><a title="t">y</a><form></form><iframe></iframe>`,
+		},
+		{
+			name: "refresh and comments",
+			in:   `<meta http-equiv="Refresh" content="0; url=https://example.com/"><meta charset="utf-8"><!-- <img src=x onerror=alert(1)> -->`,
+			want: `<meta charset="utf-8"/>`,
+		},
+		{
+			name: "noscript",
+			in:   `<noscript><img src="a.png" onload="x()"><script>y()</script></noscript>`,
+			want: `<img src="a.png"/>`,
+		},
+		{
+			name: "svg",
+			in:   `<svg><a xlink:href="javascript:alert(1)"><text>t</text></a><set attributeName="href" to="javascript:alert(1)"></set><script>alert(1)</script></svg>`,
+			want: `<svg><a><text>t</text></a><set attributeName="href"></set></svg>`,
+		},
+		{
+			name: "style in css",
+			in:   `<style>a::after { content: "</p>" }</style>`,
+			want: `<style>a::after { content: "\3c /p>" }</style>`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := Fragment(tt.in)
+		if err != nil {
+			t.Fatalf("%s: Fragment(): %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: Fragment() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestFragmentForeign checks markup that a tokenizer reads as raw text in
+// <svg> and <math>, where browsers build live elements from it.
+func TestFragmentForeign(t *testing.T) {
+	for _, in := range []string{
+		`<svg><style><img src=x onerror=alert(1)></style></svg>`,
+		`<math><style><img src=x onerror=alert(1)></style></math>`,
+		`<svg><xmp><img src=x onerror=alert(1)></xmp></svg>`,
+		`<svg><noscript><img src=x onerror=alert(1)></noscript></svg>`,
+		`<svg></p><style><a id="</style><img src=1 onerror=alert(1)>">`,
+		`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)>`,
+		`<form><math><mtext></form><form><mglyph><style></math><img src onerror=alert(1)>`,
+	} {
+		got, err := Fragment(in)
+		if err != nil {
+			t.Fatalf("Fragment(%q): %v", in, err)
+		}
+		// parsed again as a browser with and without scripts would
+		for _, scripting := range []bool{true, false} {
+			nodes, err := html.ParseFragmentWithOptions(strings.NewReader(got),
+				&html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}, html.ParseOptionEnableScripting(scripting))
+			if err != nil {
+				t.Fatalf("ParseFragment(%q): %v", got, err)
+			}
+			for _, n := range nodes {
+				if bad := findScript(n); bad != "" {
+					t.Errorf("Fragment(%q) = %q has %s", in, got, bad)
+				}
+			}
+		}
+	}
+}
+
+// findScript returns what runs a script in the tree of n.
+func findScript(n *html.Node) string {
+	if n.Type == html.ElementNode {
+		if n.Data == "script" {
+			return "a script"
+		}
+		for _, a := range n.Attr {
+			if strings.HasPrefix(a.Key, "on") {
+				return "an event handler " + a.Key
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if bad := findScript(c); bad != "" {
+			return bad
+		}
+	}
+	return ""
+}
+
+func TestCopy(t *testing.T) {
+	in := `<!DOCTYPE html><html><head><title>A</title><script>x()</script></head><body onload="x()"><p>text</p><svg><style><img src=x onerror=alert(1)></style></svg></body></html>`
+
+	var out strings.Builder
+	if err := Copy(&out, strings.NewReader(in)); err != nil {
+		t.Fatalf("Copy(): %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "<!DOCTYPE html>") || !strings.Contains(got, "<title>A</title>") || !strings.Contains(got, "<p>text</p>") {
+		t.Errorf("Copy() = %q, want the page", got)
+	}
+	for _, bad := range []string{"<script", "onload", "onerror"} {
+		if strings.Contains(got, bad) {
+			t.Errorf("Copy() = %q keeps %s", got, bad)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package server
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiPrefix is the path of the current version of the REST API.
+const apiPrefix = "/api/v1"
+
+// apiBodySize limits request bodies, they only carry a link.
+const apiBodySize = 1 << 20
+
+// errBodyTooLarge is answered with 413, no gRPC code maps to it.
+var errBodyTooLarge = status.Errorf(codes.InvalidArgument, "Body is larger than %d bytes", apiBodySize)
+
+//go:embed openapi.yaml
+var openAPI []byte
+
+// Bodies are the LinkService messages in JSON with the field names of the proto file.
+var (
+	apiMarshal   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	apiUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+var apiSorts = map[string]gen.LinkSort{
+	"":       gen.LinkSort_LINK_SORT_NEWEST,
+	"newest": gen.LinkSort_LINK_SORT_NEWEST,
+	"oldest": gen.LinkSort_LINK_SORT_OLDEST,
+	"title":  gen.LinkSort_LINK_SORT_TITLE,
+	"domain": gen.LinkSort_LINK_SORT_DOMAIN,
+}
+
+// apiError is the body of failed requests.
+type apiError struct {
+	// Code is the name of the gRPC status code, e.g. NotFound
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// configureAPI adds the REST API for clients that can't use gRPC. Requests
+// act for the user in the user_id parameter as gRPC calls do, so the API is
+// served only when an API key is set and every request must carry it.
+func (s *server) configureAPI() {
+	if s.config.APIKey == "" {
+		s.logger.Info("REST API is off, API_KEY is not set")
+		return
+	}
+
+	s.echo.GET(apiPrefix+"/openapi.yaml", s.serveOpenAPI)
+
+	api := s.echo.Group(apiPrefix, middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Validator: func(key string, ctx echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(s.config.APIKey)) == 1, nil
+		},
+		ErrorHandler: func(err error, ctx echo.Context) error {
+			return s.apiFail(ctx, status.Error(codes.Unauthenticated, "Missing or invalid API key"))
+		},
+	}))
+	api.POST("/links", s.apiSaveLink)
+	api.GET("/links", s.apiGetLinks)
+	api.GET("/links/search", s.apiSearchLinks)
+	// every request creates a share, so never on GET
+	api.POST("/links/:link_id/share", s.apiShareLink)
+	api.DELETE("/links/:link_id/share", s.apiRevokeShareLinks)
+	api.DELETE("/links/:link_id", s.apiDeleteLink)
+	api.GET("/jobs/:job_id", s.apiGetCaptureStatus)
+}
+
+func (s *server) serveOpenAPI(ctx echo.Context) error {
+	return ctx.Blob(http.StatusOK, "application/yaml", openAPI)
+}
+
+// apiSaveLink queues a link for capture, like SaveLink.
+func (s *server) apiSaveLink(ctx echo.Context) error {
+	userID, err := apiUserID(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	req := &gen.SaveLinkRequest{}
+	if err := apiBind(ctx, req); err != nil {
+		return s.apiFail(ctx, err)
+	}
+	req.UserId = userID
+
+	resp, err := s.service.SaveLink(ctx.Request().Context(), req)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	// the page is captured later, see /jobs/:job_id
+	return apiRespond(ctx, http.StatusAccepted, resp)
+}
+
+// apiGetLinks lists links like GetLinks when description is set
+// and like GetAllLinks otherwise.
+func (s *server) apiGetLinks(ctx echo.Context) error {
+	userID, err := apiUserID(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	sort, ok := apiSorts[ctx.QueryParam("sort")]
+	if !ok {
+		return s.apiFail(ctx, status.Error(codes.InvalidArgument, "sort must be newest, oldest, title or domain"))
+	}
+	pageSize, err := apiInt(ctx.QueryParam("page_size"), "page_size")
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	collectionID, err := apiInt(ctx.QueryParam("collection_id"), "collection_id")
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	description := ctx.QueryParam("description")
+	if description == "" {
+		resp, err := s.service.GetAllLinks(ctx.Request().Context(), &gen.GetAllLinksRequest{
+			UserId:       userID,
+			CollectionId: collectionID,
+			Sort:         sort,
+			PageSize:     pageSize,
+			PageToken:    ctx.QueryParam("page_token"),
+		})
+		if err != nil {
+			return s.apiFail(ctx, err)
+		}
+		return apiRespond(ctx, http.StatusOK, resp)
+	}
+
+	if collectionID != 0 {
+		return s.apiFail(ctx, status.Error(codes.InvalidArgument, "description and collection_id can't be used together"))
+	}
+	resp, err := s.service.GetLinks(ctx.Request().Context(), &gen.GetLinksRequest{
+		UserId:      userID,
+		Description: description,
+		Sort:        sort,
+		PageSize:    pageSize,
+		PageToken:   ctx.QueryParam("page_token"),
+	})
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+func (s *server) apiSearchLinks(ctx echo.Context) error {
+	userID, err := apiUserID(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	resp, err := s.service.SearchLinks(ctx.Request().Context(), &gen.SearchLinksRequest{
+		UserId: userID,
+		Query:  ctx.QueryParam("q"),
+	})
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+// apiShareLink creates a share link of the saved page, like GetLink.
+// The options are in the body, passwords should not end up in access
+// logs, and it may be empty for the defaults.
+func (s *server) apiShareLink(ctx echo.Context) error {
+	userID, linkID, err := apiLink(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	req := &gen.GetLinkRequest{}
+	if ctx.Request().ContentLength != 0 {
+		if err := apiBind(ctx, req); err != nil {
+			return s.apiFail(ctx, err)
+		}
+	}
+	req.UserId, req.UrlId = userID, linkID
+
+	resp, err := s.service.GetLink(ctx.Request().Context(), req)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+// apiRevokeShareLinks revokes the share link share_id of the link,
+// all of them without it.
+func (s *server) apiRevokeShareLinks(ctx echo.Context) error {
+	userID, linkID, err := apiLink(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	var shareID int64
+	if v := ctx.QueryParam("share_id"); v != "" {
+		shareID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || shareID <= 0 {
+			return s.apiFail(ctx, status.Error(codes.InvalidArgument, "Invalid share id"))
+		}
+	}
+
+	resp, err := s.service.RevokeShareLinks(ctx.Request().Context(), &gen.RevokeShareLinksRequest{
+		UserId:  userID,
+		LinkId:  linkID,
+		ShareId: shareID,
+	})
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+func (s *server) apiDeleteLink(ctx echo.Context) error {
+	userID, linkID, err := apiLink(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	// DeleteLink trusts the caller with any link
+	if err := s.service.CheckLinkOwner(ctx.Request().Context(), userID, linkID); err != nil {
+		return s.apiFail(ctx, err)
+	}
+
+	resp, err := s.service.DeleteLink(ctx.Request().Context(), &gen.DeleteLinkRequest{LinkId: linkID})
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+func (s *server) apiGetCaptureStatus(ctx echo.Context) error {
+	userID, err := apiUserID(ctx)
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	jobID, err := strconv.ParseInt(ctx.Param("job_id"), 10, 64)
+	if err != nil {
+		return s.apiFail(ctx, status.Error(codes.InvalidArgument, "Invalid job id"))
+	}
+
+	resp, err := s.service.GetCaptureStatus(ctx.Request().Context(), &gen.GetCaptureStatusRequest{
+		UserId: userID,
+		JobId:  jobID,
+	})
+	if err != nil {
+		return s.apiFail(ctx, err)
+	}
+	return apiRespond(ctx, http.StatusOK, resp)
+}
+
+// apiUserID reads the user the request acts for.
+func apiUserID(ctx echo.Context) (int64, error) {
+	userID, err := strconv.ParseInt(ctx.QueryParam("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return userID, nil
+}
+
+// apiLink reads the user and the link id of the path.
+func apiLink(ctx echo.Context) (int64, int32, error) {
+	userID, err := apiUserID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	linkID, err := strconv.ParseInt(ctx.Param("link_id"), 10, 32)
+	if err != nil || linkID <= 0 {
+		return 0, 0, status.Error(codes.InvalidArgument, "Invalid link id")
+	}
+	return userID, int32(linkID), nil
+}
+
+// apiInt parses an optional number parameter, empty is 0.
+func apiInt(v, name string) (int32, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a number", name)
+	}
+	return int32(n), nil
+}
+
+func apiBind(ctx echo.Context, m proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Response(), ctx.Request().Body, apiBodySize))
+	if _, ok := err.(*http.MaxBytesError); ok {
+		return errBodyTooLarge
+	}
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Failed to read body: %v", err)
+	}
+	if err := apiUnmarshal.Unmarshal(body, m); err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+func apiRespond(ctx echo.Context, code int, m proto.Message) error {
+	body, err := apiMarshal.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ctx.JSONBlob(code, body)
+}
+
+// apiFail answers with the status of err, see httpStatus.
+func (s *server) apiFail(ctx echo.Context, err error) error {
+	st := status.Convert(err)
+	if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+		s.logger.Error("REST API request failed",
+			zap.String("path", ctx.Path()),
+			zap.Error(err),
+		)
+	}
+	code := httpStatus(st.Code())
+	if err == errBodyTooLarge {
+		code = http.StatusRequestEntityTooLarge
+	}
+	return ctx.JSON(code, apiError{
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	})
+}
+
+// httpStatus maps gRPC status codes as gRPC-HTTP gateways do.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// the status nginx uses for clients that went away
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
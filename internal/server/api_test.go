@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/logger"
+	"google.golang.org/grpc/codes"
+)
+
+const testAPIKey = "secret"
+
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+
+	cfg := &config.Config{
+		Server:   config.ServerConfig{APIKey: testAPIKey},
+		Database: config.DatabaseConfig{Driver: "memory"},
+	}
+	s := New(cfg, logger.NewNop())
+	s.configureRouter()
+	return s
+}
+
+// call makes a request to the API and decodes the JSON answer into out.
+func call(t *testing.T, s *server, method, target, body, key string, out any) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s answered %q: %v", method, target, rec.Body, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestAPI(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		key    string
+		code   int
+		status string
+	}{
+		{"no key", http.MethodGet, "/api/v1/links?user_id=1", "", "", http.StatusUnauthorized, "Unauthenticated"},
+		{"wrong key", http.MethodGet, "/api/v1/links?user_id=1", "", "wrong", http.StatusUnauthorized, "Unauthenticated"},
+		{"no user", http.MethodGet, "/api/v1/links", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"bad sort", http.MethodGet, "/api/v1/links?user_id=1&sort=random", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"bad page token", http.MethodGet, "/api/v1/links?user_id=1&page_token=x", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"empty search", http.MethodGet, "/api/v1/links/search?user_id=1", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"invalid json", http.MethodPost, "/api/v1/links?user_id=1", "{", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"invalid link", http.MethodPost, "/api/v1/links?user_id=1", `{"original_url": "not a link", "description": "x"}`, testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"private link", http.MethodPost, "/api/v1/links?user_id=1", `{"original_url": "http://127.0.0.1/", "description": "x"}`, testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"missing link", http.MethodDelete, "/api/v1/links/42?user_id=1", "", testAPIKey, http.StatusNotFound, "NotFound"},
+		{"missing share", http.MethodPost, "/api/v1/links/42/share?user_id=1", "", testAPIKey, http.StatusNotFound, "NotFound"},
+		{"bad scope", http.MethodPost, "/api/v1/links/42/share?user_id=1", `{"scopes": ["SHARE_SCOPE_UNSPECIFIED"]}`, testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		// no route, not the NotFound of the link
+		{"share on get", http.MethodGet, "/api/v1/links/42/share?user_id=1", "", testAPIKey, http.StatusNotFound, ""},
+		{"large body", http.MethodPost, "/api/v1/links?user_id=1", `{"description": "` + strings.Repeat("x", apiBodySize) + `"}`, testAPIKey, http.StatusRequestEntityTooLarge, "InvalidArgument"},
+		{"missing revoke", http.MethodDelete, "/api/v1/links/42/share?user_id=1", "", testAPIKey, http.StatusNotFound, "NotFound"},
+		{"bad share id", http.MethodDelete, "/api/v1/links/42/share?user_id=1&share_id=x", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+		{"bad link id", http.MethodDelete, "/api/v1/links/abc?user_id=1", "", testAPIKey, http.StatusBadRequest, "InvalidArgument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got apiError
+			code := call(t, s, tt.method, tt.target, tt.body, tt.key, &got)
+			if code != tt.code || got.Code != tt.status {
+				t.Errorf("%s %s = %d %+v, want %d %s", tt.method, tt.target, code, got, tt.code, tt.status)
+			}
+		})
+	}
+}
+
+func TestAPISaveLink(t *testing.T) {
+	s := newTestServer(t)
+
+	var saved struct {
+		Success bool   `json:"success"`
+		JobID   string `json:"job_id"`
+	}
+	code := call(t, s, http.MethodPost, "/api/v1/links?user_id=1",
+		`{"original_url": "https://example.com/", "description": "example"}`, testAPIKey, &saved)
+	if code != http.StatusAccepted || !saved.Success || saved.JobID == "" {
+		t.Fatalf("POST /links = %d %+v, want 202 with a job", code, saved)
+	}
+
+	var job struct {
+		Status string `json:"status"`
+	}
+	if code := call(t, s, http.MethodGet, "/api/v1/jobs/"+saved.JobID+"?user_id=1", "", testAPIKey, &job); code != http.StatusOK {
+		t.Errorf("GET /jobs/%s = %d", saved.JobID, code)
+	}
+	if job.Status != "CAPTURE_STATUS_PENDING" {
+		t.Errorf("job status = %q, want CAPTURE_STATUS_PENDING", job.Status)
+	}
+	if code := call(t, s, http.MethodGet, "/api/v1/jobs/"+saved.JobID+"?user_id=2", "", testAPIKey, nil); code != http.StatusNotFound {
+		t.Errorf("GET /jobs/%s of another user = %d, want 404", saved.JobID, code)
+	}
+
+	// fields are there even when empty
+	var page map[string]any
+	if code := call(t, s, http.MethodGet, "/api/v1/links?user_id=1&sort=title", "", testAPIKey, &page); code != http.StatusOK {
+		t.Errorf("GET /links = %d", code)
+	}
+	if _, ok := page["next_page_token"]; !ok {
+		t.Errorf("GET /links = %v, want next_page_token", page)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/openapi.yaml", nil)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "openapi: 3") {
+		t.Errorf("GET /api/v1/openapi.yaml = %d %.40q", rec.Code, rec.Body)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := map[codes.Code]int{
+		codes.OK:               http.StatusOK,
+		codes.InvalidArgument:  http.StatusBadRequest,
+		codes.NotFound:         http.StatusNotFound,
+		codes.AlreadyExists:    http.StatusConflict,
+		codes.Unauthenticated:  http.StatusUnauthorized,
+		codes.DeadlineExceeded: http.StatusGatewayTimeout,
+		codes.Internal:         http.StatusInternalServerError,
+		codes.Unknown:          http.StatusInternalServerError,
+	}
+	for code, want := range tests {
+		if got := httpStatus(code); got != want {
+			t.Errorf("httpStatus(%s) = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/rewrite"
+	"github.com/0x0FACED/link-saver-api/internal/sanitize"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Archived pages are served in a sandbox without our origin, so their
+// scripts can't read our cookies or call our endpoints as the viewer.
+// Assets are still loaded from anywhere, pages saved without inlined
+// assets need them.
+const (
+	contentPolicy = "sandbox allow-popups allow-popups-to-escape-sandbox; default-src 'none'; " +
+		"img-src * data: blob:; media-src * data: blob:; style-src * 'unsafe-inline' data:; font-src * data:; " +
+		"form-action 'none'; frame-ancestors 'none'"
+	// contentScriptsPolicy lets scripts run, still in an opaque origin
+	contentScriptsPolicy = "sandbox allow-scripts allow-popups allow-popups-to-escape-sandbox; default-src 'none'; " +
+		"script-src * 'unsafe-inline' 'unsafe-eval' data: blob:; connect-src *; " +
+		"img-src * data: blob:; media-src * data: blob:; style-src * 'unsafe-inline' data:; font-src * data:; " +
+		"form-action 'none'; frame-ancestors 'none'"
+)
+
+// router is where the routes of archived pages are added, the echo
+// instance or the group of the content host.
+type router interface {
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// configureContent adds the routes of share links. With CONTENT_URL they
+// are only served on that host and the main host redirects there.
+func (s *server) configureContent() {
+	var r router = s.echo
+	if s.contentURL != "" {
+		u, err := url.Parse(s.contentURL)
+		if err != nil || u.Host == "" {
+			s.logger.Fatal("Invalid CONTENT_URL, expected an absolute url",
+				zap.String("content_url", s.contentURL),
+				zap.Error(err),
+			)
+		}
+		r = s.echo.Host(u.Host)
+		// links generated before the content host was set
+		s.echo.GET("/gen/*", s.redirectContent)
+	}
+
+	// handler to return html page to user, the token is made by GetLink
+	r.GET("/gen/:token", s.serveLink)
+	// checkers of links, they never use up one-time links
+	r.HEAD("/gen/:token", s.serveLink)
+	// password form of protected links
+	r.POST("/gen/:token/unlock", s.unlockShare)
+	// what changed on the live page since it was saved
+	r.GET("/gen/:token/diff", s.serveDiff)
+	// the capture as a WARC file, to open in replay tools
+	r.GET("/gen/:token/warc", s.serveWARC)
+
+	// links generated before share links were signed, kept until they expire
+	r.GET(legacyPath, s.serveLegacyLink)
+	r.GET(legacyPath+"/diff", s.serveLegacyLink)
+	r.GET(legacyPath+"/warc", s.serveLegacyLink)
+}
+
+// redirectContent sends share links on the main host to the content host.
+func (s *server) redirectContent(ctx echo.Context) error {
+	return ctx.Redirect(http.StatusFound, strings.TrimSuffix(s.contentURL, "/")+ctx.Request().URL.RequestURI())
+}
+
+// sandbox sets the headers of archived content on the response.
+func (s *server) sandbox(ctx echo.Context) {
+	h := ctx.Response().Header()
+	if s.config.ContentScripts {
+		h.Set(echo.HeaderContentSecurityPolicy, contentScriptsPolicy)
+	} else {
+		h.Set(echo.HeaderContentSecurityPolicy, contentPolicy)
+	}
+	h.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	// the token is in the url, assets of the page must not see it
+	h.Set("Referrer-Policy", "no-referrer")
+}
+
+// cleanHTML strips scripts from an html fragment that goes into a page
+// of ours, regardless of CONTENT_SANITIZE.
+func (s *server) cleanHTML(fragment string) string {
+	clean, err := sanitize.Fragment(fragment)
+	if err != nil {
+		s.logger.Error("Error sanitizing html",
+			zap.Error(err),
+		)
+		return ""
+	}
+	return clean
+}
+
+// writePage copies the archived page to w with its urls made absolute
+// against the original url and the banner at the top, sanitized first
+// when CONTENT_SANITIZE is set.
+func (s *server) writePage(w io.Writer, page io.Reader, original *url.URL, banner []byte) error {
+	if !s.config.ContentSanitize {
+		return rewrite.Copy(w, page, original, banner)
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(sanitize.Copy(pw, page))
+	}()
+
+	err := rewrite.Copy(w, pr, original, banner)
+	// stops the sanitizer when the copy is cut short
+	pr.CloseWithError(err)
+	<-done
+	return err
+}
+
+// renderBanner returns the banner of the snapshot with the capture date
+// and the original url.
+func (s *server) renderBanner(ctx echo.Context, userID int64, original string, at time.Time) ([]byte, error) {
+	article, err := s.service.GetArticleFromDatabase(context.TODO(), userID, original, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// absolute, the page may have a <base> of the original site
+	req := ctx.Request()
+	q := req.URL.Query()
+	q.Set("banner", "0")
+	hide := ctx.Scheme() + "://" + req.Host + req.URL.Path + "?" + q.Encode()
+
+	var buf bytes.Buffer
+	err = bannerTmpl.Execute(&buf, struct {
+		OriginalURL string
+		CapturedAt  time.Time
+		Hide        string
+	}{
+		OriginalURL: original,
+		CapturedAt:  article.CapturedAt,
+		Hide:        hide,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,46 +1,612 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"embed"
+	"errors"
+	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/0x0FACED/link-saver-api/internal/diff"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/service"
+	"github.com/0x0FACED/link-saver-api/internal/share"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/warc"
+	"github.com/0x0FACED/proto-files/link_service/gen"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+//go:embed templates
+var templatesFS embed.FS
+
+var (
+	readerTmpl   = template.Must(template.ParseFS(templatesFS, "templates/reader.html"))
+	diffTmpl     = template.Must(template.ParseFS(templatesFS, "templates/diff.html"))
+	importTmpl   = template.Must(template.ParseFS(templatesFS, "templates/import.html"))
+	passwordTmpl = template.Must(template.ParseFS(templatesFS, "templates/password.html"))
+	bannerTmpl   = template.Must(template.ParseFS(templatesFS, "templates/banner.html"))
+)
+
+// importFormSize is the room for the multipart form around the file.
+const importFormSize = 1 << 20
+
+// diffContext is the number of unchanged paragraphs shown around changes.
+const diffContext = 2
+
+// unlockCookie keeps the proof that the password of a share was entered,
+// it is scoped to the path of the share.
+const unlockCookie = "share_unlock"
+
+// unlockFormSize is the room for the password form.
+const unlockFormSize = 4 << 10
+
 func (s *server) serveLink(ctx echo.Context) error {
+	// a snapshot timestamp opens an older capture of the page
+	at, err := service.ParseSnapshotTime(ctx.QueryParam("at"))
+	if err != nil {
+		return ctx.HTML(http.StatusBadRequest, "invalid snapshot timestamp, expected YYYYMMDDhhmmss")
+	}
+
+	sh, err := s.openShare(ctx, share.ScopePage)
+	if sh == nil {
+		return err
+	}
+	userID, original := sh.UserID, sh.OriginalURL
+	s.logger.Debug("Received serveLink() request with params",
+		zap.Int64("share_id", sh.ID),
+		zap.Int("link_id", sh.LinkID),
+	)
+
+	s.sandbox(ctx)
+
+	if ctx.QueryParam("view") == "reader" {
+		return s.serveReader(ctx, sh, at)
+	}
+
+	content, err := s.service.OpenContentFromDatabase(context.TODO(), userID, original, at)
+	if err != nil {
+		s.logger.Error("Error OpenContentFromDatabase()",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusNotFound, "content not found in database")
+	}
+	defer content.Close()
+
+	base, err := url.Parse(original)
+	if err != nil {
+		s.logger.Error("Error parsing original url",
+			zap.String("original_url", original),
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusInternalServerError, "failed to serve page")
+	}
+
+	// the banner is shown unless the request turns it off with ?banner=0
+	var banner []byte
+	if show, err := strconv.ParseBool(ctx.QueryParam("banner")); err != nil || show {
+		banner, err = s.renderBanner(ctx, userID, original, at)
+		if err != nil {
+			// the page is still worth serving without it
+			s.logger.Error("Error rendering banner",
+				zap.Error(err),
+			)
+		}
+	}
+
+	if err := s.service.UseShare(ctx.Request().Context(), sh); err != nil {
+		return s.shareError(ctx, err)
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	res.WriteHeader(http.StatusOK)
+
+	// the page is rewritten as it is read from the blob store,
+	// a failure can only cut it short
+	if err := s.writePage(res, content, base, banner); err != nil {
+		s.logger.Error("Error writing page",
+			zap.Error(err),
+		)
+	}
+
+	return nil
+}
+
+// serveReader renders the article extracted from the page at save time.
+func (s *server) serveReader(ctx echo.Context, sh *models.Share, at time.Time) error {
+	article, err := s.service.GetArticleFromDatabase(context.TODO(), sh.UserID, sh.OriginalURL, at)
+	if err != nil {
+		s.logger.Error("Error GetArticleFromDatabase()",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusNotFound, "content not found in database")
+	}
+
+	var buf bytes.Buffer
+	err = readerTmpl.Execute(&buf, struct {
+		*models.Link
+		Article template.HTML
+		// At is the snapshot timestamp of the request, empty for the latest
+		At string
+	}{
+		Link: article,
+		At:   ctx.QueryParam("at"),
+		// articles saved before extraction sanitized them are
+		// cleaned here, the view is sandboxed as well
+		Article: template.HTML(s.cleanHTML(article.Article)),
+	})
+	if err != nil {
+		s.logger.Error("Error executing reader template",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusInternalServerError, "failed to render reader view")
+	}
+
+	if err := s.service.UseShare(ctx.Request().Context(), sh); err != nil {
+		return s.shareError(ctx, err)
+	}
+
+	return ctx.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// serveWARC downloads the capture as a WARC file for replay tools.
+func (s *server) serveWARC(ctx echo.Context) error {
+	at, err := service.ParseSnapshotTime(ctx.QueryParam("at"))
+	if err != nil {
+		return ctx.HTML(http.StatusBadRequest, "invalid snapshot timestamp, expected YYYYMMDDhhmmss")
+	}
+
+	sh, err := s.openShare(ctx, share.ScopeWARC)
+	if sh == nil {
+		return err
+	}
+	userID, original := sh.UserID, sh.OriginalURL
+	s.logger.Debug("Received serveWARC() request with params",
+		zap.Int64("share_id", sh.ID),
+		zap.Int("link_id", sh.LinkID),
+	)
+	s.sandbox(ctx)
+
+	archive, err := s.service.OpenWARCFromDatabase(context.TODO(), userID, original, at)
+	if err != nil {
+		if errors.Is(err, storage.ErrWARCNotFound) {
+			return ctx.HTML(http.StatusNotFound, "this capture was saved without a WARC file")
+		}
+		s.logger.Error("Error OpenWARCFromDatabase()",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusNotFound, "content not found in database")
+	}
+	defer archive.Close()
+
+	if err := s.service.UseShare(ctx.Request().Context(), sh); err != nil {
+		return s.shareError(ctx, err)
+	}
+
+	name := storage.Domain(original)
+	if name == "" {
+		name = "capture"
+	}
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+name+warc.Extension+`"`)
+
+	return ctx.Stream(http.StatusOK, warc.ContentType, archive)
+}
+
+// serveDiff compares the saved copy with the page as it is now.
+func (s *server) serveDiff(ctx echo.Context) error {
+	at, err := service.ParseSnapshotTime(ctx.QueryParam("at"))
+	if err != nil {
+		return ctx.HTML(http.StatusBadRequest, "invalid snapshot timestamp, expected YYYYMMDDhhmmss")
+	}
+
+	sh, err := s.openShare(ctx, share.ScopeDiff)
+	if sh == nil {
+		return err
+	}
+	userID, original := sh.UserID, sh.OriginalURL
+	s.logger.Debug("Received serveDiff() request with params",
+		zap.Int64("share_id", sh.ID),
+		zap.Int("link_id", sh.LinkID),
+	)
+	s.sandbox(ctx)
+
+	article, err := s.service.GetArticleFromDatabase(context.TODO(), userID, original, at)
+	if err != nil {
+		s.logger.Error("Error GetArticleFromDatabase()",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusNotFound, "content not found in database")
+	}
+
+	content, err := s.service.GetContentFromDatabase(context.TODO(), userID, original, at)
+	if err != nil {
+		s.logger.Error("Error GetContentFromDatabase()",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusNotFound, "content not found in database")
+	}
+
+	paragraphs, err := s.service.DiffWithLive(original, content)
+	if err != nil {
+		s.logger.Info("Error DiffWithLive()",
+			zap.String("original_url", original),
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusBadGateway, "failed to load the live page, it may be gone or unreachable")
+	}
+
+	var buf bytes.Buffer
+	err = diffTmpl.Execute(&buf, struct {
+		*models.Link
+		Page       string
+		At         string
+		Stats      diff.Stats
+		Paragraphs []diff.Paragraph
+	}{
+		Link:       article,
+		Page:       ctx.Param("token"),
+		At:         ctx.QueryParam("at"),
+		Stats:      diff.Count(paragraphs),
+		Paragraphs: diff.Collapse(paragraphs, diffContext),
+	})
+	if err != nil {
+		s.logger.Error("Error executing diff template",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusInternalServerError, "failed to render diff view")
+	}
+
+	if err := s.service.UseShare(ctx.Request().Context(), sh); err != nil {
+		return s.shareError(ctx, err)
+	}
+
+	return ctx.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// openShare returns the share of the token in the path if it opens the
+// view scope. Otherwise it answers the request itself and returns a nil
+// share with the result. Handlers count the view of one-time shares with
+// UseShare once the content is loaded, right before writing it.
+func (s *server) openShare(ctx echo.Context, scope share.Scope) (*models.Share, error) {
+	sh, err := s.service.OpenShare(ctx.Request().Context(), ctx.Param("token"), scope)
+	if err != nil {
+		return nil, s.shareError(ctx, err)
+	}
+
+	if sh.OneTime || sh.PasswordHash != "" {
+		// such pages must not be kept by the browser or proxies
+		ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	}
+
+	if sh.OneTime && isPreview(ctx.Request()) {
+		// the view is left to the person the link was sent to
+		return nil, ctx.HTML(http.StatusOK, "this link works once, open it in a browser")
+	}
+
+	var unlock string
+	if c, err := ctx.Cookie(unlockCookie); err == nil {
+		unlock = c.Value
+	}
+	if !s.service.ShareUnlocked(sh, unlock) {
+		return nil, s.renderPassword(ctx, http.StatusUnauthorized, sh, ctx.Request().RequestURI, "")
+	}
+
+	return sh, nil
+}
+
+// unlockShare checks the password of a share and lets the browser in.
+func (s *server) unlockShare(ctx echo.Context) error {
+	token := ctx.Param("token")
+	sh, err := s.service.OpenShare(ctx.Request().Context(), token, 0)
+	if err != nil {
+		return s.shareError(ctx, err)
+	}
+	s.logger.Debug("Received unlockShare() request with params",
+		zap.Int64("share_id", sh.ID),
+	)
+
+	req := ctx.Request()
+	req.Body = http.MaxBytesReader(ctx.Response(), req.Body, unlockFormSize)
+
+	// the page the prompt was shown on, views of this share only
+	next := ctx.FormValue("next")
+	if base := sharePath(token); next != base && !strings.HasPrefix(next, base+"/") && !strings.HasPrefix(next, base+"?") {
+		next = base
+	}
+
+	if sh.PasswordHash == "" {
+		return ctx.Redirect(http.StatusSeeOther, next)
+	}
+
+	// every check is a bcrypt compare, guesses are slowed down before it
+	shareKey := "share:" + strconv.FormatInt(sh.ID, 10)
+	if wait, ok := s.unlockAttempts.take(shareKey, "ip:"+ctx.RealIP()); !ok {
+		seconds := int(wait.Round(time.Second) / time.Second)
+		ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(max(seconds, 1)))
+		return s.renderPassword(ctx, http.StatusTooManyRequests, sh, next, "Too many attempts, try again later.")
+	}
+	if !s.service.CheckSharePassword(sh, ctx.FormValue("password")) {
+		return s.renderPassword(ctx, http.StatusUnauthorized, sh, next, "Wrong password.")
+	}
+	s.unlockAttempts.reset(shareKey)
+
+	value, expires := s.service.UnlockShare(sh)
+	ctx.SetCookie(&http.Cookie{
+		Name:     unlockCookie,
+		Value:    value,
+		Path:     sharePath(token),
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   ctx.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return ctx.Redirect(http.StatusSeeOther, next)
+}
+
+func (s *server) renderPassword(ctx echo.Context, code int, sh *models.Share, next, message string) error {
+	var buf bytes.Buffer
+	err := passwordTmpl.Execute(&buf, struct {
+		Action  string
+		Next    string
+		OneTime bool
+		Error   string
+	}{
+		Action:  sharePath(ctx.Param("token")) + "/unlock",
+		Next:    next,
+		OneTime: sh.OneTime,
+		Error:   message,
+	})
+	if err != nil {
+		s.logger.Error("Error executing password template",
+			zap.Error(err),
+		)
+
+		return ctx.HTML(http.StatusInternalServerError, "failed to render password page")
+	}
+
+	ctx.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return ctx.HTMLBlob(code, buf.Bytes())
+}
+
+// previewAgents are the fetchers of chats and social sites that load links
+// to show a preview of them.
+var previewAgents = []string{
+	"telegrambot",
+	"slackbot",
+	"slack-imgproxy",
+	"discordbot",
+	"whatsapp",
+	"facebookexternalhit",
+	"facebot",
+	"twitterbot",
+	"linkedinbot",
+	"skypeuripreview",
+	"mattermost",
+	"mastodon",
+	"redditbot",
+	"vkshare",
+	"viber",
+	"embedly",
+	"iframely",
+	"googlebot",
+	"bingbot",
+}
+
+// isPreview reports whether the request only looks at the link, a HEAD
+// or a preview fetcher, which must not use up a one-time share.
+func isPreview(req *http.Request) bool {
+	if req.Method == http.MethodHead {
+		return true
+	}
+	agent := strings.ToLower(req.UserAgent())
+	for _, a := range previewAgents {
+		if strings.Contains(agent, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// sharePath is the path of the page of a share token.
+func sharePath(token string) string {
+	return "/gen/" + token
+}
+
+// legacyPath is the route of links generated before share links were
+// signed, the views of the page follow it.
+const legacyPath = "/gen/:user_id/:url"
+
+// serveLegacyLink redirects a link generated before share links were
+// signed to a share link of the same view, until the old link expires.
+func (s *server) serveLegacyLink(ctx echo.Context) error {
 	u := ctx.Param("user_id")
-	userID, _ := strconv.ParseInt(u, 10, 64)
 	url := ctx.Param("url")
-	s.logger.Debug("Received serveLink() request with params",
+	s.logger.Debug("Received serveLegacyLink() request with params",
 		zap.String("user", u),
 		zap.String("gen_url", url),
 	)
 
-	original, err := s.service.GetURLFromRedis(context.TODO(), userID, url)
+	userID, err := strconv.ParseInt(u, 10, 64)
+	if err != nil {
+		return s.shareError(ctx, service.ErrShareNotFound)
+	}
+	token, err := s.service.OpenLegacyLink(ctx.Request().Context(), userID, url)
+	if err != nil {
+		return s.shareError(ctx, err)
+	}
+
+	target := sharePath(token) + strings.TrimPrefix(ctx.Path(), legacyPath)
+	if q := ctx.Request().URL.RawQuery; q != "" {
+		target += "?" + q
+	}
+	return ctx.Redirect(http.StatusFound, target)
+}
+
+// shareError answers requests with a share link that does not open the page.
+func (s *server) shareError(ctx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrShareNotFound):
+		return ctx.HTML(http.StatusNotFound, "link not found")
+	case errors.Is(err, service.ErrShareExpired):
+		return ctx.HTML(http.StatusGone, "link expired, ask the owner for a new one")
+	case errors.Is(err, service.ErrShareRevoked):
+		return ctx.HTML(http.StatusGone, "link was revoked by the owner")
+	case errors.Is(err, service.ErrShareUsed):
+		return ctx.HTML(http.StatusGone, "link worked once and was already opened")
+	}
+
+	s.logger.Error("Error OpenShare()",
+		zap.Error(err),
+	)
+
+	return ctx.HTML(http.StatusInternalServerError, "failed to open link")
+}
+
+// serveImport shows the upload page of a bookmark file.
+func (s *server) serveImport(ctx echo.Context) error {
+	u := ctx.Param("user_id")
+	userID, _ := strconv.ParseInt(u, 10, 64)
+	s.logger.Debug("Received serveImport() request with params",
+		zap.String("user", u),
+	)
+
+	err := s.service.CheckImportUpload(context.TODO(), userID, ctx.Param("token"))
 	if err != nil {
-		s.logger.Error("Error GetURLFromRedis()",
+		return s.importError(ctx, err)
+	}
+
+	return s.renderImport(ctx, http.StatusOK, nil, "")
+}
+
+// uploadImport queues capture of the uploaded bookmark file.
+func (s *server) uploadImport(ctx echo.Context) error {
+	u := ctx.Param("user_id")
+	userID, _ := strconv.ParseInt(u, 10, 64)
+	s.logger.Debug("Received uploadImport() request with params",
+		zap.String("user", u),
+	)
+
+	req := ctx.Request()
+	req.Body = http.MaxBytesReader(ctx.Response(), req.Body, service.MaxImportSize+importFormSize)
+
+	fh, err := ctx.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return s.renderImport(ctx, http.StatusRequestEntityTooLarge, nil, "The file is too large.")
+		}
+		return s.renderImport(ctx, http.StatusBadRequest, nil, "Choose a bookmark file to import.")
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return s.importError(ctx, err)
+	}
+	defer file.Close()
+
+	folders := gen.FolderMapping_FOLDER_MAPPING_TAGS
+	switch ctx.FormValue("folders") {
+	case "collections":
+		folders = gen.FolderMapping_FOLDER_MAPPING_COLLECTIONS
+	case "none":
+		folders = gen.FolderMapping_FOLDER_MAPPING_NONE
+	}
+
+	imp, err := s.service.ImportUpload(context.TODO(), userID, ctx.Param("token"), file, folders)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return s.renderImport(ctx, http.StatusBadRequest, nil, "This is not a bookmark file exported by a browser.")
+		}
+		return s.importError(ctx, err)
+	}
+
+	return s.renderImport(ctx, http.StatusOK, imp, "")
+}
+
+func (s *server) importError(ctx echo.Context, err error) error {
+	if errors.Is(err, service.ErrImportUploadNotFound) {
+		return ctx.HTML(http.StatusNotFound, "upload page expired or was already used, ask the bot for a new one")
+	}
+
+	s.logger.Error("Error importing bookmarks",
+		zap.Error(err),
+	)
+
+	return ctx.HTML(http.StatusInternalServerError, "failed to import bookmarks")
+}
+
+func (s *server) renderImport(ctx echo.Context, code int, imp *models.Import, message string) error {
+	var buf bytes.Buffer
+	err := importTmpl.Execute(&buf, struct {
+		Import *models.Import
+		Error  string
+	}{
+		Import: imp,
+		Error:  message,
+	})
+	if err != nil {
+		s.logger.Error("Error executing import template",
 			zap.Error(err),
 		)
 
-		return ctx.Redirect(302, "/")
+		return ctx.HTML(http.StatusInternalServerError, "failed to render import page")
 	}
 
-	s.logger.Debug("Original URL from Redis",
-		zap.String("original_url", original),
+	return ctx.HTMLBlob(code, buf.Bytes())
+}
+
+// serveExport downloads the zip archive of all links of the user.
+func (s *server) serveExport(ctx echo.Context) error {
+	u := ctx.Param("user_id")
+	userID, _ := strconv.ParseInt(u, 10, 64)
+	s.logger.Debug("Received serveExport() request with params",
+		zap.String("user", u),
 	)
 
-	content, err := s.service.GetContentFromDatabase(context.TODO(), userID, original)
+	err := s.service.CheckExportDownload(context.TODO(), userID, ctx.Param("token"))
 	if err != nil {
-		s.logger.Error("Error GetContentFromDatabase()",
+		if errors.Is(err, service.ErrExportDownloadNotFound) {
+			return ctx.HTML(http.StatusNotFound, "download link expired, ask the bot for a new one")
+		}
+		s.logger.Error("Error CheckExportDownload()",
 			zap.Error(err),
 		)
 
-		return ctx.HTML(http.StatusNotFound, "content not found in database")
+		return ctx.HTML(http.StatusInternalServerError, "failed to export links")
+	}
+
+	res := ctx.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition,
+		`attachment; filename="links-`+time.Now().UTC().Format("2006-01-02")+`.zip"`)
+	res.WriteHeader(http.StatusOK)
+
+	// the archive is streamed, a failure can only cut it short
+	if err := s.service.WriteExport(ctx.Request().Context(), userID, res); err != nil {
+		s.logger.Error("Error WriteExport()",
+			zap.Error(err),
+		)
 	}
 
-	return ctx.HTML(http.StatusOK, string(content[:]))
+	return nil
 }
 
 func (s *server) mainHandler(ctx echo.Context) error {
@@ -0,0 +1,345 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/logger"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/alicebob/miniredis/v2"
+)
+
+// savedLink captures a page served locally and returns the id of its link.
+func savedLink(t *testing.T, body string) (*server, int32) {
+	t.Helper()
+
+	return savedLinkRedis(t, body, config.RedisConfig{})
+}
+
+// savedLinkRedis is savedLink with a server that uses the Redis of cfg.
+func savedLinkRedis(t *testing.T, body string, redisCfg config.RedisConfig) (*server, int32) {
+	t.Helper()
+
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(page.Close)
+
+	cfg := &config.Config{
+		Database: config.DatabaseConfig{Driver: "memory"},
+		Capture: config.CaptureConfig{
+			Workers:     1,
+			MaxAttempts: 1,
+			Timeout:     5 * time.Second,
+			// httptest servers listen on loopback
+			Allowlist: []string{"127.0.0.1"},
+		},
+		Share: config.ShareConfig{Key: "test", TTL: time.Hour},
+		Redis: redisCfg,
+	}
+	s := New(cfg, logger.NewNop())
+	s.configureRouter()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	s.service.StartCaptureWorkers(ctx)
+
+	saved, err := s.service.SaveLink(ctx, &gen.SaveLinkRequest{UserId: 1, OriginalUrl: page.URL + "/", Description: "page"})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		job, err := s.service.GetCaptureStatus(ctx, &gen.GetCaptureStatusRequest{UserId: 1, JobId: saved.JobId})
+		if err != nil {
+			t.Fatalf("GetCaptureStatus(): %v", err)
+		}
+		switch job.Status {
+		case gen.CaptureStatus_CAPTURE_STATUS_DONE:
+			return s, job.LinkId
+		case gen.CaptureStatus_CAPTURE_STATUS_FAILED:
+			t.Fatalf("capture failed: %s", job.Error)
+		}
+	}
+	t.Fatal("capture is not done in time")
+	return nil, 0
+}
+
+func serve(s *server, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServeShareNotFound(t *testing.T) {
+	s := newTestServer(t)
+
+	for _, tt := range []struct{ method, target string }{
+		{http.MethodGet, "/gen/abc"},
+		{http.MethodGet, "/gen/abc/diff"},
+		{http.MethodGet, "/gen/abc/warc"},
+		{http.MethodPost, "/gen/abc/unlock"},
+	} {
+		if rec := serve(s, httptest.NewRequest(tt.method, tt.target, nil)); rec.Code != http.StatusNotFound {
+			t.Errorf("%s %s = %d, want 404", tt.method, tt.target, rec.Code)
+		}
+	}
+}
+
+func TestServeProtectedShare(t *testing.T) {
+	s, linkID := savedLink(t, "<html><body>saved page</body></html>")
+
+	resp, err := s.service.GetLink(context.Background(), &gen.GetLinkRequest{
+		UserId:   1,
+		UrlId:    linkID,
+		OneTime:  true,
+		Password: "secret",
+	})
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	path := resp.GeneratedUrl
+
+	rec := serve(s, httptest.NewRequest(http.MethodGet, path+"?view=reader", nil))
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), `name="password"`) {
+		t.Fatalf("GET %s = %d %q, want the password form", path, rec.Code, rec.Body)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("password form is served with Cache-Control %q", rec.Header().Get("Cache-Control"))
+	}
+
+	unlock := func(password, next string) *httptest.ResponseRecorder {
+		form := url.Values{"password": {password}, "next": {next}}
+		req := httptest.NewRequest(http.MethodPost, path+"/unlock", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(s, req)
+	}
+
+	if rec := unlock("wrong", path); rec.Code != http.StatusUnauthorized || len(rec.Result().Cookies()) != 0 {
+		t.Errorf("POST with a wrong password = %d %v", rec.Code, rec.Result().Cookies())
+	}
+	// the form never sends browsers elsewhere
+	if rec := unlock("secret", "https://example.com/"); rec.Header().Get("Location") != path {
+		t.Errorf("POST with another next redirects to %q, want %q", rec.Header().Get("Location"), path)
+	}
+
+	rec = unlock("secret", path)
+	cookies := rec.Result().Cookies()
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != path || len(cookies) != 1 {
+		t.Fatalf("POST with the password = %d to %q with %v", rec.Code, rec.Header().Get("Location"), cookies)
+	}
+	if c := cookies[0]; c.Path != path || !c.HttpOnly {
+		t.Errorf("unlock cookie = %+v, want it http only on the share path", c)
+	}
+
+	open := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookies[0])
+		return serve(s, req)
+	}
+	rec = open()
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "saved page") {
+		t.Fatalf("GET %s after unlock = %d %q, want the page", path, rec.Code, rec.Body)
+	}
+	if rec := open(); rec.Code != http.StatusGone {
+		t.Errorf("GET %s of a one-time link again = %d, want 410", path, rec.Code)
+	}
+}
+
+// TestServeLegacyLink checks that links generated before share links
+// were signed redirect to a share link of the same view.
+func TestServeLegacyLink(t *testing.T) {
+	mr := miniredis.RunT(t)
+	s, linkID := savedLinkRedis(t, "<html><body>saved page</body></html>", config.RedisConfig{Host: mr.Host(), Port: mr.Port()})
+
+	links, err := s.service.GetAllLinks(context.Background(), &gen.GetAllLinksRequest{UserId: 1})
+	if err != nil || len(links.Links) != 1 {
+		t.Fatalf("GetAllLinks() = %v, %v", links, err)
+	}
+	mr.Set("tokens:1:abc", links.Links[0].OriginalUrl)
+	mr.SetTTL("tokens:1:abc", time.Hour)
+	mr.Set("links:1:"+links.Links[0].OriginalUrl, fmt.Sprintf("%d:abc", linkID))
+	mr.SetTTL("links:1:"+links.Links[0].OriginalUrl, time.Hour)
+
+	var location string
+	for _, view := range []string{"", "/diff", "/warc"} {
+		rec := serve(s, httptest.NewRequest(http.MethodGet, "/gen/1/abc"+view+"?at=20240101000000", nil))
+		loc, ok := strings.CutSuffix(rec.Header().Get("Location"), view+"?at=20240101000000")
+		if rec.Code != http.StatusFound || !ok || !strings.HasPrefix(loc, "/gen/") || strings.Count(loc, "/") != 2 {
+			t.Fatalf("GET /gen/1/abc%s = %d to %q, want a redirect to the share link", view, rec.Code, rec.Header().Get("Location"))
+		}
+		if location != "" && loc != location {
+			t.Errorf("GET /gen/1/abc%s redirects to %q, want the same share as %q", view, loc, location)
+		}
+		location = loc
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, location, nil)); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "saved page") {
+		t.Errorf("GET %s = %d, want the saved page", location, rec.Code)
+	}
+
+	for _, target := range []string{"/gen/1/other", "/gen/2/abc", "/gen/x/abc/diff"} {
+		if rec := serve(s, httptest.NewRequest(http.MethodGet, target, nil)); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", target, rec.Code)
+		}
+	}
+}
+
+func TestServeSandboxed(t *testing.T) {
+	s, linkID := savedLink(t, `<html><body onload="steal()">saved page<script>steal()</script></body></html>`)
+
+	resp, err := s.service.GetLink(context.Background(), &gen.GetLinkRequest{UserId: 1, UrlId: linkID})
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	path := resp.GeneratedUrl
+
+	for _, target := range []string{path, path + "?view=reader"} {
+		rec := serve(s, httptest.NewRequest(http.MethodGet, target, nil))
+		csp := rec.Header().Get("Content-Security-Policy")
+		if rec.Code != http.StatusOK || !strings.HasPrefix(csp, "sandbox ") {
+			t.Fatalf("GET %s = %d with policy %q, want a sandbox", target, rec.Code, csp)
+		}
+		if strings.Contains(csp, "allow-scripts") || strings.Contains(csp, "allow-same-origin") {
+			t.Errorf("GET %s is sandboxed with %q", target, csp)
+		}
+		if rec.Header().Get("Referrer-Policy") != "no-referrer" {
+			t.Errorf("GET %s leaks the token with Referrer-Policy %q", target, rec.Header().Get("Referrer-Policy"))
+		}
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, path, nil)); !strings.Contains(rec.Body.String(), "<script>") {
+		t.Errorf("GET %s without CONTENT_SANITIZE = %q, want the page as saved", path, rec.Body)
+	}
+
+	s.config.ContentScripts = true
+	s.config.ContentSanitize = true
+	rec := serve(s, httptest.NewRequest(http.MethodGet, path, nil))
+	if csp := rec.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "allow-scripts") || strings.Contains(csp, "allow-same-origin") {
+		t.Errorf("GET %s with CONTENT_SCRIPTS is sandboxed with %q", path, csp)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "saved page") || strings.Contains(body, "steal") {
+		t.Errorf("GET %s with CONTENT_SANITIZE = %q", path, body)
+	}
+}
+
+func TestServeContentHost(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{APIKey: testAPIKey},
+		Database: config.DatabaseConfig{Driver: "memory"},
+		GRPC:     config.GRPCConfig{BaseURL: "http://example.com", ContentURL: "http://content.example.com"},
+	}
+	s := New(cfg, logger.NewNop())
+	s.configureRouter()
+
+	rec := serve(s, httptest.NewRequest(http.MethodGet, "http://example.com/gen/abc/diff?at=20240101000000", nil))
+	if want := "http://content.example.com/gen/abc/diff?at=20240101000000"; rec.Code != http.StatusFound || rec.Header().Get("Location") != want {
+		t.Errorf("GET /gen on the main host = %d to %q, want a redirect to %q", rec.Code, rec.Header().Get("Location"), want)
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, "http://content.example.com/gen/abc", nil)); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /gen on the content host = %d, want 404 of the share", rec.Code)
+	}
+
+	// nothing else is served with the archived pages
+	req := httptest.NewRequest(http.MethodGet, "http://content.example.com/api/v1/links?user_id=1", nil)
+	req.Header.Set("Authorization", "Bearer "+testAPIKey)
+	if rec := serve(s, req); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /api/v1/links on the content host = %d, want 404", rec.Code)
+	}
+}
+
+func TestServeRewritten(t *testing.T) {
+	s, linkID := savedLink(t, `<html><head><link rel="stylesheet" href="style.css"></head><body><img src="/a.png">saved page</body></html>`)
+
+	resp, err := s.service.GetLink(context.Background(), &gen.GetLinkRequest{UserId: 1, UrlId: linkID})
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	path := resp.GeneratedUrl
+
+	rec := serve(s, httptest.NewRequest(http.MethodGet, "http://content.example.com"+path, nil))
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.Contains(body, `href="http://127.0.0.1:`) || !strings.Contains(body, `/a.png"`) || strings.Contains(body, `src="/a.png"`) {
+		t.Fatalf("GET %s = %d %q, want urls of the original site", path, rec.Code, body)
+	}
+	if !strings.Contains(body, `<body><div id="link-saver-banner"`) || !strings.Contains(body, "captured "+time.Now().UTC().Format("2006-01-02")) {
+		t.Errorf("GET %s = %q, want the banner at the top of the body", path, body)
+	}
+	if hide := `href="http://content.example.com` + path + `?banner=0"`; !strings.Contains(body, hide) {
+		t.Errorf("GET %s = %q, want a link to hide the banner %s", path, body, hide)
+	}
+
+	rec = serve(s, httptest.NewRequest(http.MethodGet, path+"?banner=0", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "saved page") || strings.Contains(body, "link-saver-banner") {
+		t.Errorf("GET %s?banner=0 = %q, want the page without the banner", path, body)
+	}
+}
+
+func TestServeOneTimeShare(t *testing.T) {
+	s, linkID := savedLink(t, "<html><body>saved page</body></html>")
+
+	resp, err := s.service.GetLink(context.Background(), &gen.GetLinkRequest{UserId: 1, UrlId: linkID, OneTime: true})
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	path := resp.GeneratedUrl
+
+	// no snapshot is that old, so loading the page fails
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, path+"?at=20000101000000", nil)); rec.Code != http.StatusNotFound {
+		t.Fatalf("GET %s of a missing snapshot = %d, want 404", path, rec.Code)
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, path+"/diff?at=20000101000000", nil)); rec.Code != http.StatusNotFound {
+		t.Fatalf("GET %s/diff of a missing snapshot = %d, want 404", path, rec.Code)
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodHead, path, nil)); rec.Code != http.StatusOK {
+		t.Fatalf("HEAD %s = %d, want 200", path, rec.Code)
+	}
+	preview := httptest.NewRequest(http.MethodGet, path, nil)
+	preview.Header.Set("User-Agent", "TelegramBot (like TwitterBot)")
+	if rec := serve(s, preview); rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "saved page") {
+		t.Fatalf("GET %s by a preview fetcher = %d %q, want no content", path, rec.Code, rec.Body)
+	}
+
+	rec := serve(s, httptest.NewRequest(http.MethodGet, path, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "saved page") {
+		t.Fatalf("GET %s after failed loads and previews = %d %q, want the page", path, rec.Code, rec.Body)
+	}
+	if rec := serve(s, httptest.NewRequest(http.MethodGet, path, nil)); rec.Code != http.StatusGone {
+		t.Errorf("GET %s again = %d, want 410", path, rec.Code)
+	}
+}
+
+func TestUnlockShareLimited(t *testing.T) {
+	s, linkID := savedLink(t, "<html><body>saved page</body></html>")
+
+	resp, err := s.service.GetLink(context.Background(), &gen.GetLinkRequest{UserId: 1, UrlId: linkID, Password: "secret"})
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	path := resp.GeneratedUrl
+	// the clock stands still, slow password checks can't outlast the lockout
+	now := time.Now()
+	s.unlockAttempts.now = func() time.Time { return now }
+
+	unlock := func(password string) *httptest.ResponseRecorder {
+		form := url.Values{"password": {password}}
+		req := httptest.NewRequest(http.MethodPost, path+"/unlock", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return serve(s, req)
+	}
+
+	for i := 0; i <= freeAttempts; i++ {
+		if rec := unlock("wrong"); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("POST %d with a wrong password = %d, want 401", i+1, rec.Code)
+		}
+	}
+	rec := unlock("secret")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" || len(rec.Result().Cookies()) != 0 {
+		t.Errorf("POST after too many attempts = %d with Retry-After %q, want 429", rec.Code, rec.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// freeAttempts is how many attempts of a key are let through before
+	// each one waits for a lockout.
+	freeAttempts = 5
+	// the lockout doubles with every attempt after the free ones
+	firstLockout = time.Second
+	maxLockout   = 15 * time.Minute
+	// attemptWindow is how long attempts are remembered after the last one.
+	attemptWindow = time.Hour
+)
+
+// attemptLimiter slows down guessing of share passwords, per share and
+// per client. Attempts are counted before the password is checked, so
+// parallel requests can't get past it either.
+type attemptLimiter struct {
+	mu        sync.Mutex
+	attempts  map[string]*attempts
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type attempts struct {
+	n           int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+func newAttemptLimiter() *attemptLimiter {
+	return &attemptLimiter{
+		attempts: make(map[string]*attempts),
+		now:      time.Now,
+	}
+}
+
+// take records an attempt of every key. When one of them is locked out
+// nothing is recorded and it returns how long to wait.
+func (l *attemptLimiter) take(keys ...string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	var wait time.Duration
+	for _, k := range keys {
+		if a, ok := l.attempts[k]; ok && now.Before(a.lockedUntil) {
+			wait = max(wait, a.lockedUntil.Sub(now))
+		}
+	}
+	if wait > 0 {
+		return wait, false
+	}
+
+	for _, k := range keys {
+		a, ok := l.attempts[k]
+		if !ok {
+			a = &attempts{}
+			l.attempts[k] = a
+		}
+		a.n++
+		a.last = now
+		if over := a.n - freeAttempts; over > 0 {
+			lockout := maxLockout
+			if over <= 20 {
+				lockout = min(firstLockout<<(over-1), maxLockout)
+			}
+			a.lockedUntil = now.Add(lockout)
+		}
+	}
+	return 0, true
+}
+
+// reset forgets the attempts of key, after the right password was entered.
+func (l *attemptLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, key)
+}
+
+// prune forgets keys without attempts in the window, at most once a minute.
+func (l *attemptLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+
+	for k, a := range l.attempts {
+		if now.Sub(a.last) > attemptWindow && !now.Before(a.lockedUntil) {
+			delete(l.attempts, k)
+		}
+	}
+}
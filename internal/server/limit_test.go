@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAttemptLimiter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	l := newAttemptLimiter()
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < freeAttempts; i++ {
+		if _, ok := l.take("share:1", "ip:a"); !ok {
+			t.Fatalf("take() %d of %d free attempts = false", i+1, freeAttempts)
+		}
+	}
+	// the last free attempt starts the lockout of the next one
+	if _, ok := l.take("share:1", "ip:a"); !ok {
+		t.Fatal("take() right after the free attempts = false")
+	}
+	if wait, ok := l.take("share:1", "ip:b"); ok || wait != firstLockout {
+		t.Errorf("take() of a locked share from another client = %s, %v, want %s", wait, ok, firstLockout)
+	}
+	if wait, ok := l.take("share:2", "ip:a"); ok || wait != firstLockout {
+		t.Errorf("take() of another share from a locked client = %s, %v, want %s", wait, ok, firstLockout)
+	}
+	if _, ok := l.take("share:2", "ip:b"); !ok {
+		t.Error("take() of keys without attempts = false")
+	}
+
+	// the lockout doubles
+	now = now.Add(firstLockout)
+	if _, ok := l.take("share:1"); !ok {
+		t.Fatal("take() after the lockout = false")
+	}
+	if wait, _ := l.take("share:1"); wait != 2*firstLockout {
+		t.Errorf("take() locked again for %s, want %s", wait, 2*firstLockout)
+	}
+
+	for i := 0; i < 100; i++ {
+		now = now.Add(maxLockout)
+		l.take("share:3")
+	}
+	if wait, _ := l.take("share:3"); wait != maxLockout {
+		t.Errorf("take() after many attempts locked for %s, want at most %s", wait, maxLockout)
+	}
+
+	l.reset("share:1")
+	if _, ok := l.take("share:1"); !ok {
+		t.Error("take() after reset = false")
+	}
+
+	now = now.Add(attemptWindow + maxLockout)
+	l.take("share:4")
+	if _, ok := l.attempts["share:3"]; ok {
+		t.Error("attempts are kept after the window")
+	}
+}
@@ -1,8 +1,15 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/0x0FACED/link-saver-api/config"
 	"github.com/0x0FACED/link-saver-api/internal/cached/redis"
@@ -11,14 +18,28 @@ import (
 	"github.com/0x0FACED/proto-files/link_service/gen"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
+const (
+	// shutdownTimeout is how long requests in flight get to finish on shutdown.
+	shutdownTimeout = 10 * time.Second
+	// grpcMaxRecvSize lets ImportBookmarks take files up to the import
+	// cap, with room for the other fields of the request.
+	grpcMaxRecvSize = service.MaxImportSize + 1<<20
+)
+
 type server struct {
-	config  config.ServerConfig
-	service *service.LinkService
-	echo    *echo.Echo
-	logger  *logger.ZapLogger
+	config config.ServerConfig
+	// contentURL is the base url of the host archived pages are served
+	// from, empty to serve them with the rest
+	contentURL string
+	service    *service.LinkService
+	echo       *echo.Echo
+	logger     *logger.ZapLogger
+	// unlockAttempts limits password guesses of protected shares
+	unlockAttempts *attemptLimiter
 }
 
 func New(cfg *config.Config, logger *logger.ZapLogger) *server {
@@ -26,10 +47,13 @@ func New(cfg *config.Config, logger *logger.ZapLogger) *server {
 	s := service.New(cfg, r, logger)
 	logger.Debug("Redis and service entities are created")
 	return &server{
-		config:  cfg.Server,
-		echo:    echo.New(),
-		service: s,
-		logger:  logger,
+		config:     cfg.Server,
+		contentURL: cfg.GRPC.ContentURL,
+		echo:       echo.New(),
+		service:    s,
+		logger:     logger,
+
+		unlockAttempts: newAttemptLimiter(),
 	}
 }
 
@@ -49,18 +73,63 @@ func Start() error {
 		return err
 	}
 	logger.Info("Start listen tcp on port: ")
-	s := grpc.NewServer()
 
 	srv := New(cfg, logger)
+	s := srv.grpcServer()
 	srv.configureRouter()
 
-	go srv.echo.Start(srv.config.Host + ":" + srv.config.Port)
+	// done on SIGINT or SIGTERM, background workers stop with it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv.service.StartCaptureWorkers(ctx)
+	srv.service.StartLinkChecker(ctx)
+
+	go func() {
+		err := srv.echo.Start(srv.config.Host + ":" + srv.config.Port)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Error Start() HTTP server", zap.Error(err))
+			stop()
+		}
+	}()
 
 	logger.Info("HTTP server started")
 
-	gen.RegisterLinkServiceServer(s, srv.service)
 	logger.Info("Service registered and started, waiting for connections...")
-	return s.Serve(lis)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(lis)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err = <-served:
+	}
+	stop()
+
+	logger.Info("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.echo.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Error Shutdown() HTTP server", zap.Error(err))
+	}
+	s.GracefulStop()
+
+	// the database is closed after the workers saved what they captured
+	if err := srv.service.Close(); err != nil {
+		logger.Error("Error Close() service", zap.Error(err))
+	}
+	logger.Info("Server stopped")
+
+	return err
+}
+
+// grpcServer returns the gRPC server of the service.
+func (s *server) grpcServer() *grpc.Server {
+	g := grpc.NewServer(grpc.MaxRecvMsgSize(grpcMaxRecvSize))
+	gen.RegisterLinkServiceServer(g, s.service)
+	return g
 }
 
 func (s *server) configureRouter() {
@@ -70,7 +139,14 @@ func (s *server) configureRouter() {
 
 	s.echo.Static("/", "/root/static")
 
-	// handler to return html page to user
-	s.echo.GET("/gen/:user_id/:url", s.serveLink)
+	// share links, on the content host when there is one
+	s.configureContent()
+	// one-time pages to upload a bookmark file, see CreateImportUpload
+	s.echo.GET("/import/:user_id/:token", s.serveImport)
+	s.echo.POST("/import/:user_id/:token", s.uploadImport)
+	// zip archive of all links, see CreateExportDownload
+	s.echo.GET("/export/:user_id/:token", s.serveExport)
+	// JSON mirror of the gRPC methods, see openapi.yaml
+	s.configureAPI()
 	s.echo.GET("/", s.mainHandler)
 }
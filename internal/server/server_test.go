@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/internal/service"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestGRPCImportSize checks that bookmark files up to the import cap
+// get past the message limit of gRPC.
+func TestGRPCImportSize(t *testing.T) {
+	s := newTestServer(t)
+	lis := bufconn.Listen(1 << 20)
+	g := s.grpcServer()
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcMaxRecvSize)),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient(): %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := gen.NewLinkServiceClient(conn)
+
+	// a bookmark with a comment that takes it over the default limit of 4 MB
+	file := `<!DOCTYPE NETSCAPE-Bookmark-file-1><DL><p><DT><A HREF="https://example.com/">Example</A>` +
+		"<!--" + strings.Repeat("x", service.MaxImportSize-100) + "--></DL>"
+	resp, err := client.ImportBookmarks(context.Background(), &gen.ImportBookmarksRequest{UserId: 1, File: []byte(file)})
+	if err != nil || resp.Queued != 1 {
+		t.Fatalf("ImportBookmarks() of %d bytes = %v, %v, want 1 queued", len(file), resp, err)
+	}
+
+	_, err = client.ImportBookmarks(context.Background(), &gen.ImportBookmarksRequest{UserId: 1, File: []byte(file + strings.Repeat("x", 200))})
+	if st := status.Convert(err); !strings.Contains(st.Message(), "larger than") {
+		t.Errorf("ImportBookmarks() over the cap = %v, want the size error of the service", err)
+	}
+}
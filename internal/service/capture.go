@@ -0,0 +1,318 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/extract"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/warc"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+)
+
+const (
+	// captureLease is how long a job may run before another worker takes it over.
+	captureLease = 5 * time.Minute
+	// inlineTimeout bounds loading all assets of a page, it leaves the rest
+	// of the lease to the page download and saving.
+	inlineTimeout       = 2 * time.Minute
+	capturePollInterval = 2 * time.Second
+	captureRetryBase    = 10 * time.Second
+	captureRetryMax     = 10 * time.Minute
+)
+
+// permanentError marks capture failures that retrying won't fix.
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+// StartCaptureWorkers starts the capture worker pool, workers stop when ctx
+// is done after the captures they started.
+func (s *LinkService) StartCaptureWorkers(ctx context.Context) {
+	for i := 0; i < s.captureCfg.Workers; i++ {
+		s.workers.Add(1)
+		go func() {
+			defer s.workers.Done()
+			s.captureWorker(ctx)
+		}()
+	}
+	s.logger.Info("Capture workers started", zap.Int("workers", s.captureCfg.Workers))
+}
+
+func (s *LinkService) wakeCaptureWorkers() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *LinkService) captureWorker(ctx context.Context) {
+	ticker := time.NewTicker(capturePollInterval)
+	defer ticker.Stop()
+
+	for {
+		// run everything that is due before going to sleep, a capture
+		// that has started is saved even when ctx is done meanwhile
+		for ctx.Err() == nil && s.runNextCapture(context.WithoutCancel(ctx)) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNextCapture runs a single due job and reports whether there was one.
+func (s *LinkService) runNextCapture(ctx context.Context) bool {
+	job, err := s.db.ClaimCaptureJob(ctx, captureLease, s.captureCfg.MaxAttempts)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNoJobs) {
+			s.logger.Error("Failed to claim capture job", zap.Error(err))
+		}
+		return false
+	}
+
+	s.logger.Debug("Capture started",
+		zap.Int64("job_id", job.ID),
+		zap.Int64("user", job.UserID),
+		zap.String("url", job.OriginalURL),
+		zap.Int("attempt", job.Attempts),
+	)
+
+	var link *models.Link
+	if job.Recapture && job.LinkID == 0 {
+		// link_id is cleared when the link is deleted
+		err = permanentError{errors.New("link was deleted")}
+	} else {
+		link, err = s.capture(job)
+	}
+	switch {
+	case err != nil:
+	case job.Recapture:
+		link.ID = job.LinkID
+		err = s.db.AddSnapshot(ctx, job.UserID, job.LinkID, snapshotOf(link))
+		if errors.Is(err, storage.ErrLinksNotFound) {
+			err = permanentError{errors.New("link was deleted")}
+		}
+	default:
+		err = s.saveToDatabase(ctx, link)
+		if errors.Is(err, storage.ErrLinkExists) {
+			err = s.linkExists(ctx, job, link)
+		}
+		if err == nil {
+			s.organizeLink(ctx, job, link.ID)
+		}
+	}
+
+	s.finishCapture(ctx, job, link, err)
+	return true
+}
+
+func (s *LinkService) finishCapture(ctx context.Context, job *models.CaptureJob, link *models.Link, err error) {
+	var finishErr error
+	var permanent permanentError
+	switch {
+	case err == nil:
+		s.logger.Info("Capture done", zap.Int64("job_id", job.ID), zap.Int64("user", job.UserID))
+		finishErr = s.db.CompleteCaptureJob(ctx, job.ID, job.Attempts, link.ID)
+	case errors.As(err, &permanent) || job.Attempts >= s.captureCfg.MaxAttempts:
+		s.logger.Info("Capture failed", zap.Int64("job_id", job.ID), zap.Int64("user", job.UserID), zap.Error(err))
+		finishErr = s.db.FailCaptureJob(ctx, job.ID, job.Attempts, err.Error())
+	default:
+		after := captureBackoff(job.Attempts)
+		s.logger.Info("Capture will be retried",
+			zap.Int64("job_id", job.ID),
+			zap.Int64("user", job.UserID),
+			zap.Duration("after", after),
+			zap.Error(err),
+		)
+		finishErr = s.db.RetryCaptureJob(ctx, job.ID, job.Attempts, after, err.Error())
+	}
+
+	switch {
+	case errors.Is(finishErr, storage.ErrJobLeaseLost):
+		// the lease ran out and another worker has the job now
+		s.logger.Info("Capture job was claimed again", zap.Int64("job_id", job.ID), zap.Int("attempt", job.Attempts))
+	case finishErr != nil:
+		s.logger.Error("Failed to update capture job", zap.Int64("job_id", job.ID), zap.Error(finishErr))
+	}
+}
+
+// linkExists returns the error of a capture whose link is already saved.
+// A job claimed again after its lease ran out may find the link saved by
+// the claim before, which is a success and link gets the saved id.
+func (s *LinkService) linkExists(ctx context.Context, job *models.CaptureJob, link *models.Link) error {
+	if job.Attempts > 1 {
+		saved, err := s.GetArticleFromDatabase(ctx, job.UserID, job.OriginalURL, time.Time{})
+		if err == nil {
+			link.ID = saved.ID
+			return nil
+		}
+		s.logger.Error("Failed to get saved link of capture job", zap.Int64("job_id", job.ID), zap.Error(err))
+	}
+	return permanentError{errors.New("link already exists, use RecaptureLink for a new snapshot")}
+}
+
+// organizeLink applies the tags and the collection of an imported bookmark
+// to its saved link. The link is kept as is when that fails.
+func (s *LinkService) organizeLink(ctx context.Context, job *models.CaptureJob, linkID int) {
+	if len(job.Tags) > 0 {
+		if _, err := s.db.AddTags(ctx, job.UserID, linkID, job.Tags); err != nil {
+			s.logger.Error("Failed to tag captured link", zap.Int64("job_id", job.ID), zap.Error(err))
+		}
+	}
+
+	if job.CollectionID != 0 {
+		if err := s.db.MoveLinks(ctx, job.UserID, []int{linkID}, job.CollectionID); err != nil {
+			s.logger.Error("Failed to move captured link", zap.Int64("job_id", job.ID), zap.Error(err))
+		}
+	}
+}
+
+// captureBackoff doubles the delay after every failed attempt.
+func captureBackoff(attempts int) time.Duration {
+	after := captureRetryBase
+	for i := 1; i < attempts && after < captureRetryMax; i++ {
+		after *= 2
+	}
+	return min(after, captureRetryMax)
+}
+
+// capture downloads the page of job and prepares the link to save.
+func (s *LinkService) capture(job *models.CaptureJob) (*models.Link, error) {
+	page, err := s.fetcher.Fetch(job.OriginalURL)
+	if err != nil {
+		return nil, classifyFetchError(err)
+	}
+	s.logger.Debug("Visited link", zap.String("url", job.OriginalURL), zap.Int("status_code", page.StatusCode))
+
+	if !page.IsHTML() {
+		return nil, permanentError{errors.New("link data is missing, not an html page")}
+	}
+
+	link := &models.Link{
+		OriginalURL: job.OriginalURL,
+		UserID:      job.UserID,
+		Description: job.Description,
+		Content:     page.Body,
+		DateAdded:   job.DateAdded,
+	}
+
+	if s.captureCfg.WARC {
+		link.WARC = s.recordWARC(page)
+	}
+
+	article, err := extract.FromHTML(page.Body, page.URL)
+	if err != nil {
+		s.logger.Error("Failed to extract article", zap.String("url", link.OriginalURL), zap.Error(err))
+	} else {
+		link.ContentText = article.Text
+		link.Title = article.Title
+		link.Byline = article.Byline
+		link.LeadImage = article.LeadImage
+		link.WordCount = article.WordCount
+		link.Article = article.Content
+	}
+
+	if s.inliner != nil {
+		s.inlineAssets(link, page.URL)
+	}
+
+	return link, nil
+}
+
+// recordWARC returns the page as it was fetched in a WARC file, before
+// assets are inlined. The capture goes on without it when that fails.
+func (s *LinkService) recordWARC(page *fetch.Page) []byte {
+	var buf bytes.Buffer
+	err := warc.Write(&buf, &warc.Exchange{
+		URL:            page.URL,
+		Date:           page.Date,
+		Method:         page.Method,
+		RequestHeader:  page.RequestHeader,
+		StatusCode:     page.StatusCode,
+		ResponseHeader: page.Header,
+		Body:           page.Body,
+	})
+	if err != nil {
+		s.logger.Error("Failed to record WARC", zap.String("url", page.URL.String()), zap.Error(err))
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// snapshotOf returns the captured page of link as a new snapshot.
+func snapshotOf(link *models.Link) *models.Snapshot {
+	return &models.Snapshot{
+		Content:     link.Content,
+		ContentText: link.ContentText,
+		Title:       link.Title,
+		Byline:      link.Byline,
+		LeadImage:   link.LeadImage,
+		WordCount:   link.WordCount,
+		Article:     link.Article,
+		WARC:        link.WARC,
+	}
+}
+
+// classifyFetchError tells network troubles and server errors,
+// which are worth a retry, from pages that are just not there.
+func classifyFetchError(err error) error {
+	var statusErr *fetch.StatusError
+	switch {
+	case errors.Is(err, fetch.ErrInvalidURL):
+		return permanentError{fmt.Errorf("invalid link: %w", err)}
+	case !errors.As(err, &statusErr):
+		return fmt.Errorf("failed to fetch page: %w", err)
+	case statusErr.StatusCode == http.StatusRequestTimeout,
+		statusErr.StatusCode == http.StatusTooManyRequests,
+		statusErr.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("failed to fetch page: %w", err)
+	default:
+		return permanentError{fmt.Errorf("failed to fetch page: %w", err)}
+	}
+}
+
+// forbiddenReason explains why the fetcher refused a link,
+// it is empty for errors that are not about the link itself.
+func forbiddenReason(err error) string {
+	switch {
+	case errors.Is(err, fetch.ErrUnsupportedScheme):
+		return "only http and https links are supported"
+	case errors.Is(err, fetch.ErrForbiddenAddress):
+		return "link points to a private or local address"
+	case errors.Is(err, fetch.ErrInvalidURL):
+		return "invalid link"
+	default:
+		return ""
+	}
+}
+
+func captureStatus(s string) gen.CaptureStatus {
+	switch s {
+	case models.CaptureStatusPending:
+		return gen.CaptureStatus_CAPTURE_STATUS_PENDING
+	case models.CaptureStatusRunning:
+		return gen.CaptureStatus_CAPTURE_STATUS_RUNNING
+	case models.CaptureStatusDone:
+		return gen.CaptureStatus_CAPTURE_STATUS_DONE
+	case models.CaptureStatusFailed:
+		return gen.CaptureStatus_CAPTURE_STATUS_FAILED
+	default:
+		return gen.CaptureStatus_CAPTURE_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/cached/redis"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/link-saver-api/internal/logger"
+	"github.com/0x0FACED/link-saver-api/internal/share"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/storage/memory"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/alicebob/miniredis/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestRedis returns a client of a Redis server that runs in the test.
+func newTestRedis(t *testing.T) (*redis.Redis, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	return redis.New(config.RedisConfig{Host: mr.Host(), Port: mr.Port()}), mr
+}
+
+func newTestService(t *testing.T) *LinkService {
+	t.Helper()
+
+	cfg := config.CaptureConfig{
+		Workers:     8,
+		MaxAttempts: 1,
+		Timeout:     5 * time.Second,
+		// httptest servers listen on loopback
+		Allowlist: []string{"127.0.0.1"},
+	}
+	fetcher, err := fetch.New(cfg)
+	if err != nil {
+		t.Fatalf("fetch.New(): %v", err)
+	}
+
+	r, _ := newTestRedis(t)
+	return &LinkService{
+		db:         memory.New(),
+		redis:      r,
+		logger:     logger.NewNop(),
+		fetcher:    fetcher,
+		captureCfg: cfg,
+		shareCfg:   config.ShareConfig{TTL: time.Hour},
+		signer:     share.NewSigner([]byte("test")),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// TestConcurrentSaveLink checks that parallel captures don't mix up
+// their pages, run it with -race.
+func TestConcurrentSaveLink(t *testing.T) {
+	const n = 50
+	const userID = 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// slow responses keep many captures in flight at once
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><head><title>%[1]s</title></head><body><p>page %[1]s</p></body></html>", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartCaptureWorkers(ctx)
+
+	jobs := make([]int64, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := s.SaveLink(ctx, &gen.SaveLinkRequest{
+				UserId:      userID,
+				OriginalUrl: fmt.Sprintf("%s/page/%d", srv.URL, i),
+				Description: fmt.Sprintf("page %d", i),
+			})
+			if err != nil {
+				t.Errorf("SaveLink(%d): %v", i, err)
+				return
+			}
+			jobs[i] = resp.JobId
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	for i, id := range jobs {
+		waitCapture(t, s, userID, id)
+
+		url := fmt.Sprintf("%s/page/%d", srv.URL, i)
+		content, err := s.GetContentFromDatabase(ctx, userID, url, time.Time{})
+		if err != nil {
+			t.Fatalf("GetContentFromDatabase(%s): %v", url, err)
+		}
+		if want := fmt.Sprintf("page /page/%d<", i); !strings.Contains(string(content), want) {
+			t.Errorf("content of %s = %q, want it to contain %q", url, content, want)
+		}
+	}
+}
+
+func TestRecaptureLink(t *testing.T) {
+	const userID = 1
+
+	var version atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><body><p>version %d</p></body></html>", version.Load())
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartCaptureWorkers(ctx)
+
+	saved, err := s.SaveLink(ctx, &gen.SaveLinkRequest{UserId: userID, OriginalUrl: srv.URL, Description: "page"})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	waitCapture(t, s, userID, saved.JobId)
+	job, _ := s.GetCaptureStatus(ctx, &gen.GetCaptureStatusRequest{UserId: userID, JobId: saved.JobId})
+
+	version.Store(1)
+	if _, err := s.RecaptureLink(ctx, &gen.RecaptureLinkRequest{UserId: 2, LinkId: job.LinkId}); status.Code(err) != codes.NotFound {
+		t.Errorf("RecaptureLink() of the link of another user = %v, want NotFound", err)
+	}
+	recaptured, err := s.RecaptureLink(ctx, &gen.RecaptureLinkRequest{UserId: userID, LinkId: job.LinkId})
+	if err != nil {
+		t.Fatalf("RecaptureLink(): %v", err)
+	}
+	waitCapture(t, s, userID, recaptured.JobId)
+
+	resp, err := s.GetSnapshots(ctx, &gen.GetSnapshotsRequest{UserId: userID, LinkId: job.LinkId})
+	if err != nil {
+		t.Fatalf("GetSnapshots(): %v", err)
+	}
+	if len(resp.Snapshots) != 2 {
+		t.Fatalf("GetSnapshots() returned %d snapshots, want 2", len(resp.Snapshots))
+	}
+
+	content, err := s.GetContentFromDatabase(ctx, userID, srv.URL, time.Time{})
+	if err != nil {
+		t.Fatalf("GetContentFromDatabase(): %v", err)
+	}
+	if !strings.Contains(string(content), "version 1") {
+		t.Errorf("latest content = %q, want version 1", content)
+	}
+
+	// captures within the same second share the timestamp,
+	// so only the lookup of the older one is checked
+	at, err := ParseSnapshotTime(resp.Snapshots[1].Timestamp)
+	if err != nil {
+		t.Fatalf("ParseSnapshotTime(%q): %v", resp.Snapshots[1].Timestamp, err)
+	}
+	if _, err := s.GetContentFromDatabase(ctx, userID, srv.URL, at); err != nil {
+		t.Errorf("GetContentFromDatabase(at %s): %v", resp.Snapshots[1].Timestamp, err)
+	}
+}
+
+func TestCaptureWARC(t *testing.T) {
+	const userID = 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("X-Served-By", "test")
+		fmt.Fprint(w, "<html><body><p>archived</p></body></html>")
+	}))
+	defer srv.Close()
+
+	for _, record := range []bool{true, false} {
+		s := newTestService(t)
+		s.captureCfg.WARC = record
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s.StartCaptureWorkers(ctx)
+
+		saved, err := s.SaveLink(ctx, &gen.SaveLinkRequest{UserId: userID, OriginalUrl: srv.URL, Description: "page"})
+		if err != nil {
+			t.Fatalf("SaveLink(): %v", err)
+		}
+		waitCapture(t, s, userID, saved.JobId)
+
+		r, err := s.OpenWARCFromDatabase(ctx, userID, srv.URL, time.Time{})
+		if !record {
+			if !errors.Is(err, storage.ErrWARCNotFound) {
+				t.Errorf("OpenWARCFromDatabase() with WARC files off err = %v, want ErrWARCNotFound", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("OpenWARCFromDatabase(): %v", err)
+		}
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			t.Fatalf("gzip.NewReader(): %v", err)
+		}
+		data, err := io.ReadAll(zr)
+		r.Close()
+		if err != nil {
+			t.Fatalf("failed to read WARC file: %v", err)
+		}
+
+		for _, want := range []string{
+			"WARC-Type: request",
+			"WARC-Type: response",
+			"WARC-Target-URI: " + srv.URL,
+			"HTTP/1.1 200 OK",
+			"X-Served-By: test",
+			"<p>archived</p>",
+		} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("WARC file has no %q:\n%s", want, data)
+			}
+		}
+	}
+}
+
+// TestReclaimedCapture checks a job whose lease ran out after its link
+// was saved, the next claim finishes it and the first one can't.
+func TestReclaimedCapture(t *testing.T) {
+	const userID = 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<html><body><p>page</p></body></html>")
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	ctx := context.Background()
+
+	saved, err := s.SaveLink(ctx, &gen.SaveLinkRequest{UserId: userID, OriginalUrl: srv.URL, Description: "page"})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	// a worker that is too slow, the lease is over while it captures
+	s.captureCfg.MaxAttempts = 2
+	first, err := s.db.ClaimCaptureJob(ctx, -time.Minute, s.captureCfg.MaxAttempts)
+	if err != nil {
+		t.Fatalf("ClaimCaptureJob(): %v", err)
+	}
+	link, err := s.capture(first)
+	if err != nil {
+		t.Fatalf("capture(): %v", err)
+	}
+	if err := s.saveToDatabase(ctx, link); err != nil {
+		t.Fatalf("saveToDatabase(): %v", err)
+	}
+
+	if !s.runNextCapture(ctx) {
+		t.Fatal("runNextCapture() found no job, want the reclaimed one")
+	}
+	waitCapture(t, s, userID, saved.JobId)
+	job, _ := s.GetCaptureStatus(ctx, &gen.GetCaptureStatusRequest{UserId: userID, JobId: saved.JobId})
+	if int(job.LinkId) != link.ID {
+		t.Errorf("reclaimed job has link %d, want %d", job.LinkId, link.ID)
+	}
+
+	if err := s.db.CompleteCaptureJob(ctx, first.ID, first.Attempts, link.ID); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("CompleteCaptureJob() of the first claim err = %v, want ErrJobLeaseLost", err)
+	}
+}
+
+// TestCloseCaptureWorkers checks that Close waits for a capture in flight
+// on shutdown, and that it is saved.
+func TestCloseCaptureWorkers(t *testing.T) {
+	const userID = 1
+
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<html><body><p>page</p></body></html>")
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartCaptureWorkers(ctx)
+
+	saved, err := s.SaveLink(ctx, &gen.SaveLinkRequest{UserId: userID, OriginalUrl: srv.URL, Description: "page"})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	<-started
+	cancel()
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+
+	job, err := s.GetCaptureStatus(context.Background(), &gen.GetCaptureStatusRequest{UserId: userID, JobId: saved.JobId})
+	if err != nil {
+		t.Fatalf("GetCaptureStatus(): %v", err)
+	}
+	if job.Status != gen.CaptureStatus_CAPTURE_STATUS_DONE {
+		t.Errorf("capture in flight on Close() has status %v, want done", job.Status)
+	}
+}
+
+func TestSaveLinkForbidden(t *testing.T) {
+	s := newTestService(t)
+
+	for _, url := range []string{
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.1/",
+		"http://[::1]:8080/",
+		"file:///etc/passwd",
+	} {
+		_, err := s.SaveLink(context.Background(), &gen.SaveLinkRequest{
+			UserId:      1,
+			OriginalUrl: url,
+		})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("SaveLink(%s) = %v, want InvalidArgument", url, err)
+		}
+	}
+}
+
+func waitCapture(t *testing.T, s *LinkService, userID, jobID int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := s.GetCaptureStatus(context.Background(), &gen.GetCaptureStatusRequest{
+			UserId: userID,
+			JobId:  jobID,
+		})
+		if err != nil {
+			t.Fatalf("GetCaptureStatus(%d): %v", jobID, err)
+		}
+		switch resp.Status {
+		case gen.CaptureStatus_CAPTURE_STATUS_DONE:
+			return
+		case gen.CaptureStatus_CAPTURE_STATUS_FAILED:
+			t.Fatalf("capture %d failed: %s", jobID, resp.Error)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("capture %d is not done in time", jobID)
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const linkCheckPollInterval = time.Minute
+
+// StartLinkChecker starts checking saved links for link rot in the
+// background, it stops when ctx is done and Close waits for it. A zero
+// interval disables it.
+func (s *LinkService) StartLinkChecker(ctx context.Context) {
+	if s.checkCfg.Interval <= 0 {
+		s.logger.Info("Link checker disabled")
+		return
+	}
+
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		s.linkChecker(ctx, newDomainLimiter(s.checkCfg.DomainDelay))
+	}()
+	s.logger.Info("Link checker started",
+		zap.Duration("interval", s.checkCfg.Interval),
+		zap.Duration("domain_delay", s.checkCfg.DomainDelay),
+		zap.Int("workers", s.checkCfg.Workers),
+	)
+}
+
+func (s *LinkService) linkChecker(ctx context.Context, limiter *domainLimiter) {
+	ticker := time.NewTicker(linkCheckPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// check everything that is due before going to sleep
+		for s.runLinkChecks(ctx, limiter) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runLinkChecks checks one batch of due links and reports whether the
+// batch was full, so there may be more.
+func (s *LinkService) runLinkChecks(ctx context.Context, limiter *domainLimiter) bool {
+	checks, err := s.db.ClaimLinkChecks(ctx, s.checkCfg.Interval, s.checkCfg.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to claim link checks", zap.Error(err))
+		return false
+	}
+
+	queue := make(chan *models.LinkCheck)
+	var wg sync.WaitGroup
+	for i := 0; i < max(s.checkCfg.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range queue {
+				if limiter.wait(ctx, storage.Domain(c.OriginalURL)) != nil {
+					continue
+				}
+				s.checkLink(ctx, c)
+			}
+		}()
+	}
+
+	for _, c := range checks {
+		queue <- c
+	}
+	close(queue)
+	wg.Wait()
+
+	return len(checks) == s.checkCfg.BatchSize && ctx.Err() == nil
+}
+
+func (s *LinkService) checkLink(ctx context.Context, c *models.LinkCheck) {
+	p, err := s.fetcher.Probe(ctx, c.OriginalURL)
+	if ctx.Err() != nil {
+		// shutting down says nothing about the link
+		return
+	}
+
+	classifyCheck(c, p, err, s.checkCfg.DeadAfter)
+
+	s.logger.Debug("Link checked",
+		zap.Int("link_id", c.LinkID),
+		zap.String("url", c.OriginalURL),
+		zap.String("status", c.Status),
+		zap.Int("status_code", c.StatusCode),
+		zap.Int("failures", c.Failures),
+	)
+
+	err = s.db.RecordLinkCheck(ctx, c)
+	if err != nil && !errors.Is(err, storage.ErrLinksNotFound) {
+		s.logger.Error("Failed to record link check", zap.Int("link_id", c.LinkID), zap.Error(err))
+	}
+}
+
+// classifyCheck sets the new state of c from the probe result. A link
+// is dead only after deadAfter failures in a row, so a short outage does
+// not mark it. Answers that only say the checker is not welcome are
+// recorded without changing the state.
+func classifyCheck(c *models.LinkCheck, p *fetch.Probe, err error, deadAfter int) {
+	c.Location = ""
+	c.Error = ""
+	c.StatusCode = 0
+
+	if err != nil {
+		c.Error = err.Error()
+		fail(c, deadAfter)
+		return
+	}
+
+	c.StatusCode = p.StatusCode
+	switch code := p.StatusCode; {
+	case code >= 200 && code <= 299:
+		c.Failures = 0
+		c.Status = models.LinkStatusOK
+		if p.Permanent && !samePage(c.OriginalURL, p.URL) {
+			c.Status = models.LinkStatusMoved
+			c.Location = p.URL.String()
+		}
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusTooManyRequests:
+		c.Error = http.StatusText(code)
+	default:
+		c.Error = http.StatusText(code)
+		fail(c, deadAfter)
+	}
+}
+
+func fail(c *models.LinkCheck, deadAfter int) {
+	c.Failures++
+	if c.Failures >= deadAfter {
+		c.Status = models.LinkStatusDead
+	}
+}
+
+// samePage tells whether a redirect only normalized the address: an
+// upgrade to https, a www prefix or a trailing slash.
+func samePage(rawURL string, to *url.URL) bool {
+	from, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return storage.Domain(rawURL) == storage.Domain(to.String()) &&
+		strings.TrimSuffix(from.Path, "/") == strings.TrimSuffix(to.Path, "/") &&
+		from.RawQuery == to.RawQuery
+}
+
+// domainLimiter spaces requests to the same domain at least delay apart.
+type domainLimiter struct {
+	delay time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func newDomainLimiter(delay time.Duration) *domainLimiter {
+	return &domainLimiter{
+		delay: delay,
+		next:  make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request to domain is allowed, or ctx is done.
+func (l *domainLimiter) wait(ctx context.Context, domain string) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[domain]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[domain] = at.Add(l.delay)
+	// forget domains that are free again
+	for d, next := range l.next {
+		if next.Before(now) {
+			delete(l.next, d)
+		}
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(at.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (s *LinkService) GetBrokenLinks(ctx context.Context, req *gen.GetBrokenLinksRequest) (*gen.GetBrokenLinksResponse, error) {
+	s.logger.Debug("New req GetBrokenLinks()", zap.Int64("user", req.UserId))
+
+	checks, err := s.db.GetBrokenLinks(ctx, req.UserId)
+	if err != nil {
+		s.logger.Error("Failed to get broken links", zap.Int64("user", req.UserId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to get broken links: %v", err)
+	}
+
+	resp := &gen.GetBrokenLinksResponse{Links: make([]*gen.BrokenLink, 0, len(checks))}
+	for _, c := range checks {
+		b := &gen.BrokenLink{
+			Link: &gen.Link{
+				LinkId:      int32(c.LinkID),
+				OriginalUrl: c.OriginalURL,
+				Description: c.Description,
+			},
+			Health:     linkHealth(c.Status),
+			StatusCode: int32(c.StatusCode),
+			MovedTo:    c.Location,
+			Error:      c.Error,
+			CheckedAt:  c.CheckedAt.Unix(),
+		}
+		if !c.LastOKAt.IsZero() {
+			b.LastOkAt = c.LastOKAt.Unix()
+		}
+		resp.Links = append(resp.Links, b)
+	}
+
+	return resp, nil
+}
+
+func linkHealth(s string) gen.LinkHealth {
+	switch s {
+	case models.LinkStatusOK:
+		return gen.LinkHealth_LINK_HEALTH_OK
+	case models.LinkStatusMoved:
+		return gen.LinkHealth_LINK_HEALTH_MOVED
+	case models.LinkStatusDead:
+		return gen.LinkHealth_LINK_HEALTH_DEAD
+	default:
+		return gen.LinkHealth_LINK_HEALTH_UNSPECIFIED
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+func TestClassifyCheck(t *testing.T) {
+	moved, _ := url.Parse("https://example.com/new")
+	upgraded, _ := url.Parse("https://www.example.com/old/")
+
+	tests := []struct {
+		name     string
+		status   string
+		failures int
+		probe    *fetch.Probe
+		err      error
+		want     string
+		wantFail int
+	}{
+		{"ok", models.LinkStatusUnknown, 0, &fetch.Probe{StatusCode: 200}, nil, models.LinkStatusOK, 0},
+		{"recovered", models.LinkStatusDead, 5, &fetch.Probe{StatusCode: 204}, nil, models.LinkStatusOK, 0},
+		{"moved", models.LinkStatusOK, 0, &fetch.Probe{StatusCode: 200, URL: moved, Permanent: true}, nil, models.LinkStatusMoved, 0},
+		{"temporary redirect", models.LinkStatusOK, 0, &fetch.Probe{StatusCode: 200, URL: moved}, nil, models.LinkStatusOK, 0},
+		{"https upgrade", models.LinkStatusOK, 0, &fetch.Probe{StatusCode: 200, URL: upgraded, Permanent: true}, nil, models.LinkStatusOK, 0},
+		{"first failure", models.LinkStatusOK, 0, &fetch.Probe{StatusCode: 404}, nil, models.LinkStatusOK, 1},
+		{"dead", models.LinkStatusOK, 2, &fetch.Probe{StatusCode: 500}, nil, models.LinkStatusDead, 3},
+		{"network error", models.LinkStatusUnknown, 2, nil, errors.New("no such host"), models.LinkStatusDead, 3},
+		{"forbidden", models.LinkStatusOK, 1, &fetch.Probe{StatusCode: 403}, nil, models.LinkStatusOK, 1},
+		{"rate limited", models.LinkStatusDead, 4, &fetch.Probe{StatusCode: 429}, nil, models.LinkStatusDead, 4},
+	}
+
+	for _, tt := range tests {
+		c := &models.LinkCheck{OriginalURL: "http://example.com/old", Status: tt.status, Failures: tt.failures}
+		classifyCheck(c, tt.probe, tt.err, 3)
+		if c.Status != tt.want || c.Failures != tt.wantFail {
+			t.Errorf("%s: status %s with %d failures, want %s with %d", tt.name, c.Status, c.Failures, tt.want, tt.wantFail)
+		}
+		if tt.want == models.LinkStatusMoved && c.Location != moved.String() {
+			t.Errorf("%s: location %q, want %q", tt.name, c.Location, moved)
+		}
+	}
+}
+
+func TestDomainLimiter(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	l := newDomainLimiter(delay)
+	ctx := context.Background()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.wait(ctx, "example.com")
+		}()
+	}
+	// other domains don't wait for example.com
+	l.wait(ctx, "example.org")
+	if d := time.Since(start); d >= delay {
+		t.Errorf("wait() for another domain took %v", d)
+	}
+	wg.Wait()
+	if d := time.Since(start); d < 2*delay {
+		t.Errorf("three waits for one domain took %v, want at least %v", d, 2*delay)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	l.wait(ctx, "example.net")
+	if err := l.wait(cancelled, "example.net"); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetBrokenLinks(t *testing.T) {
+	const userID = 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	s.checkCfg = config.CheckConfig{Interval: time.Hour, Workers: 2, BatchSize: 10, DeadAfter: 1}
+	ctx := context.Background()
+
+	for _, path := range []string{"/gone", "/old", "/fine"} {
+		l := &models.Link{UserID: userID, OriginalURL: srv.URL + path, Description: path}
+		if err := s.db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(%s): %v", path, err)
+		}
+	}
+
+	if more := s.runLinkChecks(ctx, newDomainLimiter(0)); more {
+		t.Errorf("runLinkChecks() = true for a batch that was not full")
+	}
+
+	resp, err := s.GetBrokenLinks(ctx, &gen.GetBrokenLinksRequest{UserId: userID})
+	if err != nil {
+		t.Fatalf("GetBrokenLinks(): %v", err)
+	}
+	if len(resp.Links) != 2 {
+		t.Fatalf("GetBrokenLinks() = %v, want 2 links", resp.Links)
+	}
+
+	gone, old := resp.Links[0], resp.Links[1]
+	if gone.Link.Description != "/gone" || gone.Health != gen.LinkHealth_LINK_HEALTH_DEAD || gone.StatusCode != 404 || gone.LastOkAt != 0 {
+		t.Errorf("dead link = %v", gone)
+	}
+	if old.Link.Description != "/old" || old.Health != gen.LinkHealth_LINK_HEALTH_MOVED || old.MovedTo != srv.URL+"/new" || old.LastOkAt == 0 {
+		t.Errorf("moved link = %v", old)
+	}
+}
+
+// TestCloseLinkChecker checks that Close waits for the link checker,
+// which gives up the checks in flight when ctx is done.
+func TestCloseLinkChecker(t *testing.T) {
+	started := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	s := newTestService(t)
+	s.checkCfg = config.CheckConfig{Interval: time.Hour, Workers: 1, BatchSize: 10, DeadAfter: 1}
+	l := &models.Link{UserID: 1, OriginalURL: srv.URL, Description: "slow"}
+	if err := s.db.SaveLink(context.Background(), l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.StartLinkChecker(ctx)
+	<-started
+	cancel()
+
+	closed := make(chan error)
+	go func() {
+		closed <- s.Close()
+	}()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close(): %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return after the link checker was stopped")
+	}
+}
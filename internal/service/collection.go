@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxCollectionName is the limit of a collection name in characters.
+const maxCollectionName = 64
+
+func (s *LinkService) CreateCollection(ctx context.Context, req *gen.CreateCollectionRequest) (*gen.CreateCollectionResponse, error) {
+	s.logger.Debug("New req CreateCollection()",
+		zap.Int64("user", req.UserId),
+		zap.String("name", req.Name),
+		zap.Int32("parent_id", req.ParentId),
+	)
+
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &models.Collection{
+		UserID:   req.UserId,
+		ParentID: int(req.ParentId),
+		Name:     name,
+	}
+	if err := s.db.CreateCollection(ctx, c); err != nil {
+		return nil, s.collectionError("Failed to create collection", req.UserId, req.ParentId, err)
+	}
+
+	return &gen.CreateCollectionResponse{
+		Collection: &gen.Collection{
+			CollectionId: int32(c.ID),
+			ParentId:     int32(c.ParentID),
+			Name:         c.Name,
+		},
+	}, nil
+}
+
+func (s *LinkService) RenameCollection(ctx context.Context, req *gen.RenameCollectionRequest) (*gen.RenameCollectionResponse, error) {
+	s.logger.Debug("New req RenameCollection()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("collection_id", req.CollectionId),
+		zap.String("name", req.Name),
+	)
+
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.RenameCollection(ctx, req.UserId, int(req.CollectionId), name); err != nil {
+		return nil, s.collectionError("Failed to rename collection", req.UserId, req.CollectionId, err)
+	}
+
+	return &gen.RenameCollectionResponse{Success: true, Message: "Successfully renamed"}, nil
+}
+
+func (s *LinkService) DeleteCollection(ctx context.Context, req *gen.DeleteCollectionRequest) (*gen.DeleteCollectionResponse, error) {
+	s.logger.Debug("New req DeleteCollection()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("collection_id", req.CollectionId),
+	)
+
+	if err := s.db.DeleteCollection(ctx, req.UserId, int(req.CollectionId)); err != nil {
+		return nil, s.collectionError("Failed to delete collection", req.UserId, req.CollectionId, err)
+	}
+
+	return &gen.DeleteCollectionResponse{Success: true, Message: "Successfully deleted"}, nil
+}
+
+func (s *LinkService) GetCollections(ctx context.Context, req *gen.GetCollectionsRequest) (*gen.GetCollectionsResponse, error) {
+	s.logger.Debug("New req GetCollections()",
+		zap.Int64("user", req.UserId),
+	)
+
+	collections, err := s.db.GetCollections(ctx, req.UserId)
+	if err != nil {
+		s.logger.Error("Failed to get collections", zap.Int64("user", req.UserId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to get collections: %v", err)
+	}
+
+	return &gen.GetCollectionsResponse{Collections: collections}, nil
+}
+
+func (s *LinkService) MoveLinks(ctx context.Context, req *gen.MoveLinksRequest) (*gen.MoveLinksResponse, error) {
+	s.logger.Debug("New req MoveLinks()",
+		zap.Int64("user", req.UserId),
+		zap.Int32s("link_ids", req.LinkIds),
+		zap.Int32("collection_id", req.CollectionId),
+	)
+
+	if len(req.LinkIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No links given")
+	}
+
+	ids := make([]int, 0, len(req.LinkIds))
+	for _, id := range req.LinkIds {
+		ids = append(ids, int(id))
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	if err := s.db.MoveLinks(ctx, req.UserId, ids, int(req.CollectionId)); err != nil {
+		if errors.Is(err, storage.ErrLinksNotFound) {
+			return nil, status.Error(codes.NotFound, "Some of the links are not found")
+		}
+		return nil, s.collectionError("Failed to move links", req.UserId, req.CollectionId, err)
+	}
+
+	return &gen.MoveLinksResponse{Success: true, Message: "Successfully moved"}, nil
+}
+
+// collectionName returns the trimmed name, or an InvalidArgument error.
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionName {
+		return "", status.Errorf(codes.InvalidArgument, "Collection name must be 1 to %d characters", maxCollectionName)
+	}
+	return name, nil
+}
+
+func (s *LinkService) collectionError(msg string, userID int64, collectionID int32, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrCollectionNotFound):
+		return status.Errorf(codes.NotFound, "Collection not found: %d", collectionID)
+	case errors.Is(err, storage.ErrCollectionExists):
+		return status.Error(codes.AlreadyExists, "Collection with this name already exists")
+	}
+
+	s.logger.Error(msg,
+		zap.Int64("user", userID),
+		zap.Int32("collection_id", collectionID),
+		zap.Error(err),
+	)
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
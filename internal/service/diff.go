@@ -0,0 +1,41 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/diff"
+	"github.com/0x0FACED/link-saver-api/internal/extract"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+)
+
+// DiffWithLive fetches the page at originalURL as it is now and compares
+// its visible text with the saved content, paragraph by paragraph.
+func (s *LinkService) DiffWithLive(originalURL string, saved []byte) ([]diff.Paragraph, error) {
+	page, err := s.fetcher.Fetch(originalURL)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to fetch live page", err)
+	}
+	if !page.IsHTML() {
+		return nil, wrap.E(pkg, "failed to diff live page", errors.New("not an html page"))
+	}
+
+	old, err := html.Parse(bytes.NewReader(saved))
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse saved page", err)
+	}
+	live, err := html.Parse(bytes.NewReader(page.Body))
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to parse live page", err)
+	}
+
+	paragraphs := diff.Paragraphs(extract.Paragraphs(old), extract.Paragraphs(live))
+	s.logger.Debug("Diffed live page",
+		zap.String("url", originalURL),
+		zap.Any("stats", diff.Count(paragraphs)),
+	)
+
+	return paragraphs, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/internal/diff"
+)
+
+func TestDiffWithLive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head><script>var build = 2;</script></head><body>
+			<h1>Pricing</h1><p>The plan costs 12 dollars per month</p><p>New plans soon</p></body></html>`)
+	}))
+	defer srv.Close()
+
+	saved := []byte(`<html><head><script>var build = 1;</script></head><body>
+		<h1>Pricing</h1><p>The plan costs 10 dollars per month</p></body></html>`)
+
+	s := newTestService(t)
+	paragraphs, err := s.DiffWithLive(srv.URL, saved)
+	if err != nil {
+		t.Fatalf("DiffWithLive(): %v", err)
+	}
+
+	// the script changed too, but it is not visible text
+	if stats := diff.Count(paragraphs); stats != (diff.Stats{Added: 1, Changed: 1}) {
+		t.Errorf("DiffWithLive() stats = %+v, want 1 added and 1 changed", stats)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/export"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportChunkSize is the largest message of ExportLinks.
+	exportChunkSize = 64 << 10
+	// exportDownloadTTL is how long a download link works.
+	exportDownloadTTL = time.Hour
+)
+
+// ErrExportDownloadNotFound means the download link expired.
+var ErrExportDownloadNotFound = errors.New("export download not found")
+
+func (s *LinkService) ExportLinks(req *gen.ExportLinksRequest, stream grpc.ServerStreamingServer[gen.ExportLinksChunk]) error {
+	s.logger.Debug("New req ExportLinks()",
+		zap.Int64("user", req.UserId),
+	)
+
+	// the archive is written in small pieces, they are sent in full chunks
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, exportChunkSize)
+	if err := s.WriteExport(stream.Context(), req.UserId, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// chunkWriter sends the archive as ExportLinks messages.
+type chunkWriter struct {
+	stream grpc.ServerStreamingServer[gen.ExportLinksChunk]
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), exportChunkSize)]
+		if err := w.stream.Send(&gen.ExportLinksChunk{Data: chunk}); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+func (s *LinkService) CreateExportDownload(ctx context.Context, req *gen.CreateExportDownloadRequest) (*gen.CreateExportDownloadResponse, error) {
+	s.logger.Debug("New req CreateExportDownload()",
+		zap.Int64("user", req.UserId),
+	)
+
+	token, err := newToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create download token: %v", err)
+	}
+
+	if err := s.redis.SaveExportToken(ctx, req.UserId, token, exportDownloadTTL); err != nil {
+		s.logger.Error("Failed to save export token", zap.Int64("user", req.UserId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to create download link: %v", err)
+	}
+
+	return &gen.CreateExportDownloadResponse{
+		DownloadUrl: fmt.Sprintf("%s/export/%d/%s", s.cfg.BaseURL, req.UserId, token),
+		ExpiresAt:   time.Now().Add(exportDownloadTTL).Unix(),
+	}, nil
+}
+
+// CheckExportDownload returns ErrExportDownloadNotFound unless the download link works.
+func (s *LinkService) CheckExportDownload(ctx context.Context, userID int64, token string) error {
+	ok, err := s.redis.ExportTokenExists(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrExportDownloadNotFound
+	}
+	return nil
+}
+
+// WriteExport writes the zip archive of all links of the user to w,
+// see the export package. The pages are streamed from the database.
+func (s *LinkService) WriteExport(ctx context.Context, userID int64, w io.Writer) error {
+	links, err := s.db.GetLinksForExport(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get links for export", zap.Int64("user", userID), zap.Error(err))
+		return status.Errorf(codes.Internal, "Failed to export links: %v", err)
+	}
+
+	collections, err := s.db.GetCollections(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get collections", zap.Int64("user", userID), zap.Error(err))
+		return status.Errorf(codes.Internal, "Failed to export links: %v", err)
+	}
+
+	err = export.Write(w, links, export.Folders(collections), func(l *models.Link) (io.ReadCloser, error) {
+		content, err := s.db.OpenContentByTelegramIDOriginalURL(ctx, userID, l.OriginalURL, time.Time{})
+		if errors.Is(err, storage.ErrSnapshotNotFound) {
+			return nil, nil
+		}
+		return content, err
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		s.logger.Error("Failed to export links", zap.Int64("user", userID), zap.Error(err))
+		return status.Errorf(codes.Internal, "Failed to export links: %v", err)
+	}
+
+	s.logger.Info("Links exported", zap.Int64("user", userID), zap.Int("links", len(links)))
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/export"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"google.golang.org/grpc"
+)
+
+// exportStream collects the messages of ExportLinks.
+type exportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks [][]byte
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportStream) Send(c *gen.ExportLinksChunk) error {
+	s.chunks = append(s.chunks, bytes.Clone(c.Data))
+	return nil
+}
+
+func TestExportLinks(t *testing.T) {
+	const userID = 1
+	ctx := context.Background()
+	s := newTestService(t)
+
+	// a page large enough to take several messages
+	page := bytes.Repeat([]byte("<p>page</p>"), exportChunkSize)
+	for _, l := range []*models.Link{
+		{OriginalURL: "https://a.example.com", UserID: userID, Description: "a", Content: page},
+		{OriginalURL: "https://b.example.com", UserID: userID, Description: "b", Content: []byte("<html>b</html>")},
+	} {
+		if err := s.db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(): %v", err)
+		}
+	}
+
+	stream := &exportStream{ctx: ctx}
+	if err := s.ExportLinks(&gen.ExportLinksRequest{UserId: userID}, stream); err != nil {
+		t.Fatalf("ExportLinks(): %v", err)
+	}
+
+	var data []byte
+	for i, c := range stream.chunks {
+		if len(c) > exportChunkSize || (len(c) < exportChunkSize && i < len(stream.chunks)-1) {
+			t.Errorf("chunk %d of %d has %d bytes, want full chunks of %d", i, len(stream.chunks), len(c), exportChunkSize)
+		}
+		data = append(data, c...)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader(): %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s): %v", f.Name, err)
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("ReadAll(%s): %v", f.Name, err)
+		}
+	}
+
+	var records []*export.Record
+	if err := json.Unmarshal(files[export.ManifestJSON], &records); err != nil {
+		t.Fatalf("json.Unmarshal(): %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("manifest has %d links, want 2", len(records))
+	}
+	for _, r := range records {
+		if files[r.Page] == nil {
+			t.Errorf("page %q of %s is missing", r.Page, r.URL)
+		}
+	}
+	if !bytes.Equal(files[records[0].Page], page) {
+		t.Errorf("page of %s has %d bytes, want %d", records[0].URL, len(files[records[0].Page]), len(page))
+	}
+}
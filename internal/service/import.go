@@ -0,0 +1,360 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/0x0FACED/link-saver-api/internal/bookmarks"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MaxImportSize limits bookmark files, exports of thousands
+	// of bookmarks are a few megabytes.
+	MaxImportSize = 32 << 20
+	// importUploadTTL is how long an upload page works.
+	importUploadTTL = time.Hour
+	// maxDescription is the limit of a link description in characters.
+	maxDescription = 32
+)
+
+// ErrImportUploadNotFound means the upload page expired or was used.
+var ErrImportUploadNotFound = errors.New("import upload not found")
+
+func (s *LinkService) ImportBookmarks(ctx context.Context, req *gen.ImportBookmarksRequest) (*gen.ImportBookmarksResponse, error) {
+	s.logger.Debug("New req ImportBookmarks()",
+		zap.Int64("user", req.UserId),
+		zap.Int("size", len(req.File)),
+		zap.String("folders", req.Folders.String()),
+	)
+
+	if len(req.File) > MaxImportSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Bookmark file is larger than %d bytes", MaxImportSize)
+	}
+
+	list, err := parseBookmarks(bytes.NewReader(req.File))
+	if err != nil {
+		return nil, err
+	}
+
+	imp, err := s.queueImport(ctx, req.UserId, list, req.Folders)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.ImportBookmarksResponse{
+		ImportId:   imp.ID,
+		Total:      int32(imp.Total),
+		Queued:     int32(imp.Queued),
+		Duplicates: int32(imp.Duplicates),
+		Invalid:    int32(imp.Invalid),
+	}, nil
+}
+
+func (s *LinkService) GetImportStatus(ctx context.Context, req *gen.GetImportStatusRequest) (*gen.GetImportStatusResponse, error) {
+	s.logger.Debug("New req GetImportStatus()",
+		zap.Int64("user", req.UserId),
+		zap.Int64("import_id", req.ImportId),
+	)
+
+	imp, err := s.db.GetImport(ctx, req.UserId, req.ImportId)
+	if err != nil {
+		if errors.Is(err, storage.ErrImportNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Import not found: %d", req.ImportId)
+		}
+		s.logger.Error("Failed to get import",
+			zap.Int64("user", req.UserId),
+			zap.Int64("import_id", req.ImportId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to get import: %v", err)
+	}
+
+	return &gen.GetImportStatusResponse{
+		ImportId:   imp.ID,
+		Total:      int32(imp.Total),
+		Queued:     int32(imp.Queued),
+		Duplicates: int32(imp.Duplicates),
+		Invalid:    int32(imp.Invalid),
+		Pending:    int32(imp.Pending),
+		Running:    int32(imp.Running),
+		Done:       int32(imp.Done),
+		Failed:     int32(imp.Failed),
+		CreatedAt:  imp.CreatedAt.Unix(),
+	}, nil
+}
+
+func (s *LinkService) CreateImportUpload(ctx context.Context, req *gen.CreateImportUploadRequest) (*gen.CreateImportUploadResponse, error) {
+	s.logger.Debug("New req CreateImportUpload()",
+		zap.Int64("user", req.UserId),
+	)
+
+	token, err := newToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create upload token: %v", err)
+	}
+
+	if err := s.redis.SaveImportToken(ctx, req.UserId, token, importUploadTTL); err != nil {
+		s.logger.Error("Failed to save import token", zap.Int64("user", req.UserId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to create upload page: %v", err)
+	}
+
+	return &gen.CreateImportUploadResponse{
+		UploadUrl: fmt.Sprintf("%s/import/%d/%s", s.cfg.BaseURL, req.UserId, token),
+		ExpiresAt: time.Now().Add(importUploadTTL).Unix(),
+	}, nil
+}
+
+// CheckImportUpload returns ErrImportUploadNotFound unless the upload page works.
+func (s *LinkService) CheckImportUpload(ctx context.Context, userID int64, token string) error {
+	ok, err := s.redis.ImportTokenExists(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrImportUploadNotFound
+	}
+	return nil
+}
+
+// ImportUpload imports a file sent to an upload page, which stops
+// working once the file is queued. A file that is not a bookmark file
+// is an InvalidArgument status and leaves the page as is.
+func (s *LinkService) ImportUpload(ctx context.Context, userID int64, token string, r io.Reader, folders gen.FolderMapping) (*models.Import, error) {
+	s.logger.Debug("New import upload",
+		zap.Int64("user", userID),
+		zap.String("folders", folders.String()),
+	)
+
+	list, err := parseBookmarks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.redis.TakeImportToken(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrImportUploadNotFound
+	}
+
+	return s.queueImport(ctx, userID, list, folders)
+}
+
+func parseBookmarks(r io.Reader) ([]*bookmarks.Bookmark, error) {
+	list, err := bookmarks.Parse(r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid bookmark file: %v", err)
+	}
+	return list, nil
+}
+
+// queueImport enqueues capture of bookmarks that are not saved yet.
+func (s *LinkService) queueImport(ctx context.Context, userID int64, list []*bookmarks.Bookmark, folders gen.FolderMapping) (*models.Import, error) {
+	urls, descriptions, err := s.db.GetTakenLinks(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get taken links", zap.Int64("user", userID), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to import bookmarks: %v", err)
+	}
+
+	var tree *collectionTree
+	if folders == gen.FolderMapping_FOLDER_MAPPING_COLLECTIONS {
+		if tree, err = s.newCollectionTree(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	imp := &models.Import{UserID: userID, Total: len(list)}
+	var jobs []*models.CaptureJob
+	for _, b := range list {
+		if !importable(b.URL) {
+			imp.Invalid++
+			continue
+		}
+		if urls[b.URL] {
+			imp.Duplicates++
+			continue
+		}
+		urls[b.URL] = true
+
+		job := &models.CaptureJob{
+			UserID:      userID,
+			OriginalURL: b.URL,
+			Description: importDescription(b, descriptions),
+			DateAdded:   b.AddDate,
+		}
+
+		tags := b.Tags
+		if folders == gen.FolderMapping_FOLDER_MAPPING_TAGS {
+			tags = append(slices.Clone(tags), b.Folders...)
+		}
+		job.Tags = importTags(tags)
+
+		if tree != nil {
+			if job.CollectionID, err = tree.collection(ctx, b.Folders); err != nil {
+				tree.undo(ctx)
+				return nil, err
+			}
+		}
+
+		jobs = append(jobs, job)
+	}
+	imp.Queued = len(jobs)
+
+	if err := s.db.CreateImport(ctx, imp, jobs); err != nil {
+		s.logger.Error("Failed to create import", zap.Int64("user", userID), zap.Error(err))
+		if tree != nil {
+			tree.undo(ctx)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to import bookmarks: %v", err)
+	}
+	if len(jobs) > 0 {
+		s.wakeCaptureWorkers()
+	}
+
+	s.logger.Info("Bookmarks queued for capture",
+		zap.Int64("user", userID),
+		zap.Int64("import_id", imp.ID),
+		zap.Int("total", imp.Total),
+		zap.Int("queued", imp.Queued),
+		zap.Int("duplicates", imp.Duplicates),
+		zap.Int("invalid", imp.Invalid),
+	)
+
+	return imp, nil
+}
+
+// importable reports whether the bookmark is a link that can be captured,
+// files also have javascript: bookmarklets and browser pages.
+func importable(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// importDescription makes a description of the bookmark that is not
+// in taken yet and adds it there. Repeated titles get a number.
+func importDescription(b *bookmarks.Bookmark, taken map[string]bool) string {
+	base := b.Title
+	if base == "" {
+		base = storage.Domain(b.URL)
+	}
+
+	desc := truncate(base, maxDescription)
+	for n := 2; taken[desc]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		desc = truncate(base, maxDescription-len(suffix)) + suffix
+	}
+	taken[desc] = true
+
+	return desc
+}
+
+// truncate cuts s to n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:n]))
+}
+
+// importTags turns folder names and browser tags into distinct tag names,
+// see storage.NormalizeTags. Names with nothing left are dropped.
+func importTags(names []string) []string {
+	var tags []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimLeft(strings.TrimSpace(name), "#"))
+		name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		}), "-")
+		name = strings.TrimRight(truncate(name, storage.MaxTagLength), "-")
+		if name != "" && !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	return tags
+}
+
+// collectionTree finds and creates the collections of bookmark folders.
+type collectionTree struct {
+	s      *LinkService
+	userID int64
+	ids    map[collectionKey]int
+	// created are the ids of the collections made for the import
+	created []int
+}
+
+type collectionKey struct {
+	parentID int
+	name     string
+}
+
+func (s *LinkService) newCollectionTree(ctx context.Context, userID int64) (*collectionTree, error) {
+	collections, err := s.db.GetCollections(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to get collections", zap.Int64("user", userID), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to get collections: %v", err)
+	}
+
+	t := &collectionTree{s: s, userID: userID, ids: make(map[collectionKey]int)}
+	for _, c := range collections {
+		t.ids[collectionKey{int(c.ParentId), c.Name}] = int(c.CollectionId)
+	}
+	return t, nil
+}
+
+// collection returns the id of the collection nested by folders,
+// the missing ones are created. It is 0 for bookmarks out of folders.
+func (t *collectionTree) collection(ctx context.Context, folders []string) (int, error) {
+	parentID := 0
+	for _, folder := range folders {
+		name := truncate(strings.TrimSpace(folder), maxCollectionName)
+		if name == "" {
+			continue
+		}
+
+		key := collectionKey{parentID, name}
+		id, ok := t.ids[key]
+		if !ok {
+			c := &models.Collection{UserID: t.userID, ParentID: parentID, Name: name}
+			if err := t.s.db.CreateCollection(ctx, c); err != nil {
+				return 0, t.s.collectionError("Failed to create collection", t.userID, int32(parentID), err)
+			}
+			id = c.ID
+			t.ids[key] = id
+			t.created = append(t.created, id)
+		}
+		parentID = id
+	}
+	return parentID, nil
+}
+
+// undo deletes the collections created for an import that failed, nested
+// ones first, also when the request was cancelled.
+func (t *collectionTree) undo(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	for i := len(t.created) - 1; i >= 0; i-- {
+		if err := t.s.db.DeleteCollection(ctx, t.userID, t.created[i]); err != nil {
+			t.s.logger.Error("Failed to delete collection of failed import",
+				zap.Int64("user", t.userID),
+				zap.Int("collection_id", t.created[i]),
+				zap.Error(err),
+			)
+		}
+	}
+	t.created = nil
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/bookmarks"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func bookmarkFile(base string) string {
+	return fmt.Sprintf(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="%[1]s/saved" ADD_DATE="1600000000">Saved</A>
+        <DT><H3>Reading List</H3>
+        <DL><p>
+            <DT><A HREF="%[1]s/a" ADD_DATE="1600000000" TAGS="Go">Page</A>
+            <DT><H3>Later</H3>
+            <DL><p>
+                <DT><A HREF="%[1]s/b" ADD_DATE="1600000100">Page</A>
+            </DL><p>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="%[1]s/a">Page again</A>
+    <DT><A HREF="javascript:alert(1)">Bookmarklet</A>
+</DL><p>
+`, base)
+}
+
+// waitImport waits until every queued link of the import is captured or failed.
+func waitImport(t *testing.T, s *LinkService, userID, importID int64) *gen.GetImportStatusResponse {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := s.GetImportStatus(context.Background(), &gen.GetImportStatusRequest{
+			UserId:   userID,
+			ImportId: importID,
+		})
+		if err != nil {
+			t.Fatalf("GetImportStatus(%d): %v", importID, err)
+		}
+		if resp.Pending == 0 && resp.Running == 0 {
+			return resp
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("import %d is not done in time", importID)
+	return nil
+}
+
+func TestImportBookmarks(t *testing.T) {
+	const userID = 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><body><p>page %s</p></body></html>", r.URL.Path)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		folders gen.FolderMapping
+		// tags of /a and /b
+		tagsA, tagsB []string
+		// collections of /a and /b as paths
+		collectionA, collectionB []string
+	}{
+		{
+			folders: gen.FolderMapping_FOLDER_MAPPING_TAGS,
+			tagsA:   []string{"go", "reading-list"},
+			tagsB:   []string{"later", "reading-list"},
+		},
+		{
+			folders:     gen.FolderMapping_FOLDER_MAPPING_COLLECTIONS,
+			tagsA:       []string{"go"},
+			collectionA: []string{"Reading List"},
+			collectionB: []string{"Reading List", "Later"},
+		},
+		{
+			folders: gen.FolderMapping_FOLDER_MAPPING_NONE,
+			tagsA:   []string{"go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.folders.String(), func(t *testing.T) {
+			s := newTestService(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			saved, err := s.SaveLink(ctx, &gen.SaveLinkRequest{
+				UserId:      userID,
+				OriginalUrl: srv.URL + "/saved",
+				Description: "Page",
+			})
+			if err != nil {
+				t.Fatalf("SaveLink(): %v", err)
+			}
+
+			resp, err := s.ImportBookmarks(ctx, &gen.ImportBookmarksRequest{
+				UserId:  userID,
+				File:    []byte(bookmarkFile(srv.URL)),
+				Folders: tt.folders,
+			})
+			if err != nil {
+				t.Fatalf("ImportBookmarks(): %v", err)
+			}
+			if resp.Total != 5 || resp.Queued != 2 || resp.Duplicates != 2 || resp.Invalid != 1 {
+				t.Errorf("ImportBookmarks() = %+v, want 5 total, 2 queued, 2 duplicates and 1 invalid", resp)
+			}
+
+			s.StartCaptureWorkers(ctx)
+			waitCapture(t, s, userID, saved.JobId)
+			st := waitImport(t, s, userID, resp.ImportId)
+			if st.Done != 2 || st.Failed != 0 || st.Queued != 2 {
+				t.Errorf("GetImportStatus() = %+v, want 2 done", st)
+			}
+
+			// the description of the saved link was taken, titles are made unique
+			a, err := s.GetArticleFromDatabase(ctx, userID, srv.URL+"/a", time.Time{})
+			if err != nil {
+				t.Fatalf("GetArticleFromDatabase(/a): %v", err)
+			}
+			b, err := s.GetArticleFromDatabase(ctx, userID, srv.URL+"/b", time.Time{})
+			if err != nil {
+				t.Fatalf("GetArticleFromDatabase(/b): %v", err)
+			}
+			if a.Description != "Page (2)" || b.Description != "Page (3)" {
+				t.Errorf("descriptions = %q, %q, want Page (2) and Page (3)", a.Description, b.Description)
+			}
+			if !a.DateAdded.Equal(time.Unix(1600000000, 0)) || !b.DateAdded.Equal(time.Unix(1600000100, 0)) {
+				t.Errorf("dates added = %v, %v, want the dates of the bookmarks", a.DateAdded, b.DateAdded)
+			}
+
+			tags := func(id int) []string {
+				// adding no tags returns the current ones
+				got, err := s.db.AddTags(ctx, userID, id, nil)
+				if err != nil {
+					t.Fatalf("AddTags(%d): %v", id, err)
+				}
+				slices.Sort(got)
+				return got
+			}
+			if got := tags(a.ID); !slices.Equal(got, tt.tagsA) {
+				t.Errorf("tags of /a = %v, want %v", got, tt.tagsA)
+			}
+			if got := tags(b.ID); !slices.Equal(got, tt.tagsB) {
+				t.Errorf("tags of /b = %v, want %v", got, tt.tagsB)
+			}
+
+			collections, err := s.GetCollections(ctx, &gen.GetCollectionsRequest{UserId: userID})
+			if err != nil {
+				t.Fatalf("GetCollections(): %v", err)
+			}
+			if got, want := collectionPath(collections.Collections, linkCollection(t, s, userID, a.ID)), tt.collectionA; !slices.Equal(got, want) {
+				t.Errorf("collection of /a = %v, want %v", got, want)
+			}
+			if got, want := collectionPath(collections.Collections, linkCollection(t, s, userID, b.ID)), tt.collectionB; !slices.Equal(got, want) {
+				t.Errorf("collection of /b = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// linkCollection returns the collection of the link, 0 for the top level.
+func linkCollection(t *testing.T, s *LinkService, userID int64, linkID int) int32 {
+	t.Helper()
+
+	collections, err := s.GetCollections(context.Background(), &gen.GetCollectionsRequest{UserId: userID})
+	if err != nil {
+		t.Fatalf("GetCollections(): %v", err)
+	}
+	for _, c := range collections.Collections {
+		resp, err := s.GetAllLinks(context.Background(), &gen.GetAllLinksRequest{UserId: userID, CollectionId: c.CollectionId})
+		if err != nil {
+			t.Fatalf("GetAllLinks(%d): %v", c.CollectionId, err)
+		}
+		for _, l := range resp.Links {
+			if int(l.LinkId) == linkID {
+				return c.CollectionId
+			}
+		}
+	}
+	return 0
+}
+
+func collectionPath(collections []*gen.Collection, id int32) []string {
+	var path []string
+	for id != 0 {
+		i := slices.IndexFunc(collections, func(c *gen.Collection) bool { return c.CollectionId == id })
+		path = append([]string{collections[i].Name}, path...)
+		id = collections[i].ParentId
+	}
+	return path
+}
+
+// failingImports is a database where imports can't be created.
+type failingImports struct {
+	storage.Database
+}
+
+func (failingImports) CreateImport(context.Context, *models.Import, []*models.CaptureJob) error {
+	return errors.New("database is down")
+}
+
+// TestImportBookmarksFailed checks that collections made for an import
+// are deleted when the import can't be created.
+func TestImportBookmarksFailed(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	kept := &models.Collection{UserID: 1, Name: "Reading List"}
+	if err := s.db.CreateCollection(ctx, kept); err != nil {
+		t.Fatalf("CreateCollection(): %v", err)
+	}
+	s.db = failingImports{s.db}
+
+	_, err := s.ImportBookmarks(ctx, &gen.ImportBookmarksRequest{
+		UserId:  1,
+		File:    []byte(bookmarkFile("https://example.com")),
+		Folders: gen.FolderMapping_FOLDER_MAPPING_COLLECTIONS,
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("ImportBookmarks() = %v, want Internal", err)
+	}
+
+	collections, err := s.db.GetCollections(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCollections(): %v", err)
+	}
+	if len(collections) != 1 || int(collections[0].CollectionId) != kept.ID {
+		t.Errorf("GetCollections() after a failed import = %v, want only the collection made before", collections)
+	}
+}
+
+func TestImportBookmarksInvalidFile(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.ImportBookmarks(context.Background(), &gen.ImportBookmarksRequest{
+		UserId: 1,
+		File:   []byte("<html><body>not bookmarks</body></html>"),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("ImportBookmarks() err = %v, want InvalidArgument", err)
+	}
+
+	_, err = s.GetImportStatus(context.Background(), &gen.GetImportStatusRequest{UserId: 1, ImportId: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("GetImportStatus() of a missing import err = %v, want NotFound", err)
+	}
+}
+
+func TestImportDescription(t *testing.T) {
+	taken := map[string]bool{"Go": true}
+	long := strings.Repeat("я", 40)
+
+	tests := []struct {
+		b    *bookmarks.Bookmark
+		want string
+	}{
+		{&bookmarks.Bookmark{URL: "https://go.dev", Title: "Go"}, "Go (2)"},
+		{&bookmarks.Bookmark{URL: "https://go.dev/doc", Title: "Go"}, "Go (3)"},
+		{&bookmarks.Bookmark{URL: "https://www.example.com/x"}, "example.com"},
+		{&bookmarks.Bookmark{URL: "https://example.com/1", Title: long}, strings.Repeat("я", 32)},
+		{&bookmarks.Bookmark{URL: "https://example.com/2", Title: long}, strings.Repeat("я", 28) + " (2)"},
+	}
+
+	for _, tt := range tests {
+		if got := importDescription(tt.b, taken); got != tt.want {
+			t.Errorf("importDescription(%q) = %q, want %q", tt.b.Title, got, tt.want)
+		}
+	}
+}
+
+func TestImportTags(t *testing.T) {
+	got := importTags([]string{"Go", "#go", " Reading  List ", "a,b", "#", strings.Repeat("x", 31) + " y"})
+	want := []string{"go", "reading-list", "a-b", strings.Repeat("x", 31)}
+	if !slices.Equal(got, want) {
+		t.Errorf("importTags() = %v, want %v", got, want)
+	}
+}
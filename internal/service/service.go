@@ -1,13 +1,21 @@
 package service
 
 import (
+	"crypto/rand"
+	"sync"
+
 	"github.com/0x0FACED/link-saver-api/config"
 	"github.com/0x0FACED/link-saver-api/internal/cached/redis"
+	"github.com/0x0FACED/link-saver-api/internal/fetch"
+	"github.com/0x0FACED/link-saver-api/internal/inline"
 	"github.com/0x0FACED/link-saver-api/internal/logger"
+	"github.com/0x0FACED/link-saver-api/internal/share"
 	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/storage/blobstore"
+	"github.com/0x0FACED/link-saver-api/internal/storage/memory"
 	"github.com/0x0FACED/link-saver-api/internal/storage/postgres"
+	"github.com/0x0FACED/link-saver-api/internal/storage/sqlite"
 	"github.com/0x0FACED/proto-files/link_service/gen"
-	"github.com/gocolly/colly"
 	"go.uber.org/zap"
 )
 
@@ -16,11 +24,22 @@ var pkg = "service"
 type LinkService struct {
 	gen.UnimplementedLinkServiceServer
 
-	db     storage.Database
-	redis  *redis.Redis
-	logger *logger.ZapLogger
-	colly  *colly.Collector
-	cfg    config.GRPCConfig
+	db      storage.Database
+	redis   *redis.Redis
+	logger  *logger.ZapLogger
+	fetcher *fetch.Fetcher
+	// inliner is nil when assets are not inlined
+	inliner    *inline.Inliner
+	cfg        config.GRPCConfig
+	captureCfg config.CaptureConfig
+	checkCfg   config.CheckConfig
+	shareCfg   config.ShareConfig
+	// signer makes and checks the tokens of share links
+	signer *share.Signer
+	// wake signals capture workers about new jobs
+	wake chan struct{}
+	// workers are the background goroutines, Close waits for them
+	workers sync.WaitGroup
 }
 
 func New(cfg *config.Config, redis *redis.Redis, logger *logger.ZapLogger) *LinkService {
@@ -31,15 +50,27 @@ func New(cfg *config.Config, redis *redis.Redis, logger *logger.ZapLogger) *Link
 		zap.String("db_username", cfg.Database.Username),
 		zap.String("db_password", cfg.Database.Password),
 		zap.String("db_driver", cfg.Database.Driver),
+		zap.String("db_path", cfg.Database.Path),
 	)
 
-	// TODO: add more drivers
+	blobs, err := blobstore.New(cfg.Blob)
+	if err != nil {
+		logger.Fatal("Failed to create blob store", zap.Error(err))
+	}
+	if blobs != nil {
+		logger.Info("Page content is kept in a blob store", zap.String("store", cfg.Blob.Store))
+	}
+
 	var db storage.Database
 	switch cfg.Database.Driver {
+	case "sqlite":
+		db = sqlite.New(cfg.Database, blobs)
+	case "memory":
+		db = memory.New()
 	case "postgres":
-		db = postgres.New(cfg.Database)
+		db = postgres.New(cfg.Database, blobs)
 	default:
-		db = postgres.New(cfg.Database)
+		db = postgres.New(cfg.Database, blobs)
 	}
 
 	if db.Connect() == storage.ErrConnectDB {
@@ -48,18 +79,51 @@ func New(cfg *config.Config, redis *redis.Redis, logger *logger.ZapLogger) *Link
 
 	logger.Info("Successfully connected to database")
 
-	c := colly.NewCollector(
-		colly.Async(true),
-		colly.AllowURLRevisit(),
+	fetcher, err := fetch.New(cfg.Capture)
+	if err != nil {
+		logger.Fatal("Failed to create fetcher", zap.Error(err))
+	}
+
+	logger.Info("Created fetcher",
+		zap.Duration("timeout", cfg.Capture.Timeout),
+		zap.Strings("allowlist", cfg.Capture.Allowlist),
 	)
 
-	logger.Info("Created colly instance")
+	var inliner *inline.Inliner
+	if cfg.Capture.InlineAssets {
+		inliner = inline.New(fetcher, cfg.Capture.InlineMaxSize)
+		logger.Info("Assets of saved pages will be inlined",
+			zap.Int64("max_size", cfg.Capture.InlineMaxSize),
+		)
+	}
+
+	key := []byte(cfg.Share.Key)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			logger.Fatal("Failed to create share key", zap.Error(err))
+		}
+		logger.Info("SHARE_KEY is not set, share links will stop working on restart")
+	}
 
 	return &LinkService{
-		db:     db,
-		redis:  redis,
-		logger: logger,
-		colly:  c,
-		cfg:    cfg.GRPC,
+		db:         db,
+		redis:      redis,
+		logger:     logger,
+		fetcher:    fetcher,
+		inliner:    inliner,
+		cfg:        cfg.GRPC,
+		captureCfg: cfg.Capture,
+		checkCfg:   cfg.Check,
+		shareCfg:   cfg.Share,
+		signer:     share.NewSigner(key),
+		wake:       make(chan struct{}, 1),
 	}
 }
+
+// Close waits for the background workers to stop, once the context they
+// were started with is done, and closes the database.
+func (s *LinkService) Close() error {
+	s.workers.Wait()
+	return s.db.Close()
+}
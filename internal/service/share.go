@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/share"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// minShareTTL and maxShareTTL limit the lifetime of a share link
+	// that expires, longer ones should not expire at all.
+	minShareTTL = time.Minute
+	maxShareTTL = 10 * 365 * 24 * time.Hour
+	// maxSharePasswordSize is the most bcrypt takes.
+	maxSharePasswordSize = 72
+	// shareUnlockTTL is how long a browser is let in after the password
+	// was entered, at most until the link expires.
+	shareUnlockTTL = 12 * time.Hour
+)
+
+var (
+	// ErrShareNotFound means the share link is not valid or does not open
+	// the view, ErrShareExpired, ErrShareRevoked and ErrShareUsed that it
+	// stopped working.
+	ErrShareNotFound = errors.New("share link not found")
+	ErrShareExpired  = errors.New("share link expired")
+	ErrShareRevoked  = errors.New("share link revoked")
+	ErrShareUsed     = errors.New("share link already used")
+)
+
+var shareScopes = map[gen.ShareScope]share.Scope{
+	gen.ShareScope_SHARE_SCOPE_PAGE: share.ScopePage,
+	gen.ShareScope_SHARE_SCOPE_DIFF: share.ScopeDiff,
+	gen.ShareScope_SHARE_SCOPE_WARC: share.ScopeWARC,
+}
+
+// GetLink creates a share link of the saved page. The link is a signed
+// token, it works until its ttl passes, it is revoked or, for one-time
+// links, viewed.
+func (s *LinkService) GetLink(ctx context.Context, req *gen.GetLinkRequest) (*gen.GetLinkResponse, error) {
+	s.logger.Debug("New req GetLink()",
+		zap.Int64("user", req.UserId),
+		zap.String("desc", req.Description),
+		zap.Int32("url_id", req.UrlId),
+		zap.Int64("ttl_seconds", req.TtlSeconds),
+		zap.Bool("one_time", req.OneTime),
+		zap.Bool("password", req.Password != ""),
+	)
+
+	scope := share.ScopeAll
+	if len(req.Scopes) > 0 {
+		scope = 0
+		for _, sc := range req.Scopes {
+			v, ok := shareScopes[sc]
+			if !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "Invalid share scope: %s", sc)
+			}
+			scope |= v
+		}
+	}
+
+	ttl := s.shareCfg.TTL
+	switch {
+	case req.TtlSeconds < 0:
+		ttl = 0
+	case req.TtlSeconds > 0:
+		// checked in seconds, so large values can't overflow
+		if req.TtlSeconds < int64(minShareTTL/time.Second) || req.TtlSeconds > int64(maxShareTTL/time.Second) {
+			return nil, status.Errorf(codes.InvalidArgument, "ttl_seconds must be from %d to %d, negative for a link that never expires",
+				int64(minShareTTL/time.Second), int64(maxShareTTL/time.Second))
+		}
+		ttl = time.Duration(req.TtlSeconds) * time.Second
+	}
+
+	sh := &models.Share{LinkID: int(req.UrlId), OneTime: req.OneTime}
+	if ttl > 0 {
+		// tokens keep the expiry in seconds
+		sh.ExpiresAt = time.Now().Add(ttl).UTC().Truncate(time.Second)
+	}
+	if req.Password != "" {
+		if len(req.Password) > maxSharePasswordSize {
+			return nil, status.Errorf(codes.InvalidArgument, "Password must be at most %d bytes", maxSharePasswordSize)
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to hash password: %v", err)
+		}
+		sh.PasswordHash = string(hash)
+	}
+	if err := s.db.CreateShare(ctx, req.UserId, sh); err != nil {
+		if errors.Is(err, storage.ErrLinksNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Link not found: %d", req.UrlId)
+		}
+		s.logger.Error("Failed to create share",
+			zap.Int64("user", req.UserId),
+			zap.Int32("url_id", req.UrlId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to create share link: %v", err)
+	}
+
+	token := s.signer.Sign(&share.Claims{
+		ShareID: sh.ID,
+		LinkID:  sh.LinkID,
+		Expires: sh.ExpiresAt,
+		Scope:   scope,
+	})
+	// archived pages are served from their own host when there is one
+	baseURL := s.cfg.BaseURL
+	if s.cfg.ContentURL != "" {
+		baseURL = s.cfg.ContentURL
+	}
+	fullURL := getFullLink(baseURL, token)
+
+	s.logger.Debug("Generated Full Link",
+		zap.Int64("share_id", sh.ID),
+		zap.String("full_link", fullURL),
+	)
+
+	resp := &gen.GetLinkResponse{GeneratedUrl: fullURL, ShareId: sh.ID}
+	if !sh.ExpiresAt.IsZero() {
+		resp.ExpiresAt = sh.ExpiresAt.Unix()
+	}
+	return resp, nil
+}
+
+func (s *LinkService) RevokeShareLinks(ctx context.Context, req *gen.RevokeShareLinksRequest) (*gen.RevokeShareLinksResponse, error) {
+	s.logger.Debug("New req RevokeShareLinks()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("link_id", req.LinkId),
+		zap.Int64("share_id", req.ShareId),
+	)
+
+	n, err := s.db.RevokeShares(ctx, req.UserId, int(req.LinkId), req.ShareId)
+	if err != nil {
+		if errors.Is(err, storage.ErrLinksNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Link not found: %d", req.LinkId)
+		}
+		s.logger.Error("Failed to revoke shares",
+			zap.Int64("user", req.UserId),
+			zap.Int32("link_id", req.LinkId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to revoke share links: %v", err)
+	}
+
+	return &gen.RevokeShareLinksResponse{Revoked: int32(n)}, nil
+}
+
+// OpenShare returns the share of a token made by GetLink if it opens
+// the view scope, see ErrShareNotFound.
+func (s *LinkService) OpenShare(ctx context.Context, token string, scope share.Scope) (*models.Share, error) {
+	claims, err := s.signer.Verify(token, time.Now())
+	if errors.Is(err, share.ErrExpired) {
+		return nil, ErrShareExpired
+	}
+	if err != nil || !claims.Scope.Has(scope) {
+		return nil, ErrShareNotFound
+	}
+
+	// the token is checked first, so made up ones never reach the database
+	sh, err := s.db.GetShare(ctx, claims.ShareID)
+	if err != nil {
+		if errors.Is(err, storage.ErrShareNotFound) {
+			return nil, ErrShareNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetShare()", err)
+	}
+	if sh.LinkID != claims.LinkID {
+		return nil, ErrShareNotFound
+	}
+	if !sh.RevokedAt.IsZero() {
+		return nil, ErrShareRevoked
+	}
+	if sh.OneTime && !sh.UsedAt.IsZero() {
+		return nil, ErrShareUsed
+	}
+
+	return sh, nil
+}
+
+// OpenLegacyLink returns the share token that a link generated before
+// share links were signed redirects to. The share is made on the first
+// visit and expires with the generated link, see ErrShareNotFound.
+func (s *LinkService) OpenLegacyLink(ctx context.Context, userID int64, generated string) (string, error) {
+	token, err := s.redis.GetShareToken(ctx, userID, generated)
+	if err != nil {
+		return "", wrap.E(pkg, "failed to GetShareToken()", err)
+	}
+	if token != "" {
+		return token, nil
+	}
+
+	original, ttl, err := s.redis.GetOriginalURL(ctx, userID, generated)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrShareNotFound
+		}
+		return "", wrap.E(pkg, "failed to GetOriginalURL()", err)
+	}
+
+	l, err := s.GetArticleFromDatabase(ctx, userID, original, time.Time{})
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) || errors.Is(err, storage.ErrLinksNotFound) ||
+			errors.Is(err, storage.ErrSnapshotNotFound) {
+			return "", ErrShareNotFound
+		}
+		return "", wrap.E(pkg, "failed to GetArticleFromDatabase()", err)
+	}
+
+	// tokens keep the expiry in seconds
+	sh := &models.Share{LinkID: l.ID, ExpiresAt: time.Now().Add(ttl).UTC().Truncate(time.Second)}
+	if err := s.db.CreateShare(ctx, userID, sh); err != nil {
+		if errors.Is(err, storage.ErrLinksNotFound) {
+			return "", ErrShareNotFound
+		}
+		return "", wrap.E(pkg, "failed to CreateShare()", err)
+	}
+
+	token = s.signer.Sign(&share.Claims{
+		ShareID: sh.ID,
+		LinkID:  sh.LinkID,
+		Expires: sh.ExpiresAt,
+		Scope:   share.ScopeAll,
+	})
+	if err := s.redis.SaveShareToken(ctx, userID, generated, token, ttl); err != nil {
+		// the next visit makes another share
+		s.logger.Error("Failed to save share token of generated link",
+			zap.Int64("share_id", sh.ID),
+			zap.Error(err),
+		)
+	}
+
+	return token, nil
+}
+
+// UseShare is called when a view of the share is served, one-time
+// shares stop working then. ErrShareUsed means another view was first.
+func (s *LinkService) UseShare(ctx context.Context, sh *models.Share) error {
+	if !sh.OneTime {
+		return nil
+	}
+
+	err := s.db.UseShare(ctx, sh.ID)
+	if errors.Is(err, storage.ErrShareUsed) {
+		return ErrShareUsed
+	}
+	if err != nil {
+		return wrap.E(pkg, "failed to UseShare()", err)
+	}
+	return nil
+}
+
+// CheckSharePassword reports whether password opens the share.
+func (s *LinkService) CheckSharePassword(sh *models.Share, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(sh.PasswordHash), []byte(password)) == nil
+}
+
+// UnlockShare returns the token that lets a browser into the share
+// after its password was entered and when the token expires.
+func (s *LinkService) UnlockShare(sh *models.Share) (string, time.Time) {
+	expires := time.Now().Add(shareUnlockTTL)
+	if !sh.ExpiresAt.IsZero() && sh.ExpiresAt.Before(expires) {
+		expires = sh.ExpiresAt
+	}
+	return s.signer.SignUnlock(sh.ID, expires), expires
+}
+
+// ShareUnlocked reports whether a share needs no password, or token
+// is of UnlockShare.
+func (s *LinkService) ShareUnlocked(sh *models.Share, token string) bool {
+	return sh.PasswordHash == "" || s.signer.VerifyUnlock(token, sh.ID, time.Now())
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/share"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// shareToken returns the token of a link made by GetLink.
+func shareToken(t *testing.T, s *LinkService, req *gen.GetLinkRequest) (string, *gen.GetLinkResponse) {
+	t.Helper()
+
+	resp, err := s.GetLink(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetLink(): %v", err)
+	}
+	token, ok := strings.CutPrefix(resp.GeneratedUrl, "/gen/")
+	if !ok || strings.Contains(token, "/") {
+		t.Fatalf("GetLink() = %q, want /gen/<token>", resp.GeneratedUrl)
+	}
+	return token, resp
+}
+
+func TestShareLinks(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	l := &models.Link{UserID: 1, OriginalURL: "https://example.com/", Description: "example", Content: []byte("<p>page</p>")}
+	if err := s.db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	if _, err := s.GetLink(ctx, &gen.GetLinkRequest{UserId: 2, UrlId: int32(l.ID)}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetLink() of another user = %v, want NotFound", err)
+	}
+	if _, err := s.GetLink(ctx, &gen.GetLinkRequest{UserId: 1, UrlId: int32(l.ID), Scopes: []gen.ShareScope{0}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetLink() with an unspecified scope = %v, want InvalidArgument", err)
+	}
+
+	token, resp := shareToken(t, s, &gen.GetLinkRequest{UserId: 1, UrlId: int32(l.ID)})
+	if left := time.Until(time.Unix(resp.ExpiresAt, 0)); left <= 0 || left > time.Hour {
+		t.Errorf("GetLink() expires in %s, want within the share ttl", left)
+	}
+
+	for _, scope := range []share.Scope{share.ScopePage, share.ScopeDiff, share.ScopeWARC} {
+		sh, err := s.OpenShare(ctx, token, scope)
+		if err != nil {
+			t.Fatalf("OpenShare(%d): %v", scope, err)
+		}
+		if sh.ID != resp.ShareId || sh.UserID != 1 || sh.OriginalURL != l.OriginalURL {
+			t.Errorf("OpenShare(%d) = %+v", scope, sh)
+		}
+	}
+
+	pageOnly, _ := shareToken(t, s, &gen.GetLinkRequest{UserId: 1, UrlId: int32(l.ID), Scopes: []gen.ShareScope{gen.ShareScope_SHARE_SCOPE_PAGE}})
+	if _, err := s.OpenShare(ctx, pageOnly, share.ScopePage); err != nil {
+		t.Errorf("OpenShare() of the page = %v", err)
+	}
+	if _, err := s.OpenShare(ctx, pageOnly, share.ScopeWARC); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenShare() of a view out of scope = %v, want ErrShareNotFound", err)
+	}
+
+	altered := []byte(token)
+	altered[len(altered)/2] ^= 1
+	for name, bad := range map[string]string{
+		"made up":    "abc",
+		"altered":    string(altered),
+		"unknown":    s.signer.Sign(&share.Claims{ShareID: 100, LinkID: l.ID, Scope: share.ScopeAll}),
+		"other link": s.signer.Sign(&share.Claims{ShareID: resp.ShareId, LinkID: l.ID + 1, Scope: share.ScopeAll}),
+	} {
+		if _, err := s.OpenShare(ctx, bad, share.ScopePage); !errors.Is(err, ErrShareNotFound) {
+			t.Errorf("OpenShare(%s) = %v, want ErrShareNotFound", name, err)
+		}
+	}
+
+	expired := s.signer.Sign(&share.Claims{ShareID: resp.ShareId, LinkID: l.ID, Expires: time.Now().Add(-time.Second), Scope: share.ScopeAll})
+	if _, err := s.OpenShare(ctx, expired, share.ScopePage); !errors.Is(err, ErrShareExpired) {
+		t.Errorf("OpenShare() of an expired link = %v, want ErrShareExpired", err)
+	}
+
+	revoked, err := s.RevokeShareLinks(ctx, &gen.RevokeShareLinksRequest{UserId: 1, LinkId: int32(l.ID), ShareId: resp.ShareId})
+	if err != nil || revoked.Revoked != 1 {
+		t.Fatalf("RevokeShareLinks() = %v, %v, want 1 revoked", revoked, err)
+	}
+	if _, err := s.OpenShare(ctx, token, share.ScopePage); !errors.Is(err, ErrShareRevoked) {
+		t.Errorf("OpenShare() of a revoked link = %v, want ErrShareRevoked", err)
+	}
+	if _, err := s.OpenShare(ctx, pageOnly, share.ScopePage); err != nil {
+		t.Errorf("OpenShare() of a link that was not revoked = %v", err)
+	}
+
+	if _, err := s.RevokeShareLinks(ctx, &gen.RevokeShareLinksRequest{UserId: 2, LinkId: int32(l.ID)}); status.Code(err) != codes.NotFound {
+		t.Errorf("RevokeShareLinks() of another user = %v, want NotFound", err)
+	}
+	if revoked, err := s.RevokeShareLinks(ctx, &gen.RevokeShareLinksRequest{UserId: 1, LinkId: int32(l.ID)}); err != nil || revoked.Revoked != 1 {
+		t.Errorf("RevokeShareLinks() of all links = %v, %v, want 1 revoked", revoked, err)
+	}
+}
+
+// TestShareDeletedLink checks that a token resolves to its link only
+// while the link is saved, saving the page again doesn't bring it back.
+func TestShareDeletedLink(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	l := &models.Link{UserID: 1, OriginalURL: "https://example.com/", Description: "example", Content: []byte("<p>page</p>")}
+	if err := s.db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	token, _ := shareToken(t, s, &gen.GetLinkRequest{UserId: 1, UrlId: int32(l.ID)})
+	if sh, err := s.OpenShare(ctx, token, share.ScopePage); err != nil || sh.LinkID != l.ID {
+		t.Fatalf("OpenShare() = %+v, %v, want the share of link %d", sh, err, l.ID)
+	}
+
+	if _, err := s.DeleteLink(ctx, &gen.DeleteLinkRequest{LinkId: int32(l.ID)}); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := s.OpenShare(ctx, token, share.ScopePage); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenShare() of a deleted link = %v, want ErrShareNotFound", err)
+	}
+
+	again := &models.Link{UserID: 1, OriginalURL: l.OriginalURL, Description: "example", Content: []byte("<p>page</p>")}
+	if err := s.db.SaveLink(ctx, again); err != nil {
+		t.Fatalf("SaveLink() again: %v", err)
+	}
+	if _, err := s.OpenShare(ctx, token, share.ScopePage); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenShare() after saving the page again = %v, want ErrShareNotFound", err)
+	}
+}
+
+// TestLegacyLink checks links generated before share links were signed:
+// they open a share that expires with them until the page is deleted.
+func TestLegacyLink(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	r, mr := newTestRedis(t)
+	s.redis = r
+
+	l := &models.Link{UserID: 1, OriginalURL: "https://example.com/", Description: "example", Content: []byte("<p>page</p>")}
+	if err := s.db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	// saved without the token key, as before the reverse index
+	mr.Set("links:1:"+l.OriginalURL, "1:abc")
+	mr.SetTTL("links:1:"+l.OriginalURL, time.Hour)
+	mr.SAdd("links:1:urls", l.OriginalURL)
+
+	token, err := s.OpenLegacyLink(ctx, 1, "abc")
+	if err != nil {
+		t.Fatalf("OpenLegacyLink(): %v", err)
+	}
+	sh, err := s.OpenShare(ctx, token, share.ScopeDiff)
+	if err != nil || sh.LinkID != l.ID {
+		t.Fatalf("OpenShare() of the legacy link = %+v, %v, want a share of link %d", sh, err, l.ID)
+	}
+	if left := time.Until(sh.ExpiresAt); left <= 59*time.Minute || left > time.Hour {
+		t.Errorf("share of the legacy link expires in %s, want with the link in an hour", left)
+	}
+	if again, err := s.OpenLegacyLink(ctx, 1, "abc"); err != nil || again != token {
+		t.Errorf("OpenLegacyLink() again = %q, %v, want the same share", again, err)
+	}
+
+	for _, generated := range []string{"other", ""} {
+		if _, err := s.OpenLegacyLink(ctx, 1, generated); !errors.Is(err, ErrShareNotFound) {
+			t.Errorf("OpenLegacyLink(%q) = %v, want ErrShareNotFound", generated, err)
+		}
+	}
+	if _, err := s.OpenLegacyLink(ctx, 2, "abc"); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenLegacyLink() of another user = %v, want ErrShareNotFound", err)
+	}
+
+	if _, err := s.DeleteLink(ctx, &gen.DeleteLinkRequest{LinkId: int32(l.ID)}); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := s.OpenLegacyLink(ctx, 1, "abc"); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenLegacyLink() of a deleted page = %v, want ErrShareNotFound", err)
+	}
+	if _, err := s.OpenShare(ctx, token, share.ScopePage); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("OpenShare() of a deleted page = %v, want ErrShareNotFound", err)
+	}
+}
+
+func TestShareLinkOptions(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+
+	l := &models.Link{UserID: 1, OriginalURL: "https://example.com/", Description: "example", Content: []byte("<p>page</p>")}
+	if err := s.db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	req := func(ttl int64, oneTime bool, password string) *gen.GetLinkRequest {
+		return &gen.GetLinkRequest{UserId: 1, UrlId: int32(l.ID), TtlSeconds: ttl, OneTime: oneTime, Password: password}
+	}
+
+	for _, bad := range []*gen.GetLinkRequest{req(30, false, ""), req(1<<62, false, ""), req(0, false, strings.Repeat("p", 73))} {
+		if _, err := s.GetLink(ctx, bad); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetLink(ttl %d, password of %d) = %v, want InvalidArgument", bad.TtlSeconds, len(bad.Password), err)
+		}
+	}
+
+	if _, resp := shareToken(t, s, req(-1, false, "")); resp.ExpiresAt != 0 {
+		t.Errorf("GetLink() that never expires expires at %d", resp.ExpiresAt)
+	}
+	if _, resp := shareToken(t, s, req(120, false, "")); time.Until(time.Unix(resp.ExpiresAt, 0)) > 2*time.Minute {
+		t.Errorf("GetLink() for 2 minutes expires at %d", resp.ExpiresAt)
+	}
+
+	token, _ := shareToken(t, s, req(0, true, ""))
+	sh, err := s.OpenShare(ctx, token, share.ScopePage)
+	if err != nil || !sh.OneTime {
+		t.Fatalf("OpenShare() of a one-time link = %+v, %v", sh, err)
+	}
+	if err := s.UseShare(ctx, sh); err != nil {
+		t.Fatalf("UseShare(): %v", err)
+	}
+	if err := s.UseShare(ctx, sh); !errors.Is(err, ErrShareUsed) {
+		t.Errorf("UseShare() twice = %v, want ErrShareUsed", err)
+	}
+	if _, err := s.OpenShare(ctx, token, share.ScopePage); !errors.Is(err, ErrShareUsed) {
+		t.Errorf("OpenShare() of a used link = %v, want ErrShareUsed", err)
+	}
+
+	token, _ = shareToken(t, s, req(0, false, "secret"))
+	sh, err = s.OpenShare(ctx, token, share.ScopePage)
+	if err != nil {
+		t.Fatalf("OpenShare() of a protected link: %v", err)
+	}
+	if s.ShareUnlocked(sh, "") {
+		t.Error("ShareUnlocked() without a password = true")
+	}
+	if s.CheckSharePassword(sh, "wrong") || !s.CheckSharePassword(sh, "secret") {
+		t.Error("CheckSharePassword() does not tell the password")
+	}
+	unlock, expires := s.UnlockShare(sh)
+	if !s.ShareUnlocked(sh, unlock) {
+		t.Error("ShareUnlocked() with the token of UnlockShare = false")
+	}
+	if !expires.Equal(sh.ExpiresAt) {
+		t.Errorf("UnlockShare() expires at %s, want the expiry of the link %s", expires, sh.ExpiresAt)
+	}
+	// the unlock of one share does not open another
+	other, _ := shareToken(t, s, req(0, false, "secret"))
+	if sh, _ := s.OpenShare(ctx, other, share.ScopePage); sh == nil || s.ShareUnlocked(sh, unlock) {
+		t.Error("ShareUnlocked() of another share = true")
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SnapshotTimeLayout is the format of snapshot timestamps,
+// the at query parameter of generated urls uses it too.
+const SnapshotTimeLayout = "20060102150405"
+
+func (s *LinkService) RecaptureLink(ctx context.Context, req *gen.RecaptureLinkRequest) (*gen.RecaptureLinkResponse, error) {
+	s.logger.Debug("New req RecaptureLink()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("link_id", req.LinkId),
+	)
+
+	l, err := s.db.GetLinkByID(ctx, int(req.LinkId))
+	// links of other users are reported as missing
+	if err != nil || l.UserID != req.UserId {
+		return nil, status.Errorf(codes.NotFound, "Link not found: %d", req.LinkId)
+	}
+
+	id, err := s.db.EnqueueCapture(ctx, &models.CaptureJob{
+		UserID:      req.UserId,
+		OriginalURL: l.OriginalURL,
+		Description: l.Description,
+		LinkID:      l.ID,
+		Recapture:   true,
+	})
+	if err != nil {
+		s.logger.Error("Failed to enqueue recapture",
+			zap.Int64("user", req.UserId),
+			zap.Int32("link_id", req.LinkId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to enqueue capture: %v", err)
+	}
+	s.wakeCaptureWorkers()
+
+	s.logger.Debug("Recapture enqueued", zap.Int64("user", req.UserId), zap.Int64("job_id", id))
+
+	return &gen.RecaptureLinkResponse{JobId: id}, nil
+}
+
+func (s *LinkService) GetSnapshots(ctx context.Context, req *gen.GetSnapshotsRequest) (*gen.GetSnapshotsResponse, error) {
+	s.logger.Debug("New req GetSnapshots()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("link_id", req.LinkId),
+	)
+
+	snapshots, err := s.db.GetSnapshots(ctx, req.UserId, int(req.LinkId))
+	if err != nil {
+		return nil, s.linkError("Failed to get snapshots", req.UserId, req.LinkId, err)
+	}
+
+	resp := &gen.GetSnapshotsResponse{Snapshots: make([]*gen.Snapshot, 0, len(snapshots))}
+	for _, snap := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, &gen.Snapshot{
+			SnapshotId: int32(snap.ID),
+			Timestamp:  snap.CapturedAt.UTC().Format(SnapshotTimeLayout),
+			CapturedAt: snap.CapturedAt.Unix(),
+			Title:      snap.Title,
+			WordCount:  int32(snap.WordCount),
+		})
+	}
+
+	return resp, nil
+}
+
+// snapshotBefore turns a snapshot timestamp into the bound of the
+// storage lookups: snapshots captured within that second still match.
+func snapshotBefore(at time.Time) time.Time {
+	if at.IsZero() {
+		return at
+	}
+	return at.Truncate(time.Second).Add(time.Second)
+}
+
+// ParseSnapshotTime parses the at query parameter of generated urls,
+// an empty one means the latest snapshot and gives the zero time.
+func ParseSnapshotTime(at string) (time.Time, error) {
+	if at == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(SnapshotTimeLayout, at, time.UTC)
+}
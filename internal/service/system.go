@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"net/url"
+	"strings"
 
 	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
 	"github.com/0x0FACED/proto-files/link_service/gen"
-	"github.com/gocolly/colly"
-	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -18,65 +20,90 @@ func (s *LinkService) SaveLink(ctx context.Context, req *gen.SaveLinkRequest) (*
 		zap.String("desc", req.Description),
 		zap.String("url", req.OriginalUrl),
 	)
-	statusCode := -1
-	var link *models.Link
-	// onHTML -> html page visiting
-	s.colly.OnHTML("html", func(e *colly.HTMLElement) {
-		link = &models.Link{
-			OriginalURL: req.OriginalUrl,
-			UserID:      req.UserId,
-			Description: req.Description,
-			Content:     []byte(e.Response.Body),
+
+	u, err := url.ParseRequestURI(req.OriginalUrl)
+	if err != nil || u.Host == "" {
+		s.logger.Info("Not Saved, invalid link", zap.Int64("user", req.UserId), zap.String("url", req.OriginalUrl))
+		return &gen.SaveLinkResponse{Success: false, Message: "Not Saved, invalid link"}, status.Errorf(codes.InvalidArgument, "Invalid link: %s", req.OriginalUrl)
+	}
+
+	// the workers check every connection again, this is for a clear answer
+	if err := s.fetcher.Check(ctx, req.OriginalUrl); err != nil {
+		if reason := forbiddenReason(err); reason != "" {
+			s.logger.Info("Not Saved, link is not allowed",
+				zap.Int64("user", req.UserId),
+				zap.String("url", req.OriginalUrl),
+				zap.Error(err),
+			)
+			return &gen.SaveLinkResponse{Success: false, Message: "Not Saved, " + reason}, status.Errorf(codes.InvalidArgument, "Link is not allowed: %s", reason)
 		}
-		s.logger.Debug("Visited link", zap.String("url", link.OriginalURL))
-	})
+		// the host may be resolved by the time the job runs
+		s.logger.Debug("Failed to check link", zap.String("url", req.OriginalUrl), zap.Error(err))
+	}
 
-	// onError -> to handle error in scrap
-	s.colly.OnError(func(r *colly.Response, e error) {
-		statusCode = r.StatusCode
-		s.logger.Debug("OnError()", zap.Error(e), zap.Int("status_code", r.StatusCode))
+	// the page is captured in background by the capture workers
+	id, err := s.db.EnqueueCapture(ctx, &models.CaptureJob{
+		UserID:      req.UserId,
+		OriginalURL: req.OriginalUrl,
+		Description: req.Description,
 	})
-
-	// start colly
-	err := s.colly.Visit(req.OriginalUrl)
-	s.colly.Wait()
 	if err != nil {
-		s.logger.Error("Error while scrap HTML",
-			zap.Error(err),
-			zap.Int64("user", req.UserId),
-			zap.String("desc", req.Description),
-			zap.String("url", req.OriginalUrl),
-		)
-		return &gen.SaveLinkResponse{Success: false, Message: "Not Saved, invalid link"}, status.Errorf(codes.InvalidArgument, "Invalid link: %v", err)
+		s.logger.Error("Failed to enqueue capture", zap.Int64("user", req.UserId), zap.Error(err))
+		return &gen.SaveLinkResponse{Success: false, Message: "Not saved, try again later"}, status.Errorf(codes.Internal, "Failed to enqueue capture: %v", err)
 	}
+	s.wakeCaptureWorkers()
 
-	if statusCode == 0 || link == nil {
-		s.logger.Info("Not Saved", zap.Int64("user", req.UserId))
-		return &gen.SaveLinkResponse{Success: false, Message: "Not Saved, invalid link"}, status.Errorf(codes.InvalidArgument, "Link data is missing")
-	}
+	s.logger.Debug("Capture enqueued", zap.Int64("user", req.UserId), zap.Int64("job_id", id))
 
-	s.logger.Info("Finished", zap.Int64("user", req.UserId))
+	return &gen.SaveLinkResponse{Success: true, Message: "Queued for saving", JobId: id}, nil
+}
 
-	// save page as bytea to database
-	err = s.saveToDatabase(context.TODO(), link)
+func (s *LinkService) GetCaptureStatus(ctx context.Context, req *gen.GetCaptureStatusRequest) (*gen.GetCaptureStatusResponse, error) {
+	s.logger.Debug("New req GetCaptureStatus()",
+		zap.Int64("user", req.UserId),
+		zap.Int64("job_id", req.JobId),
+	)
+
+	job, err := s.db.GetCaptureJob(ctx, req.JobId)
 	if err != nil {
-		s.logger.Error("Failed to save to db", zap.Error(err))
-		return &gen.SaveLinkResponse{Success: false, Message: "Not saved, already exists"}, status.Error(codes.AlreadyExists, "link already exists")
+		if errors.Is(err, storage.ErrJobNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Capture job not found: %d", req.JobId)
+		}
+		s.logger.Error("Failed to get capture job",
+			zap.Int64("user", req.UserId),
+			zap.Int64("job_id", req.JobId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to get capture job: %v", err)
+	}
+
+	// jobs of other users are reported as missing
+	if job.UserID != req.UserId {
+		return nil, status.Errorf(codes.NotFound, "Capture job not found: %d", req.JobId)
 	}
-	s.logger.Debug("Link successfully saved to db")
 
-	return &gen.SaveLinkResponse{Success: true, Message: "Succeefully saved"}, nil
+	return &gen.GetCaptureStatusResponse{
+		JobId:    job.ID,
+		Status:   captureStatus(job.Status),
+		Attempts: int32(job.Attempts),
+		Error:    job.Error,
+		LinkId:   int32(job.LinkID),
+	}, nil
 }
 
 func (s *LinkService) DeleteLink(ctx context.Context, req *gen.DeleteLinkRequest) (*gen.DeleteLinkResponse, error) {
+	// share links are deleted with the link
 	original, id, err := s.db.DeleteLink(ctx, int(req.LinkId))
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Link not found: %v", err)
 	}
 
-	err = s.redis.DeleteLink(ctx, id, original)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to delete link from Redis: %v", err)
+	// links generated before share links were signed
+	if err := s.redis.DeleteLink(ctx, id, original); err != nil {
+		s.logger.Error("Failed to delete link from Redis",
+			zap.Int32("link_id", req.LinkId),
+			zap.Error(err),
+		)
 	}
 
 	return &gen.DeleteLinkResponse{Success: true, Message: "Successfully deleted"}, nil
@@ -86,10 +113,19 @@ func (s *LinkService) GetLinks(ctx context.Context, req *gen.GetLinksRequest) (*
 	s.logger.Debug("New req GetLinks()",
 		zap.Int64("user", req.UserId),
 		zap.String("desc", req.Description),
+		zap.String("sort", req.Sort.String()),
 	)
 
-	links, err := s.db.GetLinksByTelegramIDDesc(ctx, req.UserId, req.Description)
+	page, err := linksPage(req.Sort, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	links, next, err := s.db.GetLinksByTelegramIDDesc(ctx, req.UserId, req.Description, page)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
 		s.logger.Error("Failed to get links by username and desc",
 			zap.Int64("user", req.UserId),
 			zap.String("desc", req.Description),
@@ -98,94 +134,70 @@ func (s *LinkService) GetLinks(ctx context.Context, req *gen.GetLinksRequest) (*
 	}
 	s.logger.Debug("Found links", zap.Int64("user", req.UserId), zap.Any("links", links))
 
-	return &gen.GetLinksResponse{Links: links}, nil
+	return &gen.GetLinksResponse{Links: links, NextPageToken: next.Token()}, nil
 }
 
-func (s *LinkService) GetLink(ctx context.Context, req *gen.GetLinkRequest) (*gen.GetLinkResponse, error) {
-	s.logger.Debug("New req GetLink()",
+func (s *LinkService) GetAllLinks(ctx context.Context, req *gen.GetAllLinksRequest) (*gen.GetAllLinksResponse, error) {
+	s.logger.Debug("New req GetAllLinks()",
 		zap.Int64("user", req.UserId),
-		zap.String("desc", req.Description),
-		zap.Int32("url_id", req.UrlId),
+		zap.Int32("collection_id", req.CollectionId),
+		zap.String("sort", req.Sort.String()),
 	)
 
-	l, err := s.db.GetLinkByID(ctx, int(req.UrlId))
+	page, err := linksPage(req.Sort, req.PageSize, req.PageToken)
 	if err != nil {
-		s.logger.Error("Failed to get link by id from Postgres",
-			zap.Int64("user", req.UserId),
-			zap.String("desc", req.Description),
-			zap.Error(err),
-		)
-		return nil, status.Errorf(codes.NotFound, "Link not found: %v", err)
+		return nil, err
 	}
 
-	redisLink, err := s.redis.GetLink(ctx, req.UserId, l.OriginalURL)
-	if err != nil && err != redis.Nil {
-		s.logger.Error("Failed to get link from Redis",
-			zap.Int64("user", req.UserId),
-			zap.String("desc", req.Description),
-			zap.Error(err),
-		)
-		//return nil, status.Errorf(codes.Internal, "Failed to get link from Redis: %v", err)
+	var links []*gen.Link
+	var next *storage.Cursor
+	if req.CollectionId != 0 {
+		links, next, err = s.db.GetCollectionLinks(ctx, req.UserId, int(req.CollectionId), page)
+	} else {
+		links, next, err = s.db.GetUserLinks(ctx, req.UserId, page)
 	}
-
-	if redisLink != nil {
-		s.logger.Debug("Link found in Redis",
-			zap.Int64("user", req.UserId),
-			zap.String("desc", req.Description),
-		)
-
-		fullURL := getFullLink(s.cfg.BaseURL, l.UserID, redisLink.Link)
-		s.logger.Debug("Generated Full Link",
-			zap.String("full_link", fullURL),
-		)
-		return &gen.GetLinkResponse{GeneratedUrl: fullURL}, nil
+	if errors.Is(err, storage.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+	}
+	if req.CollectionId != 0 && err != nil {
+		return nil, s.collectionError("Failed to get links of collection", req.UserId, req.CollectionId, err)
 	}
-
-	generatedLink := hash(l.UserID, l.OriginalURL)
-
-	err = s.redis.SaveLink(ctx, l.UserID, generatedLink, l.OriginalURL, int32(l.ID))
 	if err != nil {
-		s.logger.Error("Failed to save link to Redis",
+		s.logger.Error("Failed to get all links from DB",
 			zap.Int64("user", req.UserId),
-			zap.String("desc", req.Description),
-			zap.Int32("url_id", req.UrlId),
 			zap.Error(err),
 		)
-		return nil, status.Errorf(codes.Internal, "Failed to save link to cache: %v", err)
+		return nil, status.Errorf(codes.Aborted, "Can't get all links from DB, err: %s", err)
 	}
 
-	s.logger.Debug("Saved to redis",
-		zap.Int64("user", req.UserId),
-		zap.String("desc", req.Description),
-		zap.String("gen_url", generatedLink),
-	)
-
-	fullURL := getFullLink(s.cfg.BaseURL, l.UserID, generatedLink)
-
-	s.logger.Debug("Generated Full Link",
-		zap.String("full_link", fullURL),
-	)
+	resp := &gen.GetAllLinksResponse{
+		Links:         links,
+		NextPageToken: next.Token(),
+	}
 
-	return &gen.GetLinkResponse{GeneratedUrl: fullURL}, nil
+	return resp, nil
 }
 
-func (s *LinkService) GetAllLinks(ctx context.Context, req *gen.GetAllLinksRequest) (*gen.GetAllLinksResponse, error) {
-	s.logger.Debug("New req GetAllLinks()",
+func (s *LinkService) SearchLinks(ctx context.Context, req *gen.SearchLinksRequest) (*gen.SearchLinksResponse, error) {
+	s.logger.Debug("New req SearchLinks()",
 		zap.Int64("user", req.UserId),
+		zap.String("query", req.Query),
 	)
 
-	links, err := s.db.GetUserLinks(ctx, req.UserId)
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, status.Error(codes.InvalidArgument, "Search query is empty")
+	}
+
+	results, err := s.db.SearchLinks(ctx, req.UserId, req.Query)
 	if err != nil {
-		s.logger.Error("Failed to get all links from DB",
+		s.logger.Error("Failed to search links",
 			zap.Int64("user", req.UserId),
+			zap.String("query", req.Query),
 			zap.Error(err),
 		)
-		return nil, status.Errorf(codes.Aborted, "Can't get all links from DB, err: %s", err)
+		return nil, status.Errorf(codes.Internal, "Failed to search links: %v", err)
 	}
+	s.logger.Debug("Found links", zap.Int64("user", req.UserId), zap.Int("count", len(results)))
 
-	resp := &gen.GetAllLinksResponse{
-		Links: links,
-	}
-
-	return resp, nil
+	return &gen.SearchLinksResponse{Results: results}, nil
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *LinkService) AddTags(ctx context.Context, req *gen.AddTagsRequest) (*gen.AddTagsResponse, error) {
+	s.logger.Debug("New req AddTags()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("link_id", req.LinkId),
+		zap.Strings("tags", req.Tags),
+	)
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	linkTags, err := s.db.AddTags(ctx, req.UserId, int(req.LinkId), tags)
+	if err != nil {
+		return nil, s.linkError("Failed to add tags", req.UserId, req.LinkId, err)
+	}
+
+	return &gen.AddTagsResponse{Tags: linkTags}, nil
+}
+
+func (s *LinkService) RemoveTags(ctx context.Context, req *gen.RemoveTagsRequest) (*gen.RemoveTagsResponse, error) {
+	s.logger.Debug("New req RemoveTags()",
+		zap.Int64("user", req.UserId),
+		zap.Int32("link_id", req.LinkId),
+		zap.Strings("tags", req.Tags),
+	)
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	linkTags, err := s.db.RemoveTags(ctx, req.UserId, int(req.LinkId), tags)
+	if err != nil {
+		return nil, s.linkError("Failed to remove tags", req.UserId, req.LinkId, err)
+	}
+
+	return &gen.RemoveTagsResponse{Tags: linkTags}, nil
+}
+
+func (s *LinkService) GetLinksByTags(ctx context.Context, req *gen.GetLinksByTagsRequest) (*gen.GetLinksByTagsResponse, error) {
+	s.logger.Debug("New req GetLinksByTags()",
+		zap.Int64("user", req.UserId),
+		zap.Strings("tags", req.Tags),
+		zap.String("match", req.Match.String()),
+	)
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.db.GetLinksByTags(ctx, req.UserId, tags, req.Match == gen.TagMatch_TAG_MATCH_ALL)
+	if err != nil {
+		s.logger.Error("Failed to get links by tags",
+			zap.Int64("user", req.UserId),
+			zap.Strings("tags", tags),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "Failed to get links by tags: %v", err)
+	}
+
+	return &gen.GetLinksByTagsResponse{Links: links}, nil
+}
+
+func (s *LinkService) GetTags(ctx context.Context, req *gen.GetTagsRequest) (*gen.GetTagsResponse, error) {
+	s.logger.Debug("New req GetTags()",
+		zap.Int64("user", req.UserId),
+	)
+
+	counts, err := s.db.GetTagCounts(ctx, req.UserId)
+	if err != nil {
+		s.logger.Error("Failed to get tag counts", zap.Int64("user", req.UserId), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "Failed to get tags: %v", err)
+	}
+
+	return &gen.GetTagsResponse{Tags: counts}, nil
+}
+
+// normalizeTags returns the tags as they are stored, or an InvalidArgument error.
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No tags given")
+	}
+
+	normalized, err := storage.NormalizeTags(tags)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Tags must be up to %d characters without spaces and commas: %v", storage.MaxTagLength, err)
+	}
+
+	return normalized, nil
+}
+
+// linkError reports a missing link of the user as NotFound.
+func (s *LinkService) linkError(msg string, userID int64, linkID int32, err error) error {
+	if errors.Is(err, storage.ErrLinksNotFound) {
+		return status.Errorf(codes.NotFound, "Link not found: %d", linkID)
+	}
+
+	s.logger.Error(msg,
+		zap.Int64("user", userID),
+		zap.Int32("link_id", linkID),
+		zap.Error(err),
+	)
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
@@ -2,28 +2,60 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"net/url"
 	"time"
 
 	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
 	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func (s *LinkService) GetContentFromDatabase(ctx context.Context, userID int64, originalURL string) ([]byte, error) {
-	return s.db.GetContentByTelegramIDOriginalURL(ctx, userID, originalURL)
+// GetContentFromDatabase and GetArticleFromDatabase read the snapshot
+// with the timestamp at, see ParseSnapshotTime.
+func (s *LinkService) GetContentFromDatabase(ctx context.Context, userID int64, originalURL string, at time.Time) ([]byte, error) {
+	return s.db.GetContentByTelegramIDOriginalURL(ctx, userID, originalURL, snapshotBefore(at))
 }
 
-func (s *LinkService) GetURLFromRedis(ctx context.Context, userID int64, generatedURL string) (string, error) {
-	return s.redis.GetOriginalURL(ctx, userID, generatedURL)
+// OpenContentFromDatabase streams the page, the caller must close it.
+func (s *LinkService) OpenContentFromDatabase(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	return s.db.OpenContentByTelegramIDOriginalURL(ctx, userID, originalURL, snapshotBefore(at))
 }
 
-func hash(userID int64, url string) string {
-	data := fmt.Sprintf("%d:%s:%d", userID, url, time.Now().UnixNano())
-	hash := sha256.Sum256([]byte(data))
-	return hex.EncodeToString(hash[:])
+// OpenWARCFromDatabase streams the WARC file of the snapshot OpenContentFromDatabase reads,
+// storage.ErrWARCNotFound means it was captured without one.
+func (s *LinkService) OpenWARCFromDatabase(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	return s.db.OpenWARCByTelegramIDOriginalURL(ctx, userID, originalURL, snapshotBefore(at))
+}
+
+func (s *LinkService) GetArticleFromDatabase(ctx context.Context, userID int64, originalURL string, at time.Time) (*models.Link, error) {
+	return s.db.GetArticleByTelegramIDOriginalURL(ctx, userID, originalURL, snapshotBefore(at))
+}
+
+// CheckLinkOwner returns a NotFound status unless the link is the user's,
+// for callers of DeleteLink that act for a user.
+func (s *LinkService) CheckLinkOwner(ctx context.Context, userID int64, linkID int32) error {
+	l, err := s.db.GetLinkByID(ctx, int(linkID))
+	if err != nil || l.UserID != userID {
+		return status.Errorf(codes.NotFound, "Link not found: %d", linkID)
+	}
+	return nil
+}
+
+// newToken returns a random token for links to upload and download pages.
+func newToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
 }
 
 func (s *LinkService) saveToDatabase(ctx context.Context, link *models.Link) error {
@@ -36,6 +68,54 @@ func (s *LinkService) saveToDatabase(ctx context.Context, link *models.Link) err
 	return nil
 }
 
-func getFullLink(baseURL string, userID int64, generatedURL string) string {
-	return fmt.Sprintf("%s/gen/%d/%s", baseURL, userID, generatedURL)
+// inlineAssets replaces the content of link with a self-contained page,
+// the raw page is kept when inlining fails, takes longer than inlineTimeout
+// or the page gets too large.
+func (s *LinkService) inlineAssets(link *models.Link, pageURL *url.URL) {
+	ctx, cancel := context.WithTimeout(context.Background(), inlineTimeout)
+	defer cancel()
+
+	content, err := s.inliner.Inline(ctx, link.Content, pageURL)
+	if err != nil {
+		s.logger.Info("Saving raw page, failed to inline assets",
+			zap.String("url", link.OriginalURL),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Debug("Inlined page assets",
+		zap.String("url", link.OriginalURL),
+		zap.Int("raw_size", len(link.Content)),
+		zap.Int("size", len(content)),
+	)
+	link.Content = content
+}
+
+// linksPage converts the paging fields of list requests,
+// a malformed token is an InvalidArgument error.
+func linksPage(sort gen.LinkSort, size int32, token string) (storage.Page, error) {
+	page := storage.Page{Limit: int(size)}
+	switch sort {
+	case gen.LinkSort_LINK_SORT_OLDEST:
+		page.Sort = storage.SortOldest
+	case gen.LinkSort_LINK_SORT_TITLE:
+		page.Sort = storage.SortTitle
+	case gen.LinkSort_LINK_SORT_DOMAIN:
+		page.Sort = storage.SortDomain
+	default:
+		page.Sort = storage.SortNewest
+	}
+
+	after, err := storage.ParsePageToken(token, page.Sort)
+	if err != nil {
+		return page, status.Error(codes.InvalidArgument, "Invalid page token, it must come from a listing with the same sort")
+	}
+	page.After = after
+
+	return page, nil
+}
+
+func getFullLink(baseURL string, token string) string {
+	return fmt.Sprintf("%s/gen/%s", baseURL, token)
 }
@@ -0,0 +1,169 @@
+// Package share signs the tokens of share links. A token names the share
+// and the link it opens with what it may be used for and until when,
+// so it is checked without a lookup and can't be altered or made up
+// without the key. Revocation is left to the caller, which keeps the
+// shares by id. Unlock tokens let a browser remember that the password
+// of a protected share was entered.
+package share
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid share token")
+	ErrExpired      = errors.New("share token expired")
+)
+
+// Scope is the set of views a share link opens.
+type Scope uint8
+
+const (
+	// ScopePage is the saved page and its reader view.
+	ScopePage Scope = 1 << iota
+	// ScopeDiff is the comparison with the live page.
+	ScopeDiff
+	// ScopeWARC is the download of the WARC file.
+	ScopeWARC
+
+	ScopeAll = ScopePage | ScopeDiff | ScopeWARC
+)
+
+// Has reports whether s includes every view of other.
+func (s Scope) Has(other Scope) bool {
+	return s&other == other
+}
+
+// version is the first byte of the payload, bumped when the layout changes.
+const version = 1
+
+// unlockKind is the first byte of unlock tokens, so they never pass for
+// share tokens and the other way round.
+const unlockKind = 0x80
+
+// tagSize is the length of the truncated HMAC-SHA256, 128 bits as with
+// most truncated MACs keep the tokens short.
+const tagSize = 16
+
+// Claims is what a token says about the share.
+type Claims struct {
+	ShareID int64
+	LinkID  int
+	// Expires is zero for links that never expire
+	Expires time.Time
+	Scope   Scope
+}
+
+// Signer makes and checks tokens with a secret key.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign returns the url-safe token of c, c.Expires is kept in seconds.
+func (s *Signer) Sign(c *Claims) string {
+	var expires uint64
+	if !c.Expires.IsZero() {
+		expires = uint64(c.Expires.Unix())
+	}
+
+	payload := []byte{version}
+	payload = binary.AppendUvarint(payload, uint64(c.ShareID))
+	payload = binary.AppendUvarint(payload, uint64(c.LinkID))
+	payload = binary.AppendUvarint(payload, expires)
+	payload = append(payload, byte(c.Scope))
+
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.tag(payload)...))
+}
+
+// Verify returns the claims of a token signed with the key. An expired
+// token returns its claims with ErrExpired, anything else that is not
+// a valid token ErrInvalidToken.
+func (s *Signer) Verify(token string, now time.Time) (*Claims, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) <= tagSize {
+		return nil, ErrInvalidToken
+	}
+	payload, tag := data[:len(data)-tagSize], data[len(data)-tagSize:]
+	if !hmac.Equal(tag, s.tag(payload)) {
+		return nil, ErrInvalidToken
+	}
+
+	if payload[0] != version {
+		return nil, ErrInvalidToken
+	}
+	r := payload[1:]
+	var fields [3]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(r)
+		if n <= 0 {
+			return nil, ErrInvalidToken
+		}
+		fields[i], r = v, r[n:]
+	}
+	if len(r) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	c := &Claims{
+		ShareID: int64(fields[0]),
+		LinkID:  int(fields[1]),
+		Scope:   Scope(r[0]),
+	}
+	if fields[2] != 0 {
+		c.Expires = time.Unix(int64(fields[2]), 0).UTC()
+		if !now.Before(c.Expires) {
+			return c, ErrExpired
+		}
+	}
+
+	return c, nil
+}
+
+// SignUnlock returns a token saying the password of the share was
+// entered, it is kept by the browser until expires.
+func (s *Signer) SignUnlock(shareID int64, expires time.Time) string {
+	payload := []byte{unlockKind}
+	payload = binary.AppendUvarint(payload, uint64(shareID))
+	payload = binary.AppendUvarint(payload, uint64(expires.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.tag(payload)...))
+}
+
+// VerifyUnlock reports whether token is an unlock token of the share
+// that did not expire before now.
+func (s *Signer) VerifyUnlock(token string, shareID int64, now time.Time) bool {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) <= tagSize {
+		return false
+	}
+	payload, tag := data[:len(data)-tagSize], data[len(data)-tagSize:]
+	if !hmac.Equal(tag, s.tag(payload)) || payload[0] != unlockKind {
+		return false
+	}
+
+	id, n := binary.Uvarint(payload[1:])
+	if n <= 0 || int64(id) != shareID {
+		return false
+	}
+	expires, m := binary.Uvarint(payload[1+n:])
+	if m <= 0 || 1+n+m != len(payload) {
+		return false
+	}
+
+	return now.Before(time.Unix(int64(expires), 0))
+}
+
+func (s *Signer) tag(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:tagSize]
+}
@@ -0,0 +1,109 @@
+package share
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []*Claims{
+		{ShareID: 1, LinkID: 1, Expires: now.Add(time.Hour), Scope: ScopeAll},
+		{ShareID: 1 << 40, LinkID: 1 << 30, Expires: now.Add(time.Second), Scope: ScopePage},
+		{ShareID: 7, LinkID: 3, Scope: ScopeDiff | ScopeWARC},
+	}
+	for _, want := range tests {
+		token := s.Sign(want)
+		got, err := s.Verify(token, now)
+		if err != nil {
+			t.Fatalf("Verify(Sign(%+v)): %v", want, err)
+		}
+		if got.ShareID != want.ShareID || got.LinkID != want.LinkID ||
+			!got.Expires.Equal(want.Expires) || got.Scope != want.Scope {
+			t.Errorf("Verify(Sign(%+v)) = %+v", want, got)
+		}
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	token := s.Sign(&Claims{ShareID: 1, LinkID: 2, Expires: now, Scope: ScopeAll})
+	c, err := s.Verify(token, now)
+	if !errors.Is(err, ErrExpired) {
+		t.Fatalf("Verify() at expiry = %v, want ErrExpired", err)
+	}
+	if c == nil || c.ShareID != 1 {
+		t.Errorf("Verify() of an expired token = %+v, want its claims", c)
+	}
+	if _, err := s.Verify(token, now.Add(-time.Second)); err != nil {
+		t.Errorf("Verify() before expiry = %v", err)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	token := s.Sign(&Claims{ShareID: 1, LinkID: 2, Expires: now.Add(time.Hour), Scope: ScopePage})
+
+	data, _ := base64.RawURLEncoding.DecodeString(token)
+	// the scope byte right before the tag
+	data[len(data)-tagSize-1] = byte(ScopeAll)
+	widened := base64.RawURLEncoding.EncodeToString(data)
+
+	tests := map[string]string{
+		"empty":      "",
+		"not base64": "!!!",
+		"too short":  token[:10],
+		"truncated":  token[:len(token)-1],
+		"altered":    widened,
+		"other key":  NewSigner([]byte("other")).Sign(&Claims{ShareID: 1, LinkID: 2, Scope: ScopeAll}),
+	}
+	for name, token := range tests {
+		if _, err := s.Verify(token, now); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("Verify(%s) = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	s := NewSigner([]byte("key"))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	token := s.SignUnlock(3, now.Add(time.Hour))
+	if !s.VerifyUnlock(token, 3, now) {
+		t.Error("VerifyUnlock() of its own token = false")
+	}
+	if s.VerifyUnlock(token, 4, now) {
+		t.Error("VerifyUnlock() of another share = true")
+	}
+	if s.VerifyUnlock(token, 3, now.Add(time.Hour)) {
+		t.Error("VerifyUnlock() after expiry = true")
+	}
+	if NewSigner([]byte("other")).VerifyUnlock(token, 3, now) {
+		t.Error("VerifyUnlock() with another key = true")
+	}
+
+	// the two kinds of tokens are not interchangeable
+	if _, err := s.Verify(token, now); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() of an unlock token = %v, want ErrInvalidToken", err)
+	}
+	shareToken := s.Sign(&Claims{ShareID: 3, LinkID: 1, Scope: ScopeAll})
+	if s.VerifyUnlock(shareToken, 3, now) {
+		t.Error("VerifyUnlock() of a share token = true")
+	}
+}
+
+func TestScopeHas(t *testing.T) {
+	if !ScopeAll.Has(ScopeWARC) || !ScopePage.Has(ScopePage) {
+		t.Error("scope does not have its own views")
+	}
+	if ScopePage.Has(ScopeDiff) || (ScopePage | ScopeDiff).Has(ScopeAll) {
+		t.Error("scope has views it was not given")
+	}
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Page content is kept once per distinct body in a blob table keyed by
+// the SHA-256 of the uncompressed content, snapshots refer to it by hash.
+// New blobs are compressed with zstd, gzip blobs of older versions are
+// still read.
+const (
+	EncodingIdentity = "identity"
+	EncodingGzip     = "gzip"
+	EncodingZstd     = "zstd"
+)
+
+var (
+	ErrUnknownEncoding = errors.New("unknown blob encoding")
+	// ErrNoBlobStore means content is in a blob store, but none is configured.
+	ErrNoBlobStore = errors.New("content is in a blob store, but none is configured")
+)
+
+// BlobHash is the key of content in the blob table.
+func BlobHash(content []byte) []byte {
+	sum := sha256.Sum256(content)
+	return sum[:]
+}
+
+// zstdEncoder is shared by all captures, EncodeAll is safe for concurrent use.
+var zstdEncoder, _ = zstd.NewWriter(nil,
+	zstd.WithEncoderLevel(zstd.SpeedBetterCompression),
+	zstd.WithEncoderConcurrency(1),
+)
+
+// EncodeBlob compresses content for storage. Content that does not get
+// smaller is kept as it is.
+func EncodeBlob(content []byte) (string, []byte, error) {
+	data := zstdEncoder.EncodeAll(content, nil)
+	if len(data) >= len(content) {
+		return EncodingIdentity, content, nil
+	}
+	return EncodingZstd, data, nil
+}
+
+// NewBlobKey returns a key for content with hash in a BlobStore. Keys
+// are never reused, so deleting an old blob never hits a new one with the
+// same content.
+func NewBlobKey(hash []byte) string {
+	suffix := make([]byte, 8)
+	rand.Read(suffix)
+	return hex.EncodeToString(hash) + "-" + hex.EncodeToString(suffix)
+}
+
+// OpenBlob streams the content of a blob stored with encoding, from
+// store when key is set and from data otherwise.
+func OpenBlob(ctx context.Context, store BlobStore, encoding, key string, data []byte) (io.ReadCloser, error) {
+	var r io.ReadCloser = io.NopCloser(bytes.NewReader(data))
+	if key != "" {
+		if store == nil {
+			return nil, ErrNoBlobStore
+		}
+		var err error
+		if r, err = store.Open(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	switch encoding {
+	case EncodingIdentity:
+		return r, nil
+	case EncodingGzip:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		return &gzipReadCloser{Reader: zr, body: r}, nil
+	case EncodingZstd:
+		// a blob is read by a single request, it needs no decoding goroutines
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		return &zstdReadCloser{Decoder: zr, body: r}, nil
+	default:
+		r.Close()
+		return nil, ErrUnknownEncoding
+	}
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (r *gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
+}
+
+type zstdReadCloser struct {
+	*zstd.Decoder
+	body io.Closer
+}
+
+func (r *zstdReadCloser) Close() error {
+	r.Decoder.Close()
+	return r.body.Close()
+}
@@ -0,0 +1,47 @@
+// Package blobstore keeps page content outside of the database,
+// in a directory or an S3-compatible bucket.
+package blobstore
+
+import (
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+)
+
+var pkg = "storage/blobstore"
+
+var (
+	ErrUnknownStore = errors.New("unknown blob store")
+	ErrInvalidKey   = errors.New("invalid blob key")
+	ErrNoBucket     = errors.New("no S3 bucket")
+)
+
+// New returns the store selected by cfg.Store, nil when content is kept
+// in the database.
+func New(cfg config.BlobConfig) (storage.BlobStore, error) {
+	switch cfg.Store {
+	case "":
+		return nil, nil
+	case "fs":
+		return NewFS(cfg.Path)
+	case "s3":
+		return NewS3(cfg)
+	default:
+		return nil, ErrUnknownStore
+	}
+}
+
+// validKey keeps keys to what storage.NewBlobKey makes, so they are safe
+// as file names and in urls.
+func validKey(key string) bool {
+	if len(key) < 3 {
+		return false
+	}
+	for _, r := range key {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+// FS keeps blobs as files in a directory, spread over subdirectories by
+// the first two characters of the key.
+type FS struct {
+	root string
+}
+
+func NewFS(root string) (*FS, error) {
+	if root == "" {
+		root = "blobs"
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, wrap.E(pkg, "failed to create "+root, err)
+	}
+
+	return &FS{root: root}, nil
+}
+
+func (s *FS) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, key[:2], key), nil
+}
+
+func (s *FS) Put(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return wrap.E(pkg, "failed to create directory of "+key, err)
+	}
+
+	// readers never see a partly written file
+	f, err := os.CreateTemp(filepath.Dir(path), key+".tmp*")
+	if err != nil {
+		return wrap.E(pkg, "failed to create "+key, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return wrap.E(pkg, "failed to write "+key, err)
+	}
+	if err := f.Close(); err != nil {
+		return wrap.E(pkg, "failed to write "+key, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return wrap.E(pkg, "failed to rename "+key, err)
+	}
+
+	return nil
+}
+
+func (s *FS) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, wrap.E(pkg, "failed to open "+key, storage.ErrBlobNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to open "+key, err)
+	}
+
+	return f, nil
+}
+
+func (s *FS) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return wrap.E(pkg, "failed to delete "+key, err)
+	}
+
+	return nil
+}
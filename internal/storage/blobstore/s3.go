@@ -0,0 +1,245 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+const (
+	// s3Timeout bounds the wait for an answer and whole Put and Delete
+	// calls, blobs read from Open may stream for longer.
+	s3Timeout    = time.Minute
+	amzDateTime  = "20060102T150405Z"
+	amzDate      = "20060102"
+	s3HashHeader = "X-Amz-Content-Sha256"
+)
+
+// S3 keeps blobs in a bucket of an S3-compatible service. It speaks the
+// plain REST api signed with Signature Version 4, addressing the bucket
+// by path so that MinIO and other stand-ins work without dns setup.
+type S3 struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	// now is replaced in tests
+	now func() time.Time
+}
+
+func NewS3(cfg config.BlobConfig) (*S3, error) {
+	u, err := url.Parse(cfg.S3Endpoint)
+	if err == nil && ((u.Scheme != "http" && u.Scheme != "https") || u.Host == "") {
+		err = errors.New("expected an http or https url")
+	}
+	if err != nil {
+		return nil, wrap.E(pkg, "invalid S3 endpoint "+cfg.S3Endpoint, err)
+	}
+	if cfg.S3Bucket == "" {
+		return nil, wrap.E(pkg, "invalid S3 config", ErrNoBucket)
+	}
+
+	region := cfg.S3Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	// the client has no Timeout, it would cut off bodies returned by Open
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = s3Timeout
+
+	return &S3{
+		endpoint:  u,
+		bucket:    cfg.S3Bucket,
+		region:    region,
+		accessKey: cfg.S3AccessKey,
+		secretKey: cfg.S3SecretKey,
+		client:    &http.Client{Transport: t},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return wrap.E(pkg, "failed to put "+key, s3Error(resp))
+	}
+
+	return nil
+}
+
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, wrap.E(pkg, "failed to open "+key, storage.ErrBlobNotFound)
+	default:
+		defer resp.Body.Close()
+		return nil, wrap.E(pkg, "failed to open "+key, s3Error(resp))
+	}
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return wrap.E(pkg, "failed to delete "+key, s3Error(resp))
+	}
+
+	return nil
+}
+
+func (s *S3) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to create request", err)
+	}
+	if body == nil {
+		req.Body = nil
+		req.ContentLength = 0
+	}
+	s.sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to "+method+" "+key, err)
+	}
+
+	return resp, nil
+}
+
+// sign adds the Signature Version 4 authorization to req. The host,
+// range and x-amz-* headers are signed.
+func (s *S3) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	payload := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateTime))
+	req.Header.Set(s3HashHeader, hex.EncodeToString(payload[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payload[:]),
+	}, "\n")
+
+	scope := now.Format(amzDate) + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + now.Format(amzDateTime) + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format(amzDate))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), q[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape encodes everything but the unreserved characters of RFC 3986.
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Error reads the error code out of an S3 error response.
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	code := http.StatusText(resp.StatusCode)
+	if i := bytes.Index(body, []byte("<Code>")); i >= 0 {
+		if j := bytes.Index(body[i:], []byte("</Code>")); j > 0 {
+			code = string(body[i+len("<Code>") : i+j])
+		}
+	}
+	return fmt.Errorf("S3 responded %d %s", resp.StatusCode, code)
+}
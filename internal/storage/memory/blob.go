@@ -0,0 +1,37 @@
+package memory
+
+import "github.com/0x0FACED/link-saver-api/internal/storage"
+
+// blob is page content shared by the snapshots with the same body,
+// as in the blobs table of the sql drivers. Nothing is compressed here.
+type blob struct {
+	content []byte
+	refs    int
+}
+
+// putBlob returns the stored copy of content, the caller must hold the lock.
+func (m *Memory) putBlob(content []byte) []byte {
+	hash := string(storage.BlobHash(content))
+	b, ok := m.blobs[hash]
+	if !ok {
+		b = &blob{content: append([]byte(nil), content...)}
+		m.blobs[hash] = b
+	}
+	b.refs++
+
+	return b.content
+}
+
+// releaseBlob drops a reference to content, the caller must hold the lock.
+func (m *Memory) releaseBlob(content []byte) {
+	hash := string(storage.BlobHash(content))
+	b, ok := m.blobs[hash]
+	if !ok {
+		return
+	}
+
+	b.refs--
+	if b.refs <= 0 {
+		delete(m.blobs, hash)
+	}
+}
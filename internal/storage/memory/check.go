@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+// linkHealth is the link-rot state of a link as in the check_* columns
+// of the sql drivers, checks are the recent ones, oldest first.
+type linkHealth struct {
+	status    string
+	failures  int
+	checkedAt time.Time
+	lastOKAt  time.Time
+	checks    []models.LinkCheck
+}
+
+func (m *Memory) ClaimLinkChecks(ctx context.Context, interval time.Duration, limit int) ([]*models.LinkCheck, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*link
+	for _, l := range m.links {
+		if l.health.checkedAt.IsZero() || !l.health.checkedAt.After(now.Add(-interval)) {
+			due = append(due, l)
+		}
+	}
+
+	// never checked first, the zero time sorts before any other
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].health.checkedAt.Equal(due[j].health.checkedAt) {
+			return due[i].health.checkedAt.Before(due[j].health.checkedAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	checks := make([]*models.LinkCheck, 0, len(due))
+	for _, l := range due {
+		l.health.checkedAt = now
+		checks = append(checks, &models.LinkCheck{
+			LinkID:      l.ID,
+			OriginalURL: l.OriginalURL,
+			Description: l.Description,
+			Status:      l.healthStatus(),
+			Failures:    l.health.failures,
+		})
+	}
+
+	return checks, nil
+}
+
+func (l *link) healthStatus() string {
+	if l.health.status == "" {
+		return models.LinkStatusUnknown
+	}
+	return l.health.status
+}
+
+func (m *Memory) RecordLinkCheck(ctx context.Context, c *models.LinkCheck) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.links[c.LinkID]
+	if !ok {
+		return wrap.E(pkg, "failed to RecordLinkCheck()", storage.ErrLinksNotFound)
+	}
+
+	now := time.Now()
+	c.CheckedAt = now
+
+	l.health.status = c.Status
+	l.health.failures = c.Failures
+	l.health.checkedAt = now
+	if c.StatusCode >= 200 && c.StatusCode <= 299 {
+		l.health.lastOKAt = now
+	}
+
+	l.health.checks = append(l.health.checks, *c)
+	if extra := len(l.health.checks) - storage.LinkCheckHistory; extra > 0 {
+		l.health.checks = l.health.checks[extra:]
+	}
+
+	return nil
+}
+
+func (m *Memory) GetBrokenLinks(ctx context.Context, userID int64) ([]*models.LinkCheck, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	var checks []*models.LinkCheck
+	for _, l := range m.links {
+		if l.userID != id || len(l.health.checks) == 0 {
+			continue
+		}
+		if l.health.status != models.LinkStatusDead && l.health.status != models.LinkStatusMoved {
+			continue
+		}
+
+		last := l.health.checks[len(l.health.checks)-1]
+		last.OriginalURL = l.OriginalURL
+		last.Description = l.Description
+		last.LastOKAt = l.health.lastOKAt
+		checks = append(checks, &last)
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].LinkID < checks[j].LinkID
+	})
+
+	return checks, nil
+}
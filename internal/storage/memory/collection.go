@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+func (m *Memory) CreateCollection(ctx context.Context, c *models.Collection) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.getOrCreateUserID(c.UserID)
+
+	if c.ParentID != 0 && m.userCollection(c.UserID, c.ParentID) == nil {
+		return storage.ErrCollectionNotFound
+	}
+	if m.nameTaken(c.UserID, c.ParentID, c.Name) {
+		return wrap.E(pkg, "failed to CreateCollection()", storage.ErrCollectionExists)
+	}
+
+	m.lastCollectionID++
+	stored := *c
+	stored.ID = m.lastCollectionID
+	stored.CreatedAt = time.Now()
+	m.collections[stored.ID] = &stored
+
+	c.ID = stored.ID
+	c.CreatedAt = stored.CreatedAt
+
+	return nil
+}
+
+func (m *Memory) RenameCollection(ctx context.Context, userID int64, id int, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := m.userCollection(userID, id)
+	if c == nil {
+		return storage.ErrCollectionNotFound
+	}
+	if c.Name != name && m.nameTaken(userID, c.ParentID, name) {
+		return wrap.E(pkg, "failed to RenameCollection()", storage.ErrCollectionExists)
+	}
+	c.Name = name
+
+	return nil
+}
+
+func (m *Memory) DeleteCollection(ctx context.Context, userID int64, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.userCollection(userID, id) == nil {
+		return storage.ErrCollectionNotFound
+	}
+
+	deleted := map[int]bool{id: true}
+	// nested collections may be found after their parent, so repeat until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, c := range m.collections {
+			if !deleted[c.ID] && deleted[c.ParentID] {
+				deleted[c.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	for cid := range deleted {
+		delete(m.collections, cid)
+	}
+	for _, l := range m.links {
+		if deleted[l.collectionID] {
+			l.collectionID = 0
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) GetCollections(ctx context.Context, userID int64) ([]*gen.Collection, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := make(map[int]int32)
+	for _, l := range m.links {
+		counts[l.collectionID]++
+	}
+
+	var collections []*gen.Collection
+	for _, c := range m.collections {
+		if c.UserID != userID {
+			continue
+		}
+		collections = append(collections, &gen.Collection{
+			CollectionId: int32(c.ID),
+			ParentId:     int32(c.ParentID),
+			Name:         c.Name,
+			LinkCount:    counts[c.ID],
+		})
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		if collections[i].Name != collections[j].Name {
+			return collections[i].Name < collections[j].Name
+		}
+		return collections[i].CollectionId < collections[j].CollectionId
+	})
+
+	return collections, nil
+}
+
+func (m *Memory) MoveLinks(ctx context.Context, userID int64, linkIDs []int, collectionID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if collectionID != 0 && m.userCollection(userID, collectionID) == nil {
+		return storage.ErrCollectionNotFound
+	}
+
+	links := make([]*link, 0, len(linkIDs))
+	for _, id := range linkIDs {
+		l, err := m.userLink(userID, id)
+		if err != nil {
+			return err
+		}
+		links = append(links, l)
+	}
+
+	for _, l := range links {
+		l.collectionID = collectionID
+	}
+
+	return nil
+}
+
+func (m *Memory) GetCollectionLinks(ctx context.Context, userID int64, collectionID int, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	c := m.userCollection(userID, collectionID)
+	if c == nil {
+		return nil, nil, storage.ErrCollectionNotFound
+	}
+
+	links, next := m.pageLinks(m.userByTgID[userID], func(l *link) bool {
+		return l.collectionID == collectionID
+	}, page)
+	return links, next, nil
+}
+
+// userCollection returns the collection if it belongs to the user,
+// the caller must hold the lock.
+func (m *Memory) userCollection(userID int64, id int) *models.Collection {
+	c, ok := m.collections[id]
+	if !ok || c.UserID != userID {
+		return nil
+	}
+	return c
+}
+
+func (m *Memory) nameTaken(userID int64, parentID int, name string) bool {
+	for _, c := range m.collections {
+		if c.UserID == userID && c.ParentID == parentID && c.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+)
+
+func (m *Memory) GetLinksForExport(ctx context.Context, userID int64) ([]*models.Link, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	var links []*models.Link
+	for _, l := range m.links {
+		if l.userID != id {
+			continue
+		}
+		links = append(links, &models.Link{
+			ID:           l.ID,
+			OriginalURL:  l.OriginalURL,
+			UserID:       userID,
+			Description:  l.Description,
+			Title:        l.Title,
+			DateAdded:    l.DateAdded,
+			Tags:         l.tagNames(),
+			CollectionID: l.collectionID,
+		})
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].ID < links[j].ID
+	})
+
+	return links, nil
+}
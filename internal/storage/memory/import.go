@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+)
+
+func (m *Memory) GetTakenLinks(ctx context.Context, userID int64) (map[string]bool, map[string]bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	urls := make(map[string]bool)
+	descriptions := make(map[string]bool)
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return urls, descriptions, nil
+	}
+
+	for _, l := range m.links {
+		if l.userID == id {
+			urls[l.OriginalURL] = true
+			descriptions[l.Description] = true
+		}
+	}
+	for _, j := range m.jobs {
+		if j.UserID == userID && !j.Recapture &&
+			(j.Status == models.CaptureStatusPending || j.Status == models.CaptureStatusRunning) {
+			urls[j.OriginalURL] = true
+			descriptions[j.Description] = true
+		}
+	}
+
+	return urls, descriptions, nil
+}
+
+func (m *Memory) CreateImport(ctx context.Context, imp *models.Import, jobs []*models.CaptureJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.getOrCreateUserID(imp.UserID)
+
+	m.lastImportID++
+	imp.ID = m.lastImportID
+	imp.CreatedAt = time.Now()
+
+	stored := *imp
+	stored.Pending, stored.Running, stored.Done, stored.Failed = 0, 0, 0, 0
+	m.imports[stored.ID] = &stored
+
+	for _, j := range jobs {
+		j.ImportID = imp.ID
+		j.ID = m.insertCaptureJob(j)
+	}
+
+	return nil
+}
+
+func (m *Memory) GetImport(ctx context.Context, userID int64, id int64) (*models.Import, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, ok := m.imports[id]
+	if !ok || stored.UserID != userID {
+		return nil, storage.ErrImportNotFound
+	}
+
+	imp := *stored
+	for _, j := range m.jobs {
+		if j.ImportID != id {
+			continue
+		}
+		switch j.Status {
+		case models.CaptureStatusPending:
+			imp.Pending++
+		case models.CaptureStatusRunning:
+			imp.Running++
+		case models.CaptureStatusDone:
+			imp.Done++
+		case models.CaptureStatusFailed:
+			imp.Failed++
+		}
+	}
+
+	return &imp, nil
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+)
+
+// job is a stored capture job, runAt has the same meaning
+// as the run_at column of the sql drivers.
+type job struct {
+	models.CaptureJob
+	runAt time.Time
+}
+
+func (m *Memory) EnqueueCapture(ctx context.Context, j *models.CaptureJob) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.getOrCreateUserID(j.UserID)
+
+	return m.insertCaptureJob(j), nil
+}
+
+// insertCaptureJob stores a copy of j, the caller must hold the lock.
+func (m *Memory) insertCaptureJob(j *models.CaptureJob) int64 {
+	m.lastJobID++
+	stored := &job{
+		CaptureJob: models.CaptureJob{
+			ID:           m.lastJobID,
+			UserID:       j.UserID,
+			OriginalURL:  j.OriginalURL,
+			Description:  j.Description,
+			LinkID:       j.LinkID,
+			Recapture:    j.Recapture,
+			ImportID:     j.ImportID,
+			DateAdded:    j.DateAdded,
+			Tags:         slices.Clone(j.Tags),
+			CollectionID: j.CollectionID,
+			Status:       models.CaptureStatusPending,
+			CreatedAt:    time.Now(),
+		},
+		runAt: time.Now(),
+	}
+	m.jobs[stored.ID] = stored
+
+	return stored.ID
+}
+
+// storedJob returns a copy of j with the references the sql drivers
+// would clear, the caller must hold the lock.
+func (m *Memory) storedJob(j *job) *models.CaptureJob {
+	stored := j.CaptureJob
+	stored.Tags = slices.Clone(j.Tags)
+	if _, ok := m.links[stored.LinkID]; !ok {
+		// link_id is set to NULL when the link is deleted
+		stored.LinkID = 0
+	}
+	if _, ok := m.collections[stored.CollectionID]; !ok {
+		stored.CollectionID = 0
+	}
+	return &stored
+}
+
+func (m *Memory) ClaimCaptureJob(ctx context.Context, lease time.Duration, maxAttempts int) (*models.CaptureJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	var due []*job
+	for _, j := range m.jobs {
+		if j.runAt.After(now) {
+			continue
+		}
+		switch {
+		case j.Status == models.CaptureStatusRunning && j.Attempts >= maxAttempts:
+			// the job lost its worker on the last attempt
+			j.Status = models.CaptureStatusFailed
+			j.Error = storage.JobLeaseExpired
+		case j.Status == models.CaptureStatusPending || j.Status == models.CaptureStatusRunning:
+			due = append(due, j)
+		}
+	}
+	if len(due) == 0 {
+		return nil, storage.ErrNoJobs
+	}
+
+	sort.Slice(due, func(i, k int) bool {
+		if !due[i].runAt.Equal(due[k].runAt) {
+			return due[i].runAt.Before(due[k].runAt)
+		}
+		return due[i].ID < due[k].ID
+	})
+
+	j := due[0]
+	j.Status = models.CaptureStatusRunning
+	j.Attempts++
+	j.runAt = now.Add(lease)
+
+	return m.storedJob(j), nil
+}
+
+func (m *Memory) CompleteCaptureJob(ctx context.Context, id int64, attempt int, linkID int) error {
+	return m.updateCaptureJob(id, attempt, func(j *job) {
+		j.Status = models.CaptureStatusDone
+		j.Error = ""
+		j.LinkID = linkID
+	})
+}
+
+func (m *Memory) RetryCaptureJob(ctx context.Context, id int64, attempt int, after time.Duration, reason string) error {
+	return m.updateCaptureJob(id, attempt, func(j *job) {
+		j.Status = models.CaptureStatusPending
+		j.Error = reason
+		j.runAt = time.Now().Add(after)
+	})
+}
+
+func (m *Memory) FailCaptureJob(ctx context.Context, id int64, attempt int, reason string) error {
+	return m.updateCaptureJob(id, attempt, func(j *job) {
+		j.Status = models.CaptureStatusFailed
+		j.Error = reason
+	})
+}
+
+// updateCaptureJob updates the job while the claim with attempt holds it.
+func (m *Memory) updateCaptureJob(id int64, attempt int, update func(j *job)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return storage.ErrJobNotFound
+	}
+	if j.Attempts != attempt || j.Status != models.CaptureStatusRunning {
+		return storage.ErrJobLeaseLost
+	}
+	update(j)
+
+	return nil
+}
+
+func (m *Memory) GetCaptureJob(ctx context.Context, id int64) (*models.CaptureJob, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, storage.ErrJobNotFound
+	}
+
+	return m.storedJob(j), nil
+}
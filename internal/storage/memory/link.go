@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+func (m *Memory) SaveLink(ctx context.Context, l *models.Link) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	userID := m.getOrCreateUserID(l.UserID)
+
+	for _, stored := range m.links {
+		if stored.userID != userID {
+			continue
+		}
+		if stored.OriginalURL == l.OriginalURL {
+			return wrap.E(pkg, "failed to SaveLink(), url is taken", storage.ErrLinkExists)
+		}
+		if stored.Description == l.Description {
+			return wrap.E(pkg, "failed to SaveLink(), description is taken", storage.ErrLinkExists)
+		}
+	}
+
+	m.lastLinkID++
+	stored := &link{
+		Link:   *l,
+		userID: userID,
+		domain: storage.Domain(l.OriginalURL),
+	}
+	stored.ID = m.lastLinkID
+	stored.Content = nil
+	stored.WARC = nil
+	now := time.Now()
+	// imported links keep the date they were bookmarked
+	if stored.DateAdded.IsZero() {
+		stored.DateAdded = now
+	}
+	m.addSnapshot(stored, &models.Snapshot{
+		Content:     l.Content,
+		ContentText: l.ContentText,
+		Title:       l.Title,
+		Byline:      l.Byline,
+		LeadImage:   l.LeadImage,
+		WordCount:   l.WordCount,
+		Article:     l.Article,
+		WARC:        l.WARC,
+		CapturedAt:  now,
+	})
+	m.links[stored.ID] = stored
+	l.ID = stored.ID
+
+	return nil
+}
+
+// userLinks returns links of the user ordered by id,
+// the caller must hold the lock.
+func (m *Memory) userLinks(userID int, match func(l *link) bool) []*gen.Link {
+	var links []*gen.Link
+	for _, l := range m.links {
+		if l.userID != userID || !match(l) {
+			continue
+		}
+		links = append(links, &gen.Link{
+			LinkId:      int32(l.ID),
+			OriginalUrl: l.OriginalURL,
+			Description: l.Description,
+		})
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].LinkId < links[j].LinkId
+	})
+
+	return links
+}
+
+func (m *Memory) GetUserLinks(ctx context.Context, userID int64, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.getOrCreateUserID(userID)
+
+	links, next := m.pageLinks(id, func(*link) bool { return true }, page)
+	return links, next, nil
+}
+
+func (m *Memory) GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.getOrCreateUserID(userID)
+
+	_, s, err := m.userSnapshot(id, originalURL, at)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetContent()", err)
+	}
+
+	return append([]byte(nil), s.Content...), nil
+}
+
+func (m *Memory) OpenContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	content, err := m.GetContentByTelegramIDOriginalURL(ctx, userID, originalURL, at)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (m *Memory) OpenWARCByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", storage.ErrUserNotFound)
+	}
+
+	_, s, err := m.userSnapshot(id, originalURL, at)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetWARC()", err)
+	}
+	if len(s.WARC) == 0 {
+		return nil, wrap.E(pkg, "failed to GetWARC()", storage.ErrWARCNotFound)
+	}
+
+	// blobs are never changed, only dropped
+	return io.NopCloser(bytes.NewReader(s.WARC)), nil
+}
+
+func (m *Memory) GetArticleByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (*models.Link, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", storage.ErrUserNotFound)
+	}
+
+	l, s, err := m.userSnapshot(id, originalURL, at)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetArticle()", err)
+	}
+
+	article := l.Link
+	article.ContentText = ""
+	article.Title = s.Title
+	article.Byline = s.Byline
+	article.LeadImage = s.LeadImage
+	article.WordCount = s.WordCount
+	article.Article = s.Article
+	article.CapturedAt = s.CapturedAt
+	return &article, nil
+}
+
+func (m *Memory) GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.getOrCreateUserID(userID)
+
+	links, next := m.pageLinks(id, func(l *link) bool {
+		return strings.Contains(l.Description, desc)
+	}, page)
+	return links, next, nil
+}
+
+func (m *Memory) GetLinkByID(ctx context.Context, id int) (*models.Link, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, ok := m.links[id]
+	if !ok {
+		return nil, wrap.E(pkg, "failed to GetLinkByID()", errLinkNotFound)
+	}
+
+	u, ok := m.users[stored.userID]
+	if !ok {
+		return nil, wrap.E(pkg, "unusual behavior, link exists, user not exists", errUserNotExists)
+	}
+
+	return &models.Link{
+		ID:          stored.ID,
+		OriginalURL: stored.OriginalURL,
+		UserID:      u.UserID,
+		Description: stored.Description,
+	}, nil
+}
+
+func (m *Memory) DeleteLink(ctx context.Context, id int) (string, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.links[id]
+	if !ok {
+		return "", -1, wrap.E(pkg, "failed to DeleteLink()", errLinkNotFound)
+	}
+	delete(m.links, id)
+	for _, s := range stored.snapshots {
+		m.releaseBlob(s.Content)
+		if len(s.WARC) > 0 {
+			m.releaseBlob(s.WARC)
+		}
+	}
+	for shareID, s := range m.shares {
+		if s.LinkID == id {
+			delete(m.shares, shareID)
+		}
+	}
+
+	u, ok := m.users[stored.userID]
+	if !ok {
+		return "", -1, wrap.E(pkg, "unusual behavior, link deleted, user not exists", errUserNotExists)
+	}
+
+	return stored.OriginalURL, u.UserID, nil
+}
+
+func (m *Memory) SearchLinks(ctx context.Context, userID int64, query string) ([]*gen.SearchResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.getOrCreateUserID(userID)
+
+	terms := storage.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var results []*gen.SearchResult
+	for _, l := range m.links {
+		if l.userID != id {
+			continue
+		}
+		rank := storage.Rank(terms, l.Description+" "+l.Title, l.ContentText)
+		if rank == 0 {
+			continue
+		}
+		results = append(results, &gen.SearchResult{
+			Link: &gen.Link{
+				LinkId:      int32(l.ID),
+				OriginalUrl: l.OriginalURL,
+				Description: l.Description,
+			},
+			Rank:    rank,
+			Snippet: storage.Snippet(l.ContentText, terms),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Link.LinkId < results[j].Link.LinkId
+	})
+	if len(results) > storage.SearchLimit {
+		results = results[:storage.SearchLimit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,75 @@
+// Package memory is a storage.Database kept in process memory.
+// It is meant for tests and demos, all data is lost on exit.
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+)
+
+var pkg = "storage/memory"
+
+var (
+	errLinkNotFound  = errors.New("link not found")
+	errUserExists    = errors.New("user already exists")
+	errUserNotExists = errors.New("user not exists")
+)
+
+type Memory struct {
+	mu sync.RWMutex
+
+	users       map[int]*models.User
+	userByTgID  map[int64]int
+	links       map[int]*link
+	jobs        map[int64]*job
+	collections map[int]*models.Collection
+	blobs       map[string]*blob
+	imports     map[int64]*models.Import
+	shares      map[int64]*models.Share
+
+	lastUserID       int
+	lastLinkID       int
+	lastJobID        int64
+	lastCollectionID int
+	lastSnapshotID   int
+	lastImportID     int64
+	lastShareID      int64
+}
+
+// link is a stored link, userID is the internal user id
+// as in the user_id column of the sql drivers.
+type link struct {
+	models.Link
+	userID int
+	domain string
+	tags   map[string]bool
+	// collectionID is 0 for links out of any collection
+	collectionID int
+	// snapshots are ordered oldest first, the page content is kept
+	// only there
+	snapshots []*models.Snapshot
+	health    linkHealth
+}
+
+func New() *Memory {
+	return &Memory{
+		users:       make(map[int]*models.User),
+		userByTgID:  make(map[int64]int),
+		links:       make(map[int]*link),
+		jobs:        make(map[int64]*job),
+		collections: make(map[int]*models.Collection),
+		blobs:       make(map[string]*blob),
+		imports:     make(map[int64]*models.Import),
+		shares:      make(map[int64]*models.Share),
+	}
+}
+
+func (m *Memory) Connect() error {
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/storage/storagetest"
+)
+
+func TestMemory(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Database {
+		return New()
+	})
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+// keyTime formats dates of fixed width, so they sort as strings.
+const keyTime = "2006-01-02 15:04:05.000000000"
+
+func (l *link) sortKey(s storage.Sort) string {
+	switch s {
+	case storage.SortTitle:
+		return l.Title
+	case storage.SortDomain:
+		return l.domain
+	default:
+		return l.DateAdded.UTC().Format(keyTime)
+	}
+}
+
+// pageLinks returns a page of links of the user in the order of page.Sort,
+// the caller must hold the lock.
+func (m *Memory) pageLinks(userID int, match func(l *link) bool, page storage.Page) ([]*gen.Link, *storage.Cursor) {
+	desc := page.Sort == storage.SortNewest
+
+	// compare orders links by key and id, reversed for descending sorts
+	compare := func(key string, id int, other *link) int {
+		c := strings.Compare(key, other.sortKey(page.Sort))
+		if c == 0 {
+			c = id - other.ID
+		}
+		if desc {
+			return -c
+		}
+		return c
+	}
+
+	var matched []*link
+	for _, l := range m.links {
+		if l.userID != userID || !match(l) {
+			continue
+		}
+		if page.After != nil && compare(page.After.Key, page.After.ID, l) >= 0 {
+			continue
+		}
+		matched = append(matched, l)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return compare(matched[i].sortKey(page.Sort), matched[i].ID, matched[j]) < 0
+	})
+
+	var next *storage.Cursor
+	if len(matched) > page.Size() {
+		matched = matched[:page.Size()]
+		last := matched[len(matched)-1]
+		next = &storage.Cursor{Sort: page.Sort, Key: last.sortKey(page.Sort), ID: last.ID}
+	}
+
+	links := make([]*gen.Link, 0, len(matched))
+	for _, l := range matched {
+		links = append(links, &gen.Link{
+			LinkId:      int32(l.ID),
+			OriginalUrl: l.OriginalURL,
+			Description: l.Description,
+		})
+	}
+
+	return links, next
+}
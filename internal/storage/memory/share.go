@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+)
+
+func (m *Memory) CreateShare(ctx context.Context, userID int64, s *models.Share) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, err := m.userLink(userID, s.LinkID)
+	if err != nil {
+		return err
+	}
+
+	m.lastShareID++
+	s.ID = m.lastShareID
+	s.UserID = userID
+	s.OriginalURL = l.OriginalURL
+	s.CreatedAt = time.Now()
+
+	stored := *s
+	stored.UsedAt = time.Time{}
+	m.shares[stored.ID] = &stored
+
+	return nil
+}
+
+func (m *Memory) GetShare(ctx context.Context, id int64) (*models.Share, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stored, ok := m.shares[id]
+	if !ok {
+		return nil, storage.ErrShareNotFound
+	}
+
+	s := *stored
+	return &s, nil
+}
+
+func (m *Memory) RevokeShares(ctx context.Context, userID int64, linkID int, shareID int64) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.userLink(userID, linkID); err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, s := range m.shares {
+		if s.LinkID != linkID || !s.RevokedAt.IsZero() || (shareID != 0 && s.ID != shareID) {
+			continue
+		}
+		s.RevokedAt = time.Now()
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+func (m *Memory) UseShare(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.shares[id]
+	if !ok || !s.UsedAt.IsZero() {
+		return storage.ErrShareUsed
+	}
+	s.UsedAt = time.Now()
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (m *Memory) AddSnapshot(ctx context.Context, userID int64, linkID int, s *models.Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, err := m.userLink(userID, linkID)
+	if err != nil {
+		return err
+	}
+
+	m.addSnapshot(l, s)
+
+	// a back-dated snapshot leaves the link as it is
+	if latest := l.snapshots[len(l.snapshots)-1]; latest.ID == s.ID {
+		l.ContentText = s.ContentText
+		l.Title = s.Title
+		l.Byline = s.Byline
+		l.LeadImage = s.LeadImage
+		l.WordCount = s.WordCount
+		l.Article = s.Article
+	}
+
+	return nil
+}
+
+// addSnapshot keeps a copy of s in the history of l,
+// the caller must hold the lock.
+func (m *Memory) addSnapshot(l *link, s *models.Snapshot) {
+	m.lastSnapshotID++
+	s.ID = m.lastSnapshotID
+	s.LinkID = l.ID
+	if s.CapturedAt.IsZero() {
+		s.CapturedAt = time.Now()
+	}
+
+	stored := *s
+	stored.Content = m.putBlob(s.Content)
+	if len(s.WARC) > 0 {
+		stored.WARC = m.putBlob(s.WARC)
+	}
+	l.snapshots = append(l.snapshots, &stored)
+
+	// oldest first, as ordered by captured_at and id in the sql drivers
+	sort.SliceStable(l.snapshots, func(i, j int) bool {
+		if !l.snapshots[i].CapturedAt.Equal(l.snapshots[j].CapturedAt) {
+			return l.snapshots[i].CapturedAt.Before(l.snapshots[j].CapturedAt)
+		}
+		return l.snapshots[i].ID < l.snapshots[j].ID
+	})
+}
+
+// snapshotBefore returns the latest snapshot of l captured before at,
+// zero at means the latest one. The caller must hold the lock.
+func (l *link) snapshotBefore(at time.Time) *models.Snapshot {
+	for i := len(l.snapshots) - 1; i >= 0; i-- {
+		if at.IsZero() || l.snapshots[i].CapturedAt.Before(at) {
+			return l.snapshots[i]
+		}
+	}
+	return nil
+}
+
+func (m *Memory) GetSnapshots(ctx context.Context, userID int64, linkID int) ([]*models.Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	l, err := m.userLink(userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*models.Snapshot, 0, len(l.snapshots))
+	for i := len(l.snapshots) - 1; i >= 0; i-- {
+		s := *l.snapshots[i]
+		s.Content = nil
+		s.WARC = nil
+		s.ContentText = ""
+		s.Article = ""
+		snapshots = append(snapshots, &s)
+	}
+
+	return snapshots, nil
+}
+
+// userSnapshot returns the snapshot of the link of the user with originalURL,
+// the caller must hold the lock.
+func (m *Memory) userSnapshot(userID int, originalURL string, at time.Time) (*link, *models.Snapshot, error) {
+	for _, l := range m.links {
+		if l.userID != userID || l.OriginalURL != originalURL {
+			continue
+		}
+		if s := l.snapshotBefore(at); s != nil {
+			return l, s, nil
+		}
+		break
+	}
+
+	return nil, nil, wrap.E(pkg, "failed to find snapshot", storage.ErrSnapshotNotFound)
+}
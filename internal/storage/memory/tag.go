@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+func (m *Memory) AddTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, err := m.userLink(userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.tags == nil {
+		l.tags = make(map[string]bool)
+	}
+	for _, tag := range tags {
+		l.tags[tag] = true
+	}
+
+	return l.tagNames(), nil
+}
+
+func (m *Memory) RemoveTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, err := m.userLink(userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		delete(l.tags, tag)
+	}
+
+	return l.tagNames(), nil
+}
+
+// userLink returns the link if it belongs to the user,
+// the caller must hold the lock.
+func (m *Memory) userLink(userID int64, linkID int) (*link, error) {
+	l, ok := m.links[linkID]
+	if !ok || m.users[l.userID].UserID != userID {
+		return nil, wrap.E(pkg, "link of the user not found", storage.ErrLinksNotFound)
+	}
+	return l, nil
+}
+
+func (l *link) tagNames() []string {
+	var names []string
+	for name := range l.tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Memory) GetLinksByTags(ctx context.Context, userID int64, tags []string, matchAll bool) ([]*gen.Link, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	return m.userLinks(id, func(l *link) bool {
+		n := 0
+		for _, tag := range tags {
+			if l.tags[tag] {
+				n++
+			}
+		}
+		if matchAll {
+			return n == len(tags)
+		}
+		return n > 0
+	}), nil
+}
+
+func (m *Memory) GetTagCounts(ctx context.Context, userID int64) ([]*gen.TagCount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	counts := make(map[string]int32)
+	for _, l := range m.links {
+		if l.userID != id {
+			continue
+		}
+		for tag := range l.tags {
+			counts[tag]++
+		}
+	}
+
+	var result []*gen.TagCount
+	for name, count := range counts {
+		result = append(result, &gen.TagCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
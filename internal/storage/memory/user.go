@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+// tx arguments are accepted for interface compatibility and ignored,
+// every method is atomic on its own.
+
+func (m *Memory) SaveUser(ctx context.Context, tx *sql.Tx, u *models.User) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.saveUser(u.UserID)
+}
+
+func (m *Memory) saveUser(telegramID int64) (int, error) {
+	if _, ok := m.userByTgID[telegramID]; ok {
+		return -1, wrap.E(pkg, "failed to SaveUser()", errUserExists)
+	}
+
+	m.lastUserID++
+	m.users[m.lastUserID] = &models.User{
+		ID:     m.lastUserID,
+		UserID: telegramID,
+	}
+	m.userByTgID[telegramID] = m.lastUserID
+
+	return m.lastUserID, nil
+}
+
+// getOrCreateUserID registers unknown telegram users on their first
+// request, like the sql drivers do.
+func (m *Memory) getOrCreateUserID(telegramID int64) int {
+	if id, ok := m.userByTgID[telegramID]; ok {
+		return id
+	}
+
+	id, _ := m.saveUser(telegramID)
+	return id
+}
+
+func (m *Memory) GetTelegramIDByID(ctx context.Context, tx *sql.Tx, id int) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	u, ok := m.users[id]
+	if !ok {
+		return -1, storage.ErrUserNotFound
+	}
+
+	return u.UserID, nil
+}
+
+func (m *Memory) GetUserByTelegramID(ctx context.Context, tx *sql.Tx, userID int64) (*models.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+
+	u := *m.users[id]
+	return &u, nil
+}
+
+func (m *Memory) GetUserIDByTelegramID(ctx context.Context, tx *sql.Tx, userID int64) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.userByTgID[userID]
+	if !ok {
+		return -1, storage.ErrUserNotFound
+	}
+
+	return id, nil
+}
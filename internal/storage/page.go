@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Sort is the order of a link listing, ties are broken by link id.
+type Sort int
+
+const (
+	SortNewest Sort = iota
+	SortOldest
+	SortTitle
+	SortDomain
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Page selects a slice of a link listing.
+type Page struct {
+	Sort  Sort
+	Limit int
+	// After is the position of the last link of the previous page,
+	// nil for the first page.
+	After *Cursor
+}
+
+// Size returns the number of links on the page.
+func (p Page) Size() int {
+	switch {
+	case p.Limit <= 0:
+		return DefaultPageSize
+	case p.Limit > MaxPageSize:
+		return MaxPageSize
+	default:
+		return p.Limit
+	}
+}
+
+// Cursor is a position in a link listing. Key is the sort value of the
+// link in a form chosen by the driver, ID is the link id.
+type Cursor struct {
+	Sort Sort   `json:"s"`
+	Key  string `json:"k"`
+	ID   int    `json:"i"`
+}
+
+// Token encodes the cursor for clients, it is opaque to them.
+func (c *Cursor) Token() string {
+	if c == nil {
+		return ""
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParsePageToken decodes a token made by Token. The empty token is the
+// first page, a token of a listing in another order is invalid.
+func ParsePageToken(token string, sort Sort) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort || c.ID <= 0 {
+		return nil, ErrInvalidPageToken
+	}
+
+	return &c, nil
+}
+
+// Domain returns the host of rawURL without "www.", links are sorted by it.
+func Domain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
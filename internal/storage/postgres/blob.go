@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+const convertBatchSize = 100
+
+// contentBlobsVersion is the schema version content saved before blobs
+// is converted at, the migration after it drops the old content column.
+const contentBlobsVersion = 17
+
+// putBlob stores content or takes another reference to the blob holding
+// it already, it returns the hash to refer to it by.
+func (p *Postgres) putBlob(ctx context.Context, tx *sql.Tx, content []byte) ([]byte, error) {
+	hash := storage.BlobHash(content)
+
+	// most captures of a page are new, but refetched pages often are not,
+	// and then there is nothing to compress
+	q := `UPDATE blobs SET refs = refs + 1 WHERE hash = $1`
+	res, err := tx.ExecContext(ctx, q, hash)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to putBlob(), q="+q, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, wrap.E(pkg, "failed to RowsAffected()", err)
+	} else if n > 0 {
+		return hash, nil
+	}
+
+	encoding, data, err := storage.EncodeBlob(content)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to EncodeBlob()", err)
+	}
+
+	var key sql.NullString
+	if p.blobs != nil {
+		key = sql.NullString{String: storage.NewBlobKey(hash), Valid: true}
+		if err = p.blobs.Put(ctx, key.String, data); err != nil {
+			return nil, wrap.E(pkg, "failed to Put()", err)
+		}
+		data = []byte{}
+	}
+
+	var stored sql.NullString
+	q = `INSERT INTO blobs (hash, encoding, size, data, key, refs) VALUES ($1, $2, $3, $4, $5, 1)
+	ON CONFLICT (hash) DO UPDATE SET refs = blobs.refs + 1
+	RETURNING key`
+	err = tx.QueryRowContext(ctx, q, hash, encoding, len(content), data, key).Scan(&stored)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to putBlob(), q="+q, err)
+	}
+
+	// another capture stored the same content meanwhile
+	if key.Valid && stored != key {
+		p.blobs.Delete(ctx, key.String)
+	}
+
+	return hash, nil
+}
+
+// linkBlobs returns the hashes of the pages and WARC files of the snapshots
+// of a link with the number of references to each.
+func (p *Postgres) linkBlobs(ctx context.Context, tx *sql.Tx, linkID int) (map[string]int, error) {
+	q := `SELECT hash, COUNT(*) FROM (
+		SELECT content_hash AS hash FROM link_snapshots WHERE link_id = $1
+		UNION ALL
+		SELECT warc_hash FROM link_snapshots WHERE link_id = $1
+	) h
+	WHERE hash IS NOT NULL
+	GROUP BY hash`
+	rows, err := tx.QueryContext(ctx, q, linkID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to linkBlobs(), q="+q, err)
+	}
+	defer rows.Close()
+
+	refs := make(map[string]int)
+	for rows.Next() {
+		var hash []byte
+		var n int
+		if err := rows.Scan(&hash, &n); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		refs[string(hash)] = n
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return refs, nil
+}
+
+// releaseBlobs drops the references counted by linkBlobs once their
+// snapshots are gone and deletes the blobs nothing refers to anymore.
+// It returns the store keys of the deleted blobs for deleteBlobs.
+func (p *Postgres) releaseBlobs(ctx context.Context, tx *sql.Tx, refs map[string]int) ([]string, error) {
+	var keys []string
+	for hash, n := range refs {
+		q := `UPDATE blobs SET refs = refs - $2 WHERE hash = $1`
+		if _, err := tx.ExecContext(ctx, q, []byte(hash), n); err != nil {
+			return nil, wrap.E(pkg, "failed to releaseBlobs(), q="+q, err)
+		}
+
+		var key sql.NullString
+		q = `DELETE FROM blobs WHERE hash = $1 AND refs <= 0 RETURNING key`
+		err := tx.QueryRowContext(ctx, q, []byte(hash)).Scan(&key)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to releaseBlobs(), q="+q, err)
+		}
+		if key.Valid {
+			keys = append(keys, key.String)
+		}
+	}
+
+	return keys, nil
+}
+
+// deleteBlobs deletes the content of blobs from the store once their
+// rows are gone for good. A failure only leaves an object nothing refers to.
+func (p *Postgres) deleteBlobs(ctx context.Context, keys []string) {
+	if p.blobs == nil {
+		return
+	}
+	for _, key := range keys {
+		p.blobs.Delete(ctx, key)
+	}
+}
+
+// openBlob streams the content of a blob read from the blobs table.
+func (p *Postgres) openBlob(ctx context.Context, encoding string, key sql.NullString, data []byte) (io.ReadCloser, error) {
+	r, err := storage.OpenBlob(ctx, p.blobs, encoding, key.String, data)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to OpenBlob()", err)
+	}
+	return r, nil
+}
+
+// convertContent moves snapshot content saved before blobs existed into
+// blobs. It works in batches, so a large table is not locked at once and
+// an interrupted conversion goes on from where it stopped.
+func (p *Postgres) convertContent(ctx context.Context) error {
+	for {
+		n, err := p.convertContentBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n < convertBatchSize {
+			return nil
+		}
+	}
+}
+
+func (p *Postgres) convertContentBatch(ctx context.Context) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `SELECT id, content FROM link_snapshots WHERE content_hash IS NULL
+	ORDER BY id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`
+	rows, err := tx.QueryContext(ctx, q, convertBatchSize)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to convertContent(), q="+q, err)
+	}
+
+	contents := make(map[int64][]byte)
+	for rows.Next() {
+		var id int64
+		var content []byte
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return 0, wrap.E(pkg, "failed to Scan()", err)
+		}
+		contents[id] = content
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	for id, content := range contents {
+		hash, err := p.putBlob(ctx, tx, content)
+		if err != nil {
+			return 0, err
+		}
+
+		q = `UPDATE link_snapshots SET content_hash = $2, content = '' WHERE id = $1`
+		if _, err = tx.ExecContext(ctx, q, id, hash); err != nil {
+			return 0, wrap.E(pkg, "failed to convertContent(), q="+q, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return len(contents), nil
+}
+
+// moveBlobs puts content kept in the blobs table into the store, so the
+// table keeps only keys once a store is configured.
+func (p *Postgres) moveBlobs(ctx context.Context) error {
+	if p.blobs == nil {
+		return nil
+	}
+
+	for {
+		n, err := p.moveBlobsBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n < convertBatchSize {
+			return nil
+		}
+	}
+}
+
+func (p *Postgres) moveBlobsBatch(ctx context.Context) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `SELECT hash, data FROM blobs WHERE key IS NULL
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`
+	rows, err := tx.QueryContext(ctx, q, convertBatchSize)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to moveBlobs(), q="+q, err)
+	}
+
+	blobs := make(map[string][]byte)
+	for rows.Next() {
+		var hash, data []byte
+		if err := rows.Scan(&hash, &data); err != nil {
+			rows.Close()
+			return 0, wrap.E(pkg, "failed to Scan()", err)
+		}
+		blobs[string(hash)] = data
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	for hash, data := range blobs {
+		key := storage.NewBlobKey([]byte(hash))
+		if err = p.blobs.Put(ctx, key, data); err != nil {
+			return 0, wrap.E(pkg, "failed to Put()", err)
+		}
+
+		q = `UPDATE blobs SET key = $2, data = '' WHERE hash = $1`
+		if _, err = tx.ExecContext(ctx, q, []byte(hash), key); err != nil {
+			return 0, wrap.E(pkg, "failed to moveBlobs(), q="+q, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return len(blobs), nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) ClaimLinkChecks(ctx context.Context, interval time.Duration, limit int) ([]*models.LinkCheck, error) {
+	// SKIP LOCKED lets several service instances check links concurrently
+	q := `UPDATE links l SET checked_at = now()
+	WHERE l.id IN (
+		SELECT id FROM links
+		WHERE checked_at IS NULL OR checked_at <= now() - make_interval(secs => $1)
+		ORDER BY checked_at NULLS FIRST, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING l.id, l.original_url, l.description, l.check_status, l.check_failures`
+	rows, err := p.db.QueryContext(ctx, q, interval.Seconds(), limit)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to ClaimLinkChecks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var checks []*models.LinkCheck
+	for rows.Next() {
+		var c models.LinkCheck
+		if err := rows.Scan(&c.LinkID, &c.OriginalURL, &c.Description, &c.Status, &c.Failures); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		checks = append(checks, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return checks, nil
+}
+
+func (p *Postgres) RecordLinkCheck(ctx context.Context, c *models.LinkCheck) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `UPDATE links SET check_status = $2, check_failures = $3, checked_at = now(),
+		last_ok_at = CASE WHEN $4 BETWEEN 200 AND 299 THEN now() ELSE last_ok_at END
+	WHERE id = $1`
+	res, err := tx.ExecContext(ctx, q, c.LinkID, c.Status, c.Failures, c.StatusCode)
+	if err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	} else if n == 0 {
+		return wrap.E(pkg, "failed to RecordLinkCheck()", storage.ErrLinksNotFound)
+	}
+
+	q = `INSERT INTO link_checks (link_id, status, status_code, location, error)
+	VALUES ($1, $2, $3, $4, $5) RETURNING checked_at`
+	err = tx.QueryRowContext(ctx, q, c.LinkID, c.Status, c.StatusCode, c.Location, c.Error).Scan(&c.CheckedAt)
+	if err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+
+	q = `DELETE FROM link_checks WHERE link_id = $1 AND id NOT IN (
+		SELECT id FROM link_checks WHERE link_id = $1 ORDER BY id DESC LIMIT $2
+	)`
+	if _, err = tx.ExecContext(ctx, q, c.LinkID, storage.LinkCheckHistory); err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) GetBrokenLinks(ctx context.Context, userID int64) ([]*models.LinkCheck, error) {
+	q := `SELECT l.id, l.original_url, l.description, l.check_status, l.check_failures, l.last_ok_at,
+		c.status_code, c.location, c.error, c.checked_at
+	FROM links l
+	JOIN users u ON u.id = l.user_id
+	JOIN link_checks c ON c.id = (SELECT MAX(id) FROM link_checks WHERE link_id = l.id)
+	WHERE u.telegram_user_id = $1 AND l.check_status IN ($2, $3)
+	ORDER BY l.id`
+	rows, err := p.db.QueryContext(ctx, q, userID, models.LinkStatusDead, models.LinkStatusMoved)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetBrokenLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var checks []*models.LinkCheck
+	for rows.Next() {
+		var c models.LinkCheck
+		var lastOK sql.NullTime
+		if err := rows.Scan(&c.LinkID, &c.OriginalURL, &c.Description, &c.Status, &c.Failures, &lastOK,
+			&c.StatusCode, &c.Location, &c.Error, &c.CheckedAt); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		c.LastOKAt = lastOK.Time
+		checks = append(checks, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/lib/pq"
+)
+
+func (p *Postgres) CreateCollection(ctx context.Context, c *models.Collection) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	userID, err := p.GetUserIDByTelegramID(ctx, tx, c.UserID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserNotFound) {
+			return wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+		}
+		userID, err = p.SaveUser(ctx, tx, &models.User{UserID: c.UserID})
+		if err != nil {
+			return wrap.E(pkg, "failed to CreateCollection(), SaveUser()", err)
+		}
+	}
+
+	if c.ParentID != 0 {
+		if err = p.checkCollection(ctx, tx, userID, c.ParentID); err != nil {
+			return err
+		}
+	}
+
+	q := `INSERT INTO collections (user_id, parent_id, name) VALUES ($1, $2, $3) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, q, userID, nullID(c.ParentID), c.Name).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return wrap.E(pkg, "failed to CreateCollection(), "+err.Error(), storage.ErrCollectionExists)
+		}
+		return wrap.E(pkg, "failed to CreateCollection(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) RenameCollection(ctx context.Context, userID int64, id int, name string) error {
+	q := `UPDATE collections SET name = $1
+	WHERE id = $2 AND user_id = (SELECT id FROM users WHERE telegram_user_id = $3)`
+	res, err := p.db.ExecContext(ctx, q, name, id, userID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return wrap.E(pkg, "failed to RenameCollection(), "+err.Error(), storage.ErrCollectionExists)
+		}
+		return wrap.E(pkg, "failed to RenameCollection(), q="+q, err)
+	}
+
+	return collectionAffected(res)
+}
+
+func (p *Postgres) DeleteCollection(ctx context.Context, userID int64, id int) error {
+	q := `DELETE FROM collections
+	WHERE id = $1 AND user_id = (SELECT id FROM users WHERE telegram_user_id = $2)`
+	res, err := p.db.ExecContext(ctx, q, id, userID)
+	if err != nil {
+		return wrap.E(pkg, "failed to DeleteCollection(), q="+q, err)
+	}
+
+	return collectionAffected(res)
+}
+
+func collectionAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n == 0 {
+		return storage.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (p *Postgres) GetCollections(ctx context.Context, userID int64) ([]*gen.Collection, error) {
+	q := `SELECT c.id, COALESCE(c.parent_id, 0), c.name, COUNT(l.id)
+	FROM collections c
+	JOIN users u ON u.id = c.user_id
+	LEFT JOIN links l ON l.collection_id = c.id
+	WHERE u.telegram_user_id = $1
+	GROUP BY c.id
+	ORDER BY c.name, c.id`
+	rows, err := p.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetCollections(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var collections []*gen.Collection
+	for rows.Next() {
+		var c gen.Collection
+		if err := rows.Scan(&c.CollectionId, &c.ParentId, &c.Name, &c.LinkCount); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		collections = append(collections, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return collections, nil
+}
+
+func (p *Postgres) MoveLinks(ctx context.Context, userID int64, linkIDs []int, collectionID int) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	user_ID, err := p.GetUserIDByTelegramID(ctx, tx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return wrap.E(pkg, "user has no links", storage.ErrLinksNotFound)
+		}
+		return wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	if collectionID != 0 {
+		if err = p.checkCollection(ctx, tx, user_ID, collectionID); err != nil {
+			return err
+		}
+	}
+
+	ids := make([]int64, len(linkIDs))
+	for i, id := range linkIDs {
+		ids[i] = int64(id)
+	}
+
+	q := `UPDATE links SET collection_id = $1 WHERE user_id = $2 AND id = ANY($3)`
+	res, err := tx.ExecContext(ctx, q, nullID(collectionID), user_ID, pq.Array(ids))
+	if err != nil {
+		return wrap.E(pkg, "failed to MoveLinks(), q="+q, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n != int64(len(linkIDs)) {
+		return wrap.E(pkg, "some links are not the user's", storage.ErrLinksNotFound)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) GetCollectionLinks(ctx context.Context, userID int64, collectionID int, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, nil, storage.ErrCollectionNotFound
+		}
+		return nil, nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	if err = p.checkCollection(ctx, nil, user_ID, collectionID); err != nil {
+		return nil, nil, err
+	}
+
+	links, next, err := p.listLinks(ctx, `user_id = $1 AND collection_id = $2`, []any{user_ID, collectionID}, page)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to GetCollectionLinks()", err)
+	}
+
+	return links, next, nil
+}
+
+// checkCollection returns ErrCollectionNotFound unless the collection is the user's.
+func (p *Postgres) checkCollection(ctx context.Context, tx *sql.Tx, userID int, id int) error {
+	var one int
+	var err error
+	q := `SELECT 1 FROM collections WHERE id = $1 AND user_id = $2`
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, q, id, userID).Scan(&one)
+	} else {
+		err = p.db.QueryRowContext(ctx, q, id, userID).Scan(&one)
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrCollectionNotFound
+		}
+		return wrap.E(pkg, "failed to checkCollection(), q="+q, err)
+	}
+
+	return nil
+}
+
+// nullID stores 0 as NULL.
+func nullID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) GetLinksForExport(ctx context.Context, userID int64) ([]*models.Link, error) {
+	q := `SELECT l.id, l.original_url, l.description, l.title, l.date_added,
+		COALESCE(l.collection_id, 0), COALESCE(string_agg(t.name, ','), '')
+	FROM links l
+	JOIN users u ON u.id = l.user_id
+	LEFT JOIN link_tags lt ON lt.link_id = l.id
+	LEFT JOIN tags t ON t.id = lt.tag_id
+	WHERE u.telegram_user_id = $1
+	GROUP BY l.id
+	ORDER BY l.id`
+
+	rows, err := p.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetLinksForExport(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var links []*models.Link
+	for rows.Next() {
+		l := models.Link{UserID: userID}
+		var dateAdded sql.NullTime
+		var tags string
+		err := rows.Scan(&l.ID, &l.OriginalURL, &l.Description, &l.Title, &dateAdded, &l.CollectionID, &tags)
+		if err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		l.DateAdded = dateAdded.Time
+		l.Tags = storage.SplitTags(tags)
+		slices.Sort(l.Tags)
+		links = append(links, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "failed to iterate rows", err)
+	}
+
+	return links, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) GetTakenLinks(ctx context.Context, userID int64) (map[string]bool, map[string]bool, error) {
+	urls := make(map[string]bool)
+	descriptions := make(map[string]bool)
+
+	q := `SELECT l.original_url, l.description
+	FROM links l JOIN users u ON u.id = l.user_id
+	WHERE u.telegram_user_id = $1
+	UNION ALL
+	SELECT j.original_url, j.description
+	FROM capture_jobs j JOIN users u ON u.id = j.user_id
+	WHERE u.telegram_user_id = $1 AND NOT j.recapture AND j.status IN ('pending', 'running')`
+
+	rows, err := p.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to GetTakenLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var url, description string
+		if err := rows.Scan(&url, &description); err != nil {
+			return nil, nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		urls[url] = true
+		descriptions[description] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, wrap.E(pkg, "failed to iterate rows", err)
+	}
+
+	return urls, descriptions, nil
+}
+
+func (p *Postgres) CreateImport(ctx context.Context, imp *models.Import, jobs []*models.CaptureJob) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	userID, err := p.GetUserIDByTelegramID(ctx, tx, imp.UserID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserNotFound) {
+			return wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+		}
+		userID, err = p.SaveUser(ctx, tx, &models.User{UserID: imp.UserID})
+		if err != nil {
+			return wrap.E(pkg, "failed to CreateImport(), SaveUser()", err)
+		}
+	}
+
+	q := `INSERT INTO imports (user_id, total, queued, duplicates, invalid)
+	VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, q, userID, imp.Total, imp.Queued, imp.Duplicates, imp.Invalid).
+		Scan(&imp.ID, &imp.CreatedAt)
+	if err != nil {
+		return wrap.E(pkg, "failed to CreateImport(), q="+q, err)
+	}
+
+	for _, j := range jobs {
+		j.ImportID = imp.ID
+		j.ID, err = insertCaptureJob(ctx, tx, userID, j)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) GetImport(ctx context.Context, userID int64, id int64) (*models.Import, error) {
+	q := `SELECT i.id, u.telegram_user_id, i.total, i.queued, i.duplicates, i.invalid, i.created_at,
+		COUNT(j.id) FILTER (WHERE j.status = 'pending'),
+		COUNT(j.id) FILTER (WHERE j.status = 'running'),
+		COUNT(j.id) FILTER (WHERE j.status = 'done'),
+		COUNT(j.id) FILTER (WHERE j.status = 'failed')
+	FROM imports i
+	JOIN users u ON u.id = i.user_id
+	LEFT JOIN capture_jobs j ON j.import_id = i.id
+	WHERE i.id = $1 AND u.telegram_user_id = $2
+	GROUP BY i.id, u.telegram_user_id`
+
+	var imp models.Import
+	err := p.db.QueryRowContext(ctx, q, id, userID).Scan(&imp.ID, &imp.UserID, &imp.Total, &imp.Queued,
+		&imp.Duplicates, &imp.Invalid, &imp.CreatedAt, &imp.Pending, &imp.Running, &imp.Done, &imp.Failed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrImportNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetImport(), q="+q, err)
+	}
+
+	return &imp, nil
+}
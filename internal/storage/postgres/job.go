@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) EnqueueCapture(ctx context.Context, j *models.CaptureJob) (int64, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	userID, err := p.GetUserIDByTelegramID(ctx, tx, j.UserID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserNotFound) {
+			return -1, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+		}
+		userID, err = p.SaveUser(ctx, tx, &models.User{UserID: j.UserID})
+		if err != nil {
+			return -1, wrap.E(pkg, "failed to EnqueueCapture(), SaveUser()", err)
+		}
+	}
+
+	id, err := insertCaptureJob(ctx, tx, userID, j)
+	if err != nil {
+		return -1, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return -1, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return id, nil
+}
+
+func insertCaptureJob(ctx context.Context, tx *sql.Tx, userID int, j *models.CaptureJob) (int64, error) {
+	var id int64
+	q := `INSERT INTO capture_jobs (user_id, original_url, description, link_id, recapture,
+		import_id, date_added, tags, collection_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	err := tx.QueryRowContext(ctx, q, userID, j.OriginalURL, j.Description, nullID(j.LinkID), j.Recapture,
+		nullJobID(j.ImportID), nullTime(j.DateAdded), storage.JoinTags(j.Tags), nullID(j.CollectionID)).Scan(&id)
+	if err != nil {
+		return -1, wrap.E(pkg, "failed to insert capture job, q="+q, err)
+	}
+
+	return id, nil
+}
+
+func nullJobID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// jobColumns are read by scanCaptureJob, j is capture_jobs and u is users.
+const jobColumns = `j.id, u.telegram_user_id, j.original_url, j.description, j.status, j.attempts, j.error,
+		COALESCE(j.link_id, 0), j.recapture, COALESCE(j.import_id, 0), j.date_added, j.tags,
+		COALESCE(j.collection_id, 0), j.created_at`
+
+func scanCaptureJob(row *sql.Row) (*models.CaptureJob, error) {
+	var j models.CaptureJob
+	var dateAdded sql.NullTime
+	var tags string
+	err := row.Scan(&j.ID, &j.UserID, &j.OriginalURL, &j.Description, &j.Status, &j.Attempts, &j.Error,
+		&j.LinkID, &j.Recapture, &j.ImportID, &dateAdded, &tags, &j.CollectionID, &j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	j.DateAdded = dateAdded.Time
+	j.Tags = storage.SplitTags(tags)
+
+	return &j, nil
+}
+
+func (p *Postgres) ClaimCaptureJob(ctx context.Context, lease time.Duration, maxAttempts int) (*models.CaptureJob, error) {
+	// running jobs that are due lost their worker, on the last attempt they fail
+	q := `UPDATE capture_jobs SET status = 'failed', error = $2, updated_at = now()
+	WHERE status = 'running' AND run_at <= now() AND attempts >= $1`
+	if _, err := p.db.ExecContext(ctx, q, maxAttempts, storage.JobLeaseExpired); err != nil {
+		return nil, wrap.E(pkg, "failed to fail expired capture jobs, q="+q, err)
+	}
+
+	// SKIP LOCKED lets several workers and service instances claim jobs concurrently
+	q = `UPDATE capture_jobs j
+	SET status = 'running', attempts = j.attempts + 1,
+		run_at = now() + make_interval(secs => $1), updated_at = now()
+	FROM users u
+	WHERE u.id = j.user_id AND j.id = (
+		SELECT id FROM capture_jobs
+		WHERE (status = 'pending' OR status = 'running' AND attempts < $2) AND run_at <= now()
+		ORDER BY run_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + jobColumns
+
+	j, err := scanCaptureJob(p.db.QueryRowContext(ctx, q, lease.Seconds(), maxAttempts))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNoJobs
+		}
+		return nil, wrap.E(pkg, "failed to ClaimCaptureJob(), q="+q, err)
+	}
+
+	return j, nil
+}
+
+// the attempt of a claim is its lease token, a job claimed again has more
+const leaseHeld = `id = $1 AND attempts = $2 AND status = 'running'`
+
+func (p *Postgres) CompleteCaptureJob(ctx context.Context, id int64, attempt int, linkID int) error {
+	q := `UPDATE capture_jobs SET status = 'done', error = '', link_id = $3, updated_at = now() WHERE ` + leaseHeld
+	return p.updateCaptureJob(ctx, q, id, attempt, linkID)
+}
+
+func (p *Postgres) RetryCaptureJob(ctx context.Context, id int64, attempt int, after time.Duration, reason string) error {
+	q := `UPDATE capture_jobs SET status = 'pending', error = $3,
+		run_at = now() + make_interval(secs => $4), updated_at = now() WHERE ` + leaseHeld
+	return p.updateCaptureJob(ctx, q, id, attempt, reason, after.Seconds())
+}
+
+func (p *Postgres) FailCaptureJob(ctx context.Context, id int64, attempt int, reason string) error {
+	q := `UPDATE capture_jobs SET status = 'failed', error = $3, updated_at = now() WHERE ` + leaseHeld
+	return p.updateCaptureJob(ctx, q, id, attempt, reason)
+}
+
+// updateCaptureJob runs q with the job id and the attempt of the claim
+// as the first arguments.
+func (p *Postgres) updateCaptureJob(ctx context.Context, q string, id int64, args ...any) error {
+	res, err := p.db.ExecContext(ctx, q, append([]any{id}, args...)...)
+	if err != nil {
+		return wrap.E(pkg, "failed to update capture job, q="+q, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n == 0 {
+		var exists bool
+		err = p.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM capture_jobs WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return wrap.E(pkg, "failed to check capture job", err)
+		}
+		if !exists {
+			return storage.ErrJobNotFound
+		}
+		return storage.ErrJobLeaseLost
+	}
+
+	return nil
+}
+
+func (p *Postgres) GetCaptureJob(ctx context.Context, id int64) (*models.CaptureJob, error) {
+	q := `SELECT ` + jobColumns + `
+	FROM capture_jobs j JOIN users u ON u.id = j.user_id
+	WHERE j.id = $1`
+
+	j, err := scanCaptureJob(p.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrJobNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetCaptureJob(), q="+q, err)
+	}
+
+	return j, nil
+}
@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/0x0FACED/link-saver-api/internal/domain/models"
 	"github.com/0x0FACED/link-saver-api/internal/storage"
 	"github.com/0x0FACED/link-saver-api/internal/wrap"
 	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/lib/pq"
 )
 
 func (p *Postgres) SaveLink(ctx context.Context, l *models.Link) error {
@@ -33,12 +37,32 @@ func (p *Postgres) SaveLink(ctx context.Context, l *models.Link) error {
 		}
 	}
 
-	q := `INSERT INTO links (original_url, user_id, description, content) VALUES ($1, $2, $3, $4)`
-	_, err = tx.ExecContext(ctx, q, l.OriginalURL, id, l.Description, l.Content)
+	// imported links keep the date they were bookmarked
+	q := `INSERT INTO links (original_url, user_id, description, content_text, title, byline, lead_image, word_count, article, domain, date_added)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE($11::timestamp, CURRENT_TIMESTAMP)) RETURNING id`
+	err = tx.QueryRowContext(ctx, q, l.OriginalURL, id, l.Description, l.ContentText,
+		l.Title, l.Byline, l.LeadImage, l.WordCount, l.Article, storage.Domain(l.OriginalURL), nullTime(l.DateAdded)).Scan(&l.ID)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return wrap.E(pkg, "failed to SaveLink(), "+err.Error(), storage.ErrLinkExists)
+		}
 		return wrap.E(pkg, "failed to SaveLink(), q="+q, err)
 	}
 
+	err = p.insertSnapshot(ctx, tx, l.ID, &models.Snapshot{
+		Content:     l.Content,
+		ContentText: l.ContentText,
+		Title:       l.Title,
+		Byline:      l.Byline,
+		LeadImage:   l.LeadImage,
+		WordCount:   l.WordCount,
+		Article:     l.Article,
+		WARC:        l.WARC,
+	})
+	if err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return wrap.E(pkg, "failed to Commit()", err)
 	}
@@ -46,10 +70,10 @@ func (p *Postgres) SaveLink(ctx context.Context, l *models.Link) error {
 	return nil
 }
 
-func (p *Postgres) GetUserLinks(ctx context.Context, userID int64) ([]*gen.Link, error) {
+func (p *Postgres) GetUserLinks(ctx context.Context, userID int64, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
 	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
 	if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
-		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+		return nil, nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
 	}
 
 	if errors.Is(err, storage.ErrUserNotFound) {
@@ -58,34 +82,34 @@ func (p *Postgres) GetUserLinks(ctx context.Context, userID int64) ([]*gen.Link,
 		}
 		user_ID, err = p.SaveUser(ctx, nil, u)
 		if err != nil {
-			return nil, wrap.E(pkg, "failed to SaveUser()", err)
+			return nil, nil, wrap.E(pkg, "failed to SaveUser()", err)
 		}
 	}
 
-	q := `SELECT id, original_url, description FROM links WHERE user_id = $1`
-	rows, err := p.db.QueryContext(ctx, q, user_ID)
+	links, next, err := p.listLinks(ctx, `user_id = $1`, []any{user_ID}, page)
 	if err != nil {
-		return nil, wrap.E(pkg, "failed to GetUserLinks(), q="+q, err)
+		return nil, nil, wrap.E(pkg, "failed to GetUserLinks()", err)
 	}
-	defer rows.Close()
 
-	var links []*gen.Link
-	for rows.Next() {
-		var l gen.Link
-		if err := rows.Scan(&l.LinkId, &l.OriginalUrl, &l.Description); err != nil {
-			return nil, wrap.E(pkg, "failed to Scan()", err)
-		}
-		links = append(links, &l)
+	return links, next, nil
+}
+
+func (p *Postgres) GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) ([]byte, error) {
+	r, err := p.OpenContentByTelegramIDOriginalURL(ctx, userID, originalURL, at)
+	if err != nil {
+		return nil, err
 	}
+	defer r.Close()
 
-	if err := rows.Err(); err != nil {
-		return nil, wrap.E(pkg, "error in rows.Err()()", err)
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to read content", err)
 	}
 
-	return links, nil
+	return content, nil
 }
 
-func (p *Postgres) GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string) ([]byte, error) {
+func (p *Postgres) OpenContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
 	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
 	if err != nil && err != storage.ErrUserNotFound {
 		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
@@ -100,54 +124,105 @@ func (p *Postgres) GetContentByTelegramIDOriginalURL(ctx context.Context, userID
 			return nil, wrap.E(pkg, "failed to SaveUser()", err)
 		}
 	}
-	var content []byte
-
-	q := `SELECT content FROM links WHERE user_id = $1 AND original_url = $2`
-	err = p.db.QueryRowContext(ctx, q, user_ID, originalURL).Scan(&content)
+	var encoding string
+	var key sql.NullString
+	var data []byte
+
+	q := `SELECT b.encoding, b.key, b.data FROM links l
+	JOIN link_snapshots s ON s.link_id = l.id
+	JOIN blobs b ON b.hash = s.content_hash
+	WHERE l.user_id = $1 AND l.original_url = $2 AND ($3::timestamp IS NULL OR s.captured_at < $3)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = p.db.QueryRowContext(ctx, q, user_ID, originalURL, nullTime(at)).Scan(&encoding, &key, &data)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetContent()", storage.ErrSnapshotNotFound)
+		}
 		return nil, wrap.E(pkg, "failed to GetContent(), q="+q, err)
 	}
 
-	return content, nil
+	return p.openBlob(ctx, encoding, key, data)
 }
 
-func (p *Postgres) GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string) ([]*gen.Link, error) {
+func (p *Postgres) OpenWARCByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
 	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
-	if err != nil && err != storage.ErrUserNotFound {
+	if err != nil {
 		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
 	}
 
+	var encoding, key sql.NullString
+	var data []byte
+
+	// the snapshot is picked as for the page, whether it has a WARC file or not
+	q := `SELECT b.encoding, b.key, b.data FROM links l
+	JOIN link_snapshots s ON s.link_id = l.id
+	LEFT JOIN blobs b ON b.hash = s.warc_hash
+	WHERE l.user_id = $1 AND l.original_url = $2 AND ($3::timestamp IS NULL OR s.captured_at < $3)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = p.db.QueryRowContext(ctx, q, user_ID, originalURL, nullTime(at)).Scan(&encoding, &key, &data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetWARC()", storage.ErrSnapshotNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to GetWARC(), q="+q, err)
+	}
+	if !encoding.Valid {
+		return nil, wrap.E(pkg, "failed to GetWARC()", storage.ErrWARCNotFound)
+	}
+
+	return p.openBlob(ctx, encoding.String, key, data)
+}
+
+func (p *Postgres) GetArticleByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (*models.Link, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	l := models.Link{UserID: userID}
+
+	q := `SELECT l.id, l.original_url, l.description, s.title, s.byline, s.lead_image, s.word_count, s.article,
+		l.date_added, s.captured_at
+	FROM links l JOIN link_snapshots s ON s.link_id = l.id
+	WHERE l.user_id = $1 AND l.original_url = $2 AND ($3::timestamp IS NULL OR s.captured_at < $3)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = p.db.QueryRowContext(ctx, q, user_ID, originalURL, nullTime(at)).Scan(&l.ID, &l.OriginalURL, &l.Description,
+		&l.Title, &l.Byline, &l.LeadImage, &l.WordCount, &l.Article, &l.DateAdded, &l.CapturedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetArticle()", storage.ErrSnapshotNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to GetArticle(), q="+q, err)
+	}
+
+	return &l, nil
+}
+
+func (p *Postgres) GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil && err != storage.ErrUserNotFound {
+		return nil, nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
 	if err == storage.ErrUserNotFound {
 		u := &models.User{
 			UserID: userID,
 		}
 		user_ID, err = p.SaveUser(ctx, nil, u)
 		if err != nil {
-			return nil, wrap.E(pkg, "failed to SaveUser()", err)
+			return nil, nil, wrap.E(pkg, "failed to SaveUser()", err)
 		}
 	}
 
-	q := `SELECT id, original_url, description FROM links WHERE user_id = $1 AND description LIKE $2`
-	rows, err := p.db.QueryContext(ctx, q, user_ID, "%"+desc+"%")
+	links, next, err := p.listLinks(ctx, `user_id = $1 AND description LIKE $2`, []any{user_ID, "%" + desc + "%"}, page)
 	if err != nil {
-		return nil, wrap.E(pkg, "failed to GetLinks(), q="+q, err)
-	}
-	defer rows.Close()
-
-	var links []*gen.Link
-	for rows.Next() {
-		var l gen.Link
-		if err := rows.Scan(&l.LinkId, &l.OriginalUrl, &l.Description); err != nil {
-			return nil, wrap.E(pkg, "failed to Scan()", err)
-		}
-		links = append(links, &l)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, wrap.E(pkg, "error in rows.Err()", err)
+		return nil, nil, wrap.E(pkg, "failed to GetLinks()", err)
 	}
 
-	return links, nil
+	return links, next, nil
 }
 
 func (p *Postgres) GetLinkByID(ctx context.Context, id int) (*models.Link, error) {
@@ -180,13 +255,37 @@ func (p *Postgres) DeleteLink(ctx context.Context, id int) (string, int64, error
 	var telegramUserID int64
 	var userID int
 
-	q := `DELETE FROM links WHERE id = $1 RETURNING original_url, user_id`
-	err := p.db.QueryRowContext(ctx, q, id).Scan(&originalURL, &userID)
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", -1, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	// new snapshots lock the link too, so none is added between
+	// counting the references and deleting them
+	q := `SELECT id FROM links WHERE id = $1 FOR UPDATE`
+	if err = tx.QueryRowContext(ctx, q, id).Scan(&id); err != nil {
+		return "", -1, wrap.E(pkg, "failed to DeleteLink()", err)
+	}
+
+	refs, err := p.linkBlobs(ctx, tx, id)
+	if err != nil {
+		return "", -1, err
+	}
+
+	q = `DELETE FROM links WHERE id = $1 RETURNING original_url, user_id`
+	err = tx.QueryRowContext(ctx, q, id).Scan(&originalURL, &userID)
 	if err != nil {
 		return "", -1, wrap.E(pkg, "failed to DeleteLink()", err)
 	}
 
-	telegramUserID, err = p.GetTelegramIDByID(ctx, nil, userID)
+	// the snapshots are gone with the link, their blobs may be too
+	keys, err := p.releaseBlobs(ctx, tx, refs)
+	if err != nil {
+		return "", -1, err
+	}
+
+	telegramUserID, err = p.GetTelegramIDByID(ctx, tx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			return "", -1, wrap.E(pkg, "unusual behavior, link deleted, user not exists", err)
@@ -194,5 +293,71 @@ func (p *Postgres) DeleteLink(ctx context.Context, id int) (string, int64, error
 		return "", -1, wrap.E(pkg, "failed to GetTelegramIDByID()", err)
 	}
 
+	if err = tx.Commit(); err != nil {
+		return "", -1, wrap.E(pkg, "failed to Commit()", err)
+	}
+	p.deleteBlobs(ctx, keys)
+
 	return originalURL, telegramUserID, nil
 }
+
+// headlineOptions mark the terms in ts_headline as storage.Snippet does.
+const headlineOptions = `StartSel="` + storage.StartMark + `", StopSel="` + storage.StopMark + `", MaxFragments=2, MaxWords=30, MinWords=10`
+
+func (p *Postgres) SearchLinks(ctx context.Context, userID int64, query string) ([]*gen.SearchResult, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	if errors.Is(err, storage.ErrUserNotFound) {
+		u := &models.User{
+			UserID: userID,
+		}
+		user_ID, err = p.SaveUser(ctx, nil, u)
+		if err != nil {
+			return nil, wrap.E(pkg, "failed to SaveUser()", err)
+		}
+	}
+
+	// plainto_tsquery looks for all words of the query, as storage.SearchTerms,
+	// ts_headline is expensive, so it runs only for the rows that made the limit.
+	// The terms are marked and the snippet is escaped here, as by storage.Snippet.
+	q := `SELECT l.id, l.original_url, l.description, r.rank,
+		ts_headline('simple', translate(l.content_text, $4, ''), r.q, $5)
+	FROM (
+		SELECT id, q, ts_rank(search_vector, q) AS rank
+		FROM links, plainto_tsquery('simple', $2) q
+		WHERE user_id = $1 AND search_vector @@ q
+		ORDER BY rank DESC, id
+		LIMIT $3
+	) r
+	JOIN links l ON l.id = r.id
+	ORDER BY r.rank DESC, l.id`
+	rows, err := p.db.QueryContext(ctx, q, user_ID, query, storage.SearchLimit, storage.StartMark+storage.StopMark, headlineOptions)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to SearchLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var results []*gen.SearchResult
+	for rows.Next() {
+		r := gen.SearchResult{Link: &gen.Link{}}
+		if err := rows.Scan(&r.Link.LinkId, &r.Link.OriginalUrl, &r.Link.Description, &r.Rank, &r.Snippet); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		r.Snippet = storage.SnippetHTML(r.Snippet)
+		results = append(results, &r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return results, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
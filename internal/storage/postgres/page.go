@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+// sortColumn returns the column links are ordered by and whether the order is descending.
+func sortColumn(sort storage.Sort) (string, bool) {
+	switch sort {
+	case storage.SortOldest:
+		return "date_added", false
+	case storage.SortTitle:
+		return "title", false
+	case storage.SortDomain:
+		return "domain", false
+	default:
+		return "date_added", true
+	}
+}
+
+// listLinks returns a page of links matching where,
+// which refers to args as $1, $2 and so on.
+func (p *Postgres) listLinks(ctx context.Context, where string, args []any, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	col, desc := sortColumn(page.Sort)
+	order, cmp := "ASC", ">"
+	if desc {
+		order, cmp = "DESC", "<"
+	}
+
+	q := `SELECT id, original_url, description, date_added, title, domain FROM links WHERE ` + where
+	if page.After != nil {
+		var key any = page.After.Key
+		if col == "date_added" {
+			t, err := time.Parse(time.RFC3339Nano, page.After.Key)
+			if err != nil {
+				return nil, nil, storage.ErrInvalidPageToken
+			}
+			key = t
+		}
+		args = append(args, key, page.After.ID)
+		q += fmt.Sprintf(` AND (%s, id) %s ($%d, $%d)`, col, cmp, len(args)-1, len(args))
+	}
+	// one more row tells whether there is a next page
+	args = append(args, page.Size()+1)
+	q += fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT $%d`, col, order, order, len(args))
+
+	rows, err := p.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to listLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var links []*gen.Link
+	var keys []string
+	for rows.Next() {
+		var l gen.Link
+		var dateAdded time.Time
+		var title, domain string
+		if err := rows.Scan(&l.LinkId, &l.OriginalUrl, &l.Description, &dateAdded, &title, &domain); err != nil {
+			return nil, nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		links = append(links, &l)
+
+		switch col {
+		case "title":
+			keys = append(keys, title)
+		case "domain":
+			keys = append(keys, domain)
+		default:
+			keys = append(keys, dateAdded.Format(time.RFC3339Nano))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	if len(links) <= page.Size() {
+		return links, nil, nil
+	}
+
+	links = links[:page.Size()]
+	last := len(links) - 1
+	return links, &storage.Cursor{Sort: page.Sort, Key: keys[last], ID: int(links[last].LinkId)}, nil
+}
@@ -1,10 +1,12 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
 	"github.com/0x0FACED/link-saver-api/internal/wrap"
 	"github.com/0x0FACED/link-saver-api/migrations"
 	_ "github.com/lib/pq"
@@ -15,11 +17,14 @@ var pkg = "storage/postgres"
 type Postgres struct {
 	db     *sql.DB
 	config config.DatabaseConfig
+	// blobs is nil when content is kept in the blobs table
+	blobs storage.BlobStore
 }
 
-func New(cfg config.DatabaseConfig) *Postgres {
+func New(cfg config.DatabaseConfig, blobs storage.BlobStore) *Postgres {
 	return &Postgres{
 		config: cfg,
+		blobs:  blobs,
 	}
 }
 
@@ -36,14 +41,30 @@ func (p *Postgres) Connect() error {
 
 	p.db = db
 
-	err = migrations.Up(connStr)
+	err = migrations.Up(connStr, migrations.Step{
+		Version: contentBlobsVersion,
+		Run: func() error {
+			return p.convertContent(context.Background())
+		},
+	})
 	if err != nil {
 		return wrap.E(pkg, "failed to Up()", err)
 	}
 
+	if err = p.moveBlobs(context.Background()); err != nil {
+		return wrap.E(pkg, "failed to moveBlobs()", err)
+	}
+
 	return nil
 }
 
+func (p *Postgres) Close() error {
+	if p.db == nil {
+		return nil
+	}
+	return p.db.Close()
+}
+
 func (p Postgres) getConnStr() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
 		p.config.Username, p.config.Password, p.config.Host, p.config.Port, p.config.Name)
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/storage/storagetest"
+)
+
+func TestMain(m *testing.M) {
+	// migrations are looked up relative to the module root
+	if err := os.Chdir("../../.."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// TestPostgres runs against the database from TEST_DB_* variables,
+// all of its data is truncated before every test.
+func TestPostgres(t *testing.T) {
+	if os.Getenv("TEST_DB_HOST") == "" {
+		t.Skip("TEST_DB_HOST is not set")
+	}
+
+	cfg := config.DatabaseConfig{
+		Username: os.Getenv("TEST_DB_USER"),
+		Password: os.Getenv("TEST_DB_PASS"),
+		Name:     os.Getenv("TEST_DB_NAME"),
+		Host:     os.Getenv("TEST_DB_HOST"),
+		Port:     os.Getenv("TEST_DB_PORT"),
+	}
+
+	storagetest.Run(t, func(t *testing.T) storage.Database {
+		db := New(cfg, nil)
+		if err := db.Connect(); err != nil {
+			t.Fatalf("Connect(): %v", err)
+		}
+		t.Cleanup(func() { db.db.Close() })
+
+		if _, err := db.db.Exec(`TRUNCATE users, links, blobs RESTART IDENTITY CASCADE`); err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+		return db
+	})
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) CreateShare(ctx context.Context, userID int64, s *models.Share) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `SELECT l.original_url FROM links l JOIN users u ON u.id = l.user_id
+	WHERE l.id = $1 AND u.telegram_user_id = $2`
+	err = tx.QueryRowContext(ctx, q, s.LinkID, userID).Scan(&s.OriginalURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return wrap.E(pkg, "link of the user not found", storage.ErrLinksNotFound)
+		}
+		return wrap.E(pkg, "failed to CreateShare(), q="+q, err)
+	}
+
+	q = `INSERT INTO shares (link_id, expires_at, one_time, password_hash)
+	VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, q, s.LinkID, nullTime(s.ExpiresAt), s.OneTime, s.PasswordHash).
+		Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return wrap.E(pkg, "failed to CreateShare(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+	s.UserID = userID
+
+	return nil
+}
+
+func (p *Postgres) GetShare(ctx context.Context, id int64) (*models.Share, error) {
+	q := `SELECT s.id, s.link_id, u.telegram_user_id, l.original_url, s.expires_at, s.revoked_at, s.created_at,
+		s.one_time, s.used_at, s.password_hash
+	FROM shares s
+	JOIN links l ON l.id = s.link_id
+	JOIN users u ON u.id = l.user_id
+	WHERE s.id = $1`
+
+	var s models.Share
+	var expiresAt, revokedAt, usedAt sql.NullTime
+	err := p.db.QueryRowContext(ctx, q, id).Scan(&s.ID, &s.LinkID, &s.UserID, &s.OriginalURL,
+		&expiresAt, &revokedAt, &s.CreatedAt, &s.OneTime, &usedAt, &s.PasswordHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrShareNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetShare(), q="+q, err)
+	}
+	s.ExpiresAt = expiresAt.Time
+	s.RevokedAt = revokedAt.Time
+	s.UsedAt = usedAt.Time
+
+	return &s, nil
+}
+
+func (p *Postgres) RevokeShares(ctx context.Context, userID int64, linkID int, shareID int64) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := p.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return 0, err
+	}
+
+	q := `UPDATE shares SET revoked_at = CURRENT_TIMESTAMP
+	WHERE link_id = $1 AND revoked_at IS NULL AND ($2::bigint = 0 OR id = $2)`
+	res, err := tx.ExecContext(ctx, q, linkID, shareID)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to RevokeShares(), q="+q, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return int(n), nil
+}
+
+func (p *Postgres) UseShare(ctx context.Context, id int64) error {
+	q := `UPDATE shares SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL`
+	res, err := p.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return wrap.E(pkg, "failed to UseShare(), q="+q, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n == 0 {
+		return storage.ErrShareUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (p *Postgres) AddSnapshot(ctx context.Context, userID int64, linkID int, s *models.Snapshot) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = p.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return err
+	}
+
+	if err = p.insertSnapshot(ctx, tx, linkID, s); err != nil {
+		return err
+	}
+
+	// a back-dated snapshot leaves the link as it is
+	q := `UPDATE links l SET content_text = s.content_text, title = s.title, byline = s.byline,
+		lead_image = s.lead_image, word_count = s.word_count, article = s.article
+	FROM (
+		SELECT * FROM link_snapshots WHERE link_id = $1
+		ORDER BY captured_at DESC, id DESC
+		LIMIT 1
+	) s
+	WHERE l.id = $1 AND s.id = $2`
+	if _, err = tx.ExecContext(ctx, q, linkID, s.ID); err != nil {
+		return wrap.E(pkg, "failed to AddSnapshot(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) insertSnapshot(ctx context.Context, tx *sql.Tx, linkID int, s *models.Snapshot) error {
+	hash, err := p.putBlob(ctx, tx, s.Content)
+	if err != nil {
+		return err
+	}
+
+	var warcHash any
+	if len(s.WARC) > 0 {
+		if warcHash, err = p.putBlob(ctx, tx, s.WARC); err != nil {
+			return err
+		}
+	}
+
+	q := `INSERT INTO link_snapshots (link_id, content_hash, warc_hash, content_text, title, byline, lead_image, word_count, article, captured_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, CURRENT_TIMESTAMP)) RETURNING id, captured_at`
+	err = tx.QueryRowContext(ctx, q, linkID, hash, warcHash, s.ContentText, s.Title, s.Byline, s.LeadImage,
+		s.WordCount, s.Article, nullTime(s.CapturedAt)).Scan(&s.ID, &s.CapturedAt)
+	if err != nil {
+		return wrap.E(pkg, "failed to insertSnapshot(), q="+q, err)
+	}
+	s.LinkID = linkID
+
+	return nil
+}
+
+func (p *Postgres) GetSnapshots(ctx context.Context, userID int64, linkID int) ([]*models.Snapshot, error) {
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = p.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return nil, err
+	}
+
+	q := `SELECT id, title, byline, lead_image, word_count, captured_at FROM link_snapshots
+	WHERE link_id = $1 ORDER BY captured_at DESC, id DESC`
+	rows, err := tx.QueryContext(ctx, q, linkID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetSnapshots(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.Snapshot
+	for rows.Next() {
+		s := models.Snapshot{LinkID: linkID}
+		if err := rows.Scan(&s.ID, &s.Title, &s.Byline, &s.LeadImage, &s.WordCount, &s.CapturedAt); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		snapshots = append(snapshots, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return snapshots, nil
+}
+
+// nullTime is NULL for the zero time, so the column default applies.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/lib/pq"
+)
+
+func (p *Postgres) AddTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	user_ID, err := p.getLinkOwner(ctx, tx, userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `INSERT INTO tags (user_id, name) SELECT $1, unnest($2::text[])
+	ON CONFLICT (user_id, name) DO NOTHING`
+	if _, err = tx.ExecContext(ctx, q, user_ID, pq.Array(tags)); err != nil {
+		return nil, wrap.E(pkg, "failed to AddTags(), q="+q, err)
+	}
+
+	q = `INSERT INTO link_tags (link_id, tag_id)
+	SELECT $1, id FROM tags WHERE user_id = $2 AND name = ANY($3)
+	ON CONFLICT DO NOTHING`
+	if _, err = tx.ExecContext(ctx, q, linkID, user_ID, pq.Array(tags)); err != nil {
+		return nil, wrap.E(pkg, "failed to AddTags(), q="+q, err)
+	}
+
+	linkTags, err := p.getLinkTags(ctx, tx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return linkTags, nil
+}
+
+func (p *Postgres) RemoveTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	user_ID, err := p.getLinkOwner(ctx, tx, userID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `DELETE FROM link_tags
+	WHERE link_id = $1 AND tag_id IN (SELECT id FROM tags WHERE user_id = $2 AND name = ANY($3))`
+	if _, err = tx.ExecContext(ctx, q, linkID, user_ID, pq.Array(tags)); err != nil {
+		return nil, wrap.E(pkg, "failed to RemoveTags(), q="+q, err)
+	}
+
+	// tags without links are not kept
+	q = `DELETE FROM tags t
+	WHERE t.user_id = $1 AND t.name = ANY($2)
+		AND NOT EXISTS (SELECT 1 FROM link_tags lt WHERE lt.tag_id = t.id)`
+	if _, err = tx.ExecContext(ctx, q, user_ID, pq.Array(tags)); err != nil {
+		return nil, wrap.E(pkg, "failed to RemoveTags(), q="+q, err)
+	}
+
+	linkTags, err := p.getLinkTags(ctx, tx, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return linkTags, nil
+}
+
+// getLinkOwner returns the internal id of the user if the link is theirs.
+func (p *Postgres) getLinkOwner(ctx context.Context, tx *sql.Tx, userID int64, linkID int) (int, error) {
+	var user_ID int
+	q := `SELECT l.user_id FROM links l JOIN users u ON u.id = l.user_id
+	WHERE l.id = $1 AND u.telegram_user_id = $2`
+	err := tx.QueryRowContext(ctx, q, linkID, userID).Scan(&user_ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, wrap.E(pkg, "link of the user not found", storage.ErrLinksNotFound)
+		}
+		return -1, wrap.E(pkg, "failed to getLinkOwner(), q="+q, err)
+	}
+
+	return user_ID, nil
+}
+
+func (p *Postgres) getLinkTags(ctx context.Context, tx *sql.Tx, linkID int) ([]string, error) {
+	q := `SELECT t.name FROM tags t JOIN link_tags lt ON lt.tag_id = t.id
+	WHERE lt.link_id = $1 ORDER BY t.name`
+	rows, err := tx.QueryContext(ctx, q, linkID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to getLinkTags(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		tags = append(tags, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return tags, nil
+}
+
+func (p *Postgres) GetLinksByTags(ctx context.Context, userID int64, tags []string, matchAll bool) ([]*gen.Link, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	// a link matches all tags when each of them joined
+	need := 1
+	if matchAll {
+		need = len(tags)
+	}
+
+	q := `SELECT l.id, l.original_url, l.description
+	FROM links l
+	JOIN link_tags lt ON lt.link_id = l.id
+	JOIN tags t ON t.id = lt.tag_id
+	WHERE l.user_id = $1 AND t.name = ANY($2)
+	GROUP BY l.id, l.original_url, l.description
+	HAVING COUNT(*) >= $3
+	ORDER BY l.id`
+	rows, err := p.db.QueryContext(ctx, q, user_ID, pq.Array(tags), need)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetLinksByTags(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var links []*gen.Link
+	for rows.Next() {
+		var l gen.Link
+		if err := rows.Scan(&l.LinkId, &l.OriginalUrl, &l.Description); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		links = append(links, &l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return links, nil
+}
+
+func (p *Postgres) GetTagCounts(ctx context.Context, userID int64) ([]*gen.TagCount, error) {
+	user_ID, err := p.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	q := `SELECT t.name, COUNT(*) AS count
+	FROM tags t JOIN link_tags lt ON lt.tag_id = t.id
+	WHERE t.user_id = $1
+	GROUP BY t.name
+	ORDER BY count DESC, t.name`
+	rows, err := p.db.QueryContext(ctx, q, user_ID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetTagCounts(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var counts []*gen.TagCount
+	for rows.Next() {
+		var c gen.TagCount
+		if err := rows.Scan(&c.Name, &c.Count); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		counts = append(counts, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// SearchLimit caps the number of results returned by SearchLinks.
+const SearchLimit = 50
+
+const (
+	snippetBefore = 80
+	snippetAfter  = 160
+
+	// snippets are html, the page text escaped and the terms in StartSel
+	// and StopSel
+	StartSel = "<b>"
+	StopSel  = "</b>"
+
+	// StartMark and StopMark wrap the terms until the snippet is escaped,
+	// control characters that are removed from the text first. Postgres
+	// uses them for ts_headline.
+	StartMark = "\x02"
+	StopMark  = "\x03"
+)
+
+var (
+	stripMarks = strings.NewReplacer(StartMark, "", StopMark, "")
+	markupSels = strings.NewReplacer(StartMark, StartSel, StopMark, StopSel)
+)
+
+// Search matches whole words regardless of case, a link is found when
+// every term of the query is a word of its description, title or text.
+// Postgres does the same with a 'simple' tsvector, the functions below
+// are used by drivers that have no full-text search of their own.
+
+// SearchTerms splits a search query into lowercase words.
+func SearchTerms(query string) []string {
+	return words(query)
+}
+
+// Rank returns how often the terms occur in the description and the page
+// text, description hits weigh more. Zero means some term does not occur.
+func Rank(terms []string, desc, text string) float32 {
+	descWords, textWords := countWords(desc), countWords(text)
+
+	var rank float32
+	for _, term := range terms {
+		n := 4*descWords[term] + textWords[term]
+		if n == 0 {
+			return 0
+		}
+		rank += float32(n)
+	}
+
+	return rank
+}
+
+// Snippet returns the fragment of text around the first occurrence of
+// any term as html, with every occurrence wrapped in StartSel and StopSel.
+func Snippet(text string, terms []string) string {
+	runes := []rune(stripMarks.Replace(text))
+
+	isTerm := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		isTerm[term] = true
+	}
+	// matches are the word spans of runes that are terms
+	var matches [][2]int
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		if isTerm[strings.ToLower(string(runes[i:j]))] {
+			matches = append(matches, [2]int{i, j})
+		}
+		i = j
+	}
+
+	first := 0
+	if len(matches) > 0 {
+		first = matches[0][0]
+	}
+
+	start := max(first-snippetBefore, 0)
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	end := min(first+snippetAfter, len(runes))
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	i := start
+	for _, m := range matches {
+		if m[0] < start || m[1] > end {
+			continue
+		}
+		b.WriteString(string(runes[i:m[0]]))
+		b.WriteString(StartMark)
+		b.WriteString(string(runes[m[0]:m[1]]))
+		b.WriteString(StopMark)
+		i = m[1]
+	}
+	b.WriteString(string(runes[i:end]))
+
+	return SnippetHTML(strings.Join(strings.Fields(b.String()), " "))
+}
+
+// SnippetHTML escapes a snippet with the terms wrapped in StartMark and
+// StopMark and wraps them in StartSel and StopSel instead.
+func SnippetHTML(marked string) string {
+	return markupSels.Replace(html.EscapeString(marked))
+}
+
+// words splits s into lowercase words of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+func countWords(s string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range words(s) {
+		counts[w]++
+	}
+	return counts
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (s *SQLite) ClaimLinkChecks(ctx context.Context, interval time.Duration, limit int) ([]*models.LinkCheck, error) {
+	// NULLs sort first, so links never checked come first
+	q := `UPDATE links SET checked_at = CURRENT_TIMESTAMP
+	WHERE id IN (
+		SELECT id FROM links
+		WHERE checked_at IS NULL OR checked_at <= datetime('now', ?)
+		ORDER BY checked_at, id
+		LIMIT ?
+	)
+	RETURNING id, original_url, description, check_status, check_failures`
+	rows, err := s.db.QueryContext(ctx, q, offset(-interval), limit)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to ClaimLinkChecks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var checks []*models.LinkCheck
+	for rows.Next() {
+		var c models.LinkCheck
+		if err := rows.Scan(&c.LinkID, &c.OriginalURL, &c.Description, &c.Status, &c.Failures); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		checks = append(checks, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return checks, nil
+}
+
+func (s *SQLite) RecordLinkCheck(ctx context.Context, c *models.LinkCheck) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `UPDATE links SET check_status = ?, check_failures = ?, checked_at = CURRENT_TIMESTAMP,
+		last_ok_at = CASE WHEN ? BETWEEN 200 AND 299 THEN CURRENT_TIMESTAMP ELSE last_ok_at END
+	WHERE id = ?`
+	res, err := tx.ExecContext(ctx, q, c.Status, c.Failures, c.StatusCode, c.LinkID)
+	if err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	} else if n == 0 {
+		return wrap.E(pkg, "failed to RecordLinkCheck()", storage.ErrLinksNotFound)
+	}
+
+	var id int64
+	q = `INSERT INTO link_checks (link_id, status, status_code, location, error)
+	VALUES (?, ?, ?, ?, ?) RETURNING id`
+	err = tx.QueryRowContext(ctx, q, c.LinkID, c.Status, c.StatusCode, c.Location, c.Error).Scan(&id)
+	if err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+
+	q = `SELECT checked_at FROM link_checks WHERE id = ?`
+	if err = tx.QueryRowContext(ctx, q, id).Scan(&c.CheckedAt); err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+
+	q = `DELETE FROM link_checks WHERE link_id = ? AND id NOT IN (
+		SELECT id FROM link_checks WHERE link_id = ? ORDER BY id DESC LIMIT ?
+	)`
+	if _, err = tx.ExecContext(ctx, q, c.LinkID, c.LinkID, storage.LinkCheckHistory); err != nil {
+		return wrap.E(pkg, "failed to RecordLinkCheck(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (s *SQLite) GetBrokenLinks(ctx context.Context, userID int64) ([]*models.LinkCheck, error) {
+	q := `SELECT l.id, l.original_url, l.description, l.check_status, l.check_failures, l.last_ok_at,
+		c.status_code, c.location, c.error, c.checked_at
+	FROM links l
+	JOIN users u ON u.id = l.user_id
+	JOIN link_checks c ON c.id = (SELECT MAX(id) FROM link_checks WHERE link_id = l.id)
+	WHERE u.telegram_user_id = ? AND l.check_status IN (?, ?)
+	ORDER BY l.id`
+	rows, err := s.db.QueryContext(ctx, q, userID, models.LinkStatusDead, models.LinkStatusMoved)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetBrokenLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var checks []*models.LinkCheck
+	for rows.Next() {
+		var c models.LinkCheck
+		var lastOK sql.NullTime
+		if err := rows.Scan(&c.LinkID, &c.OriginalURL, &c.Description, &c.Status, &c.Failures, &lastOK,
+			&c.StatusCode, &c.Location, &c.Error, &c.CheckedAt); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		c.LastOKAt = lastOK.Time
+		checks = append(checks, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return checks, nil
+}
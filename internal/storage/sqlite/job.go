@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (s *SQLite) EnqueueCapture(ctx context.Context, j *models.CaptureJob) (int64, error) {
+	userID, err := s.getOrCreateUserID(ctx, j.UserID)
+	if err != nil {
+		return -1, err
+	}
+
+	return insertCaptureJob(ctx, s.db, userID, j)
+}
+
+// rowQuerier is either the database or a transaction.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertCaptureJob(ctx context.Context, db rowQuerier, userID int, j *models.CaptureJob) (int64, error) {
+	var id int64
+	q := `INSERT INTO capture_jobs (user_id, original_url, description, link_id, recapture,
+		import_id, date_added, tags, collection_id)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	err := db.QueryRowContext(ctx, q, userID, j.OriginalURL, j.Description, nullID(j.LinkID), j.Recapture,
+		nullJobID(j.ImportID), nullTime(j.DateAdded), storage.JoinTags(j.Tags), nullID(j.CollectionID)).Scan(&id)
+	if err != nil {
+		return -1, wrap.E(pkg, "failed to insert capture job, q="+q, err)
+	}
+
+	return id, nil
+}
+
+func nullJobID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// scanCaptureJob reads the columns of a capture job in table order,
+// the user column is scanned into user.
+func scanCaptureJob(row *sql.Row, user any) (*models.CaptureJob, error) {
+	var j models.CaptureJob
+	var dateAdded sql.NullTime
+	var tags string
+	err := row.Scan(&j.ID, user, &j.OriginalURL, &j.Description, &j.Status, &j.Attempts, &j.Error,
+		&j.LinkID, &j.Recapture, &j.ImportID, &dateAdded, &tags, &j.CollectionID, &j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	j.DateAdded = dateAdded.Time
+	j.Tags = storage.SplitTags(tags)
+
+	return &j, nil
+}
+
+// offset returns a sqlite datetime modifier for d.
+func offset(d time.Duration) string {
+	return fmt.Sprintf("%+.3f seconds", d.Seconds())
+}
+
+func (s *SQLite) ClaimCaptureJob(ctx context.Context, lease time.Duration, maxAttempts int) (*models.CaptureJob, error) {
+	// running jobs that are due lost their worker, on the last attempt they fail
+	q := `UPDATE capture_jobs SET status = 'failed', error = ?, updated_at = CURRENT_TIMESTAMP
+	WHERE status = 'running' AND run_at <= datetime('now') AND attempts >= ?`
+	if _, err := s.db.ExecContext(ctx, q, storage.JobLeaseExpired, maxAttempts); err != nil {
+		return nil, wrap.E(pkg, "failed to fail expired capture jobs, q="+q, err)
+	}
+
+	// there is a single connection, so the update is never raced
+	q = `UPDATE capture_jobs
+	SET status = 'running', attempts = attempts + 1,
+		run_at = datetime('now', ?), updated_at = CURRENT_TIMESTAMP
+	WHERE id = (
+		SELECT id FROM capture_jobs
+		WHERE (status = 'pending' OR status = 'running' AND attempts < ?) AND run_at <= datetime('now')
+		ORDER BY run_at, id
+		LIMIT 1
+	)
+	RETURNING id, user_id, original_url, description, status, attempts, error,
+		COALESCE(link_id, 0), recapture, COALESCE(import_id, 0), date_added, tags,
+		COALESCE(collection_id, 0), created_at`
+
+	var userID int
+	j, err := scanCaptureJob(s.db.QueryRowContext(ctx, q, offset(lease), maxAttempts), &userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrNoJobs
+		}
+		return nil, wrap.E(pkg, "failed to ClaimCaptureJob(), q="+q, err)
+	}
+
+	j.UserID, err = s.GetTelegramIDByID(ctx, nil, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetTelegramIDByID()", err)
+	}
+
+	return j, nil
+}
+
+// the attempt of a claim is its lease token, a job claimed again has more
+const leaseHeld = `id = ? AND attempts = ? AND status = 'running'`
+
+func (s *SQLite) CompleteCaptureJob(ctx context.Context, id int64, attempt int, linkID int) error {
+	q := `UPDATE capture_jobs SET status = 'done', error = '', link_id = ?, updated_at = CURRENT_TIMESTAMP WHERE ` + leaseHeld
+	return s.updateCaptureJob(ctx, q, id, attempt, linkID)
+}
+
+func (s *SQLite) RetryCaptureJob(ctx context.Context, id int64, attempt int, after time.Duration, reason string) error {
+	q := `UPDATE capture_jobs SET status = 'pending', error = ?,
+		run_at = datetime('now', ?), updated_at = CURRENT_TIMESTAMP WHERE ` + leaseHeld
+	return s.updateCaptureJob(ctx, q, id, attempt, reason, offset(after))
+}
+
+func (s *SQLite) FailCaptureJob(ctx context.Context, id int64, attempt int, reason string) error {
+	q := `UPDATE capture_jobs SET status = 'failed', error = ?, updated_at = CURRENT_TIMESTAMP WHERE ` + leaseHeld
+	return s.updateCaptureJob(ctx, q, id, attempt, reason)
+}
+
+// updateCaptureJob runs q with the values it sets in args, followed by
+// the job id and the attempt of the claim.
+func (s *SQLite) updateCaptureJob(ctx context.Context, q string, id int64, attempt int, args ...any) error {
+	res, err := s.db.ExecContext(ctx, q, append(args, id, attempt)...)
+	if err != nil {
+		return wrap.E(pkg, "failed to update capture job, q="+q, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n == 0 {
+		var exists bool
+		err = s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM capture_jobs WHERE id = ?)`, id).Scan(&exists)
+		if err != nil {
+			return wrap.E(pkg, "failed to check capture job", err)
+		}
+		if !exists {
+			return storage.ErrJobNotFound
+		}
+		return storage.ErrJobLeaseLost
+	}
+
+	return nil
+}
+
+func (s *SQLite) GetCaptureJob(ctx context.Context, id int64) (*models.CaptureJob, error) {
+	q := `SELECT j.id, u.telegram_user_id, j.original_url, j.description, j.status,
+		j.attempts, j.error, COALESCE(j.link_id, 0), j.recapture, COALESCE(j.import_id, 0),
+		j.date_added, j.tags, COALESCE(j.collection_id, 0), j.created_at
+	FROM capture_jobs j JOIN users u ON u.id = j.user_id
+	WHERE j.id = ?`
+
+	var userID int64
+	j, err := scanCaptureJob(s.db.QueryRowContext(ctx, q, id), &userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrJobNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetCaptureJob(), q="+q, err)
+	}
+	j.UserID = userID
+
+	return j, nil
+}
@@ -0,0 +1,351 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+	"github.com/mattn/go-sqlite3"
+)
+
+func (s *SQLite) SaveLink(ctx context.Context, l *models.Link) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	id, err := s.GetUserIDByTelegramID(ctx, tx, l.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			u := &models.User{
+				UserID: l.UserID,
+			}
+			id, err = s.SaveUser(ctx, tx, u)
+			if err != nil {
+				return wrap.E(pkg, "failed to SaveLink(), SaveUser()", err)
+			}
+		} else {
+			return wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+		}
+	}
+
+	// imported links keep the date they were bookmarked
+	q := `INSERT INTO links (original_url, user_id, description, content_text, title, byline, lead_image, word_count, article, domain, date_added)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP)) RETURNING id`
+	err = tx.QueryRowContext(ctx, q, l.OriginalURL, id, l.Description, l.ContentText,
+		l.Title, l.Byline, l.LeadImage, l.WordCount, l.Article, storage.Domain(l.OriginalURL), nullTime(l.DateAdded)).Scan(&l.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return wrap.E(pkg, "failed to SaveLink(), "+err.Error(), storage.ErrLinkExists)
+		}
+		return wrap.E(pkg, "failed to SaveLink(), q="+q, err)
+	}
+
+	err = s.insertSnapshot(ctx, tx, l.ID, &models.Snapshot{
+		Content:     l.Content,
+		ContentText: l.ContentText,
+		Title:       l.Title,
+		Byline:      l.Byline,
+		LeadImage:   l.LeadImage,
+		WordCount:   l.WordCount,
+		Article:     l.Article,
+		WARC:        l.WARC,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+// getOrCreateUserID mirrors the postgres driver, which registers
+// unknown telegram users on their first read.
+func (s *SQLite) getOrCreateUserID(ctx context.Context, userID int64) (int, error) {
+	id, err := s.GetUserIDByTelegramID(ctx, nil, userID)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, storage.ErrUserNotFound) {
+		return -1, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	id, err = s.SaveUser(ctx, nil, &models.User{UserID: userID})
+	if err != nil {
+		return -1, wrap.E(pkg, "failed to SaveUser()", err)
+	}
+
+	return id, nil
+}
+
+func (s *SQLite) GetUserLinks(ctx context.Context, userID int64, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	id, err := s.getOrCreateUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	links, next, err := s.listLinks(ctx, `user_id = ?`, []any{id}, page)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to GetUserLinks()", err)
+	}
+
+	return links, next, nil
+}
+
+func (s *SQLite) GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) ([]byte, error) {
+	r, err := s.OpenContentByTelegramIDOriginalURL(ctx, userID, originalURL, at)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to read content", err)
+	}
+
+	return content, nil
+}
+
+func (s *SQLite) OpenContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	id, err := s.getOrCreateUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var encoding string
+	var key sql.NullString
+	var data []byte
+	q := `SELECT b.encoding, b.key, b.data FROM links l
+	JOIN link_snapshots s ON s.link_id = l.id
+	JOIN blobs b ON b.hash = s.content_hash
+	WHERE l.user_id = ? AND l.original_url = ? AND (? IS NULL OR s.captured_at < ?)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = s.db.QueryRowContext(ctx, q, id, originalURL, nullTime(at), nullTime(at)).Scan(&encoding, &key, &data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetContent()", storage.ErrSnapshotNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to GetContent(), q="+q, err)
+	}
+
+	return s.openBlob(ctx, encoding, key, data)
+}
+
+func (s *SQLite) OpenWARCByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error) {
+	id, err := s.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	var encoding, key sql.NullString
+	var data []byte
+	// the snapshot is picked as for the page, whether it has a WARC file or not
+	q := `SELECT b.encoding, b.key, b.data FROM links l
+	JOIN link_snapshots s ON s.link_id = l.id
+	LEFT JOIN blobs b ON b.hash = s.warc_hash
+	WHERE l.user_id = ? AND l.original_url = ? AND (? IS NULL OR s.captured_at < ?)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = s.db.QueryRowContext(ctx, q, id, originalURL, nullTime(at), nullTime(at)).Scan(&encoding, &key, &data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetWARC()", storage.ErrSnapshotNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to GetWARC(), q="+q, err)
+	}
+	if !encoding.Valid {
+		return nil, wrap.E(pkg, "failed to GetWARC()", storage.ErrWARCNotFound)
+	}
+
+	return s.openBlob(ctx, encoding.String, key, data)
+}
+
+func (s *SQLite) GetArticleByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (*models.Link, error) {
+	id, err := s.GetUserIDByTelegramID(ctx, nil, userID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetUserIDByTelegramID()", err)
+	}
+
+	l := models.Link{UserID: userID}
+
+	q := `SELECT l.id, l.original_url, l.description, s.title, s.byline, s.lead_image, s.word_count, s.article,
+		l.date_added, s.captured_at
+	FROM links l JOIN link_snapshots s ON s.link_id = l.id
+	WHERE l.user_id = ? AND l.original_url = ? AND (? IS NULL OR s.captured_at < ?)
+	ORDER BY s.captured_at DESC, s.id DESC
+	LIMIT 1`
+	err = s.db.QueryRowContext(ctx, q, id, originalURL, nullTime(at), nullTime(at)).Scan(&l.ID, &l.OriginalURL, &l.Description,
+		&l.Title, &l.Byline, &l.LeadImage, &l.WordCount, &l.Article, &l.DateAdded, &l.CapturedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, wrap.E(pkg, "failed to GetArticle()", storage.ErrSnapshotNotFound)
+		}
+		return nil, wrap.E(pkg, "failed to GetArticle(), q="+q, err)
+	}
+
+	return &l, nil
+}
+
+func (s *SQLite) GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	id, err := s.getOrCreateUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// instr keeps the match case-sensitive like LIKE in postgres
+	links, next, err := s.listLinks(ctx, `user_id = ? AND instr(description, ?) > 0`, []any{id, desc}, page)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to GetLinks()", err)
+	}
+
+	return links, next, nil
+}
+
+func (s *SQLite) GetLinkByID(ctx context.Context, id int) (*models.Link, error) {
+	q := `SELECT id, original_url, user_id, description FROM links WHERE id = ?`
+
+	l := models.Link{}
+	var user_ID int
+
+	err := s.db.QueryRowContext(ctx, q, id).Scan(&l.ID, &l.OriginalURL, &user_ID, &l.Description)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetLinkByID()", err)
+	}
+
+	l.UserID, err = s.GetTelegramIDByID(ctx, nil, user_ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, wrap.E(pkg, "unusual behavior, link exists, user not exists", err)
+		}
+		return nil, wrap.E(pkg, "failed to GetTelegramIDByID()", err)
+	}
+
+	return &l, nil
+}
+
+func (s *SQLite) DeleteLink(ctx context.Context, id int) (string, int64, error) {
+	var originalURL string
+	var userID int
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", -1, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	refs, err := s.linkBlobs(ctx, tx, id)
+	if err != nil {
+		return "", -1, err
+	}
+
+	q := `DELETE FROM links WHERE id = ? RETURNING original_url, user_id`
+	err = tx.QueryRowContext(ctx, q, id).Scan(&originalURL, &userID)
+	if err != nil {
+		return "", -1, wrap.E(pkg, "failed to DeleteLink()", err)
+	}
+
+	// the snapshots are gone with the link, their blobs may be too
+	keys, err := s.releaseBlobs(ctx, tx, refs)
+	if err != nil {
+		return "", -1, err
+	}
+
+	telegramUserID, err := s.GetTelegramIDByID(ctx, tx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return "", -1, wrap.E(pkg, "unusual behavior, link deleted, user not exists", err)
+		}
+		return "", -1, wrap.E(pkg, "failed to GetTelegramIDByID()", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", -1, wrap.E(pkg, "failed to Commit()", err)
+	}
+	s.deleteBlobs(ctx, keys)
+
+	return originalURL, telegramUserID, nil
+}
+
+// SearchLinks has no full-text index behind it: rows containing every
+// term are ranked and highlighted in go.
+func (s *SQLite) SearchLinks(ctx context.Context, userID int64, query string) ([]*gen.SearchResult, error) {
+	id, err := s.getOrCreateUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := storage.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	q := `SELECT id, original_url, description, title, content_text FROM links WHERE user_id = ?`
+	args := []any{id}
+	for _, term := range terms {
+		// substrings narrow down the rows, whole words are matched in go.
+		// sqlite lower() folds ascii only, other terms are matched in go alone
+		if !isASCII(term) {
+			continue
+		}
+		q += ` AND (instr(lower(description), ?) > 0 OR instr(lower(title), ?) > 0 OR instr(lower(content_text), ?) > 0)`
+		args = append(args, term, term, term)
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to SearchLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var results []*gen.SearchResult
+	for rows.Next() {
+		var title, text string
+		r := gen.SearchResult{Link: &gen.Link{}}
+		if err := rows.Scan(&r.Link.LinkId, &r.Link.OriginalUrl, &r.Link.Description, &title, &text); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+
+		r.Rank = storage.Rank(terms, r.Link.Description+" "+title, text)
+		if r.Rank == 0 {
+			continue
+		}
+		r.Snippet = storage.Snippet(text, terms)
+		results = append(results, &r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+	if len(results) > storage.SearchLimit {
+		results = results[:storage.SearchLimit]
+	}
+
+	return results, nil
+}
+
+func isASCII(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r > 127 }) < 0
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
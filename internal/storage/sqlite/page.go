@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+// sortColumn returns the column links are ordered by and whether the order is descending.
+func sortColumn(sort storage.Sort) (string, bool) {
+	switch sort {
+	case storage.SortOldest:
+		return "date_added", false
+	case storage.SortTitle:
+		return "title", false
+	case storage.SortDomain:
+		return "domain", false
+	default:
+		return "date_added", true
+	}
+}
+
+// sqliteTime is the format of CURRENT_TIMESTAMP, date keys are compared as text.
+const sqliteTime = "2006-01-02 15:04:05"
+
+// listLinks returns a page of links matching where with args.
+func (s *SQLite) listLinks(ctx context.Context, where string, args []any, page storage.Page) ([]*gen.Link, *storage.Cursor, error) {
+	col, desc := sortColumn(page.Sort)
+	order, cmp := "ASC", ">"
+	if desc {
+		order, cmp = "DESC", "<"
+	}
+
+	q := `SELECT id, original_url, description, date_added, title, domain FROM links WHERE ` + where
+	if page.After != nil {
+		args = append(args, page.After.Key, page.After.ID)
+		q += fmt.Sprintf(` AND (%s, id) %s (?, ?)`, col, cmp)
+	}
+	// one more row tells whether there is a next page
+	args = append(args, page.Size()+1)
+	q += fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT ?`, col, order, order)
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, nil, wrap.E(pkg, "failed to listLinks(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var links []*gen.Link
+	var keys []string
+	for rows.Next() {
+		var l gen.Link
+		var dateAdded time.Time
+		var title, domain string
+		if err := rows.Scan(&l.LinkId, &l.OriginalUrl, &l.Description, &dateAdded, &title, &domain); err != nil {
+			return nil, nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		links = append(links, &l)
+
+		switch col {
+		case "title":
+			keys = append(keys, title)
+		case "domain":
+			keys = append(keys, domain)
+		default:
+			keys = append(keys, dateAdded.UTC().Format(sqliteTime))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	if len(links) <= page.Size() {
+		return links, nil, nil
+	}
+
+	links = links[:page.Size()]
+	last := len(links) - 1
+	return links, &storage.Cursor{Sort: page.Sort, Key: keys[last], ID: int(links[last].LinkId)}, nil
+}
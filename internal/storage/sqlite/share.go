@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (s *SQLite) CreateShare(ctx context.Context, userID int64, sh *models.Share) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	q := `SELECT l.original_url FROM links l JOIN users u ON u.id = l.user_id
+	WHERE l.id = ? AND u.telegram_user_id = ?`
+	err = tx.QueryRowContext(ctx, q, sh.LinkID, userID).Scan(&sh.OriginalURL)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return wrap.E(pkg, "link of the user not found", storage.ErrLinksNotFound)
+		}
+		return wrap.E(pkg, "failed to CreateShare(), q="+q, err)
+	}
+
+	q = `INSERT INTO shares (link_id, expires_at, one_time, password_hash)
+	VALUES (?, ?, ?, ?) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, q, sh.LinkID, nullTime(sh.ExpiresAt), sh.OneTime, sh.PasswordHash).
+		Scan(&sh.ID, &sh.CreatedAt)
+	if err != nil {
+		return wrap.E(pkg, "failed to CreateShare(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+	sh.UserID = userID
+
+	return nil
+}
+
+func (s *SQLite) GetShare(ctx context.Context, id int64) (*models.Share, error) {
+	q := `SELECT s.id, s.link_id, u.telegram_user_id, l.original_url, s.expires_at, s.revoked_at, s.created_at,
+		s.one_time, s.used_at, s.password_hash
+	FROM shares s
+	JOIN links l ON l.id = s.link_id
+	JOIN users u ON u.id = l.user_id
+	WHERE s.id = ?`
+
+	var sh models.Share
+	var expiresAt, revokedAt, usedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, q, id).Scan(&sh.ID, &sh.LinkID, &sh.UserID, &sh.OriginalURL,
+		&expiresAt, &revokedAt, &sh.CreatedAt, &sh.OneTime, &usedAt, &sh.PasswordHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrShareNotFound
+		}
+		return nil, wrap.E(pkg, "failed to GetShare(), q="+q, err)
+	}
+	sh.ExpiresAt = expiresAt.Time
+	sh.RevokedAt = revokedAt.Time
+	sh.UsedAt = usedAt.Time
+
+	return &sh, nil
+}
+
+func (s *SQLite) RevokeShares(ctx context.Context, userID int64, linkID int, shareID int64) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := s.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return 0, err
+	}
+
+	q := `UPDATE shares SET revoked_at = CURRENT_TIMESTAMP
+	WHERE link_id = ? AND revoked_at IS NULL AND (? = 0 OR id = ?)`
+	res, err := tx.ExecContext(ctx, q, linkID, shareID, shareID)
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to RevokeShares(), q="+q, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return int(n), nil
+}
+
+func (s *SQLite) UseShare(ctx context.Context, id int64) error {
+	q := `UPDATE shares SET used_at = CURRENT_TIMESTAMP WHERE id = ? AND used_at IS NULL`
+	res, err := s.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return wrap.E(pkg, "failed to UseShare(), q="+q, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return wrap.E(pkg, "failed to RowsAffected()", err)
+	}
+	if n == 0 {
+		return storage.ErrShareUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+func (s *SQLite) AddSnapshot(ctx context.Context, userID int64, linkID int, snap *models.Snapshot) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = s.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return err
+	}
+
+	if err = s.insertSnapshot(ctx, tx, linkID, snap); err != nil {
+		return err
+	}
+
+	// a back-dated snapshot leaves the link as it is
+	q := `UPDATE links SET (content_text, title, byline, lead_image, word_count, article) = (
+		SELECT content_text, title, byline, lead_image, word_count, article FROM link_snapshots WHERE id = ?
+	)
+	WHERE id = ? AND ? = (
+		SELECT id FROM link_snapshots WHERE link_id = ?
+		ORDER BY captured_at DESC, id DESC
+		LIMIT 1
+	)`
+	if _, err = tx.ExecContext(ctx, q, snap.ID, linkID, snap.ID, linkID); err != nil {
+		return wrap.E(pkg, "failed to AddSnapshot(), q="+q, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrap.E(pkg, "failed to Commit()", err)
+	}
+
+	return nil
+}
+
+func (s *SQLite) insertSnapshot(ctx context.Context, tx *sql.Tx, linkID int, snap *models.Snapshot) error {
+	hash, err := s.putBlob(ctx, tx, snap.Content)
+	if err != nil {
+		return err
+	}
+
+	var warcHash any
+	if len(snap.WARC) > 0 {
+		if warcHash, err = s.putBlob(ctx, tx, snap.WARC); err != nil {
+			return err
+		}
+	}
+
+	q := `INSERT INTO link_snapshots (link_id, content_hash, warc_hash, content_text, title, byline, lead_image, word_count, article, captured_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP)) RETURNING id`
+	err = tx.QueryRowContext(ctx, q, linkID, hash, warcHash, snap.ContentText, snap.Title, snap.Byline,
+		snap.LeadImage, snap.WordCount, snap.Article, nullTime(snap.CapturedAt)).Scan(&snap.ID)
+	if err != nil {
+		return wrap.E(pkg, "failed to insertSnapshot(), q="+q, err)
+	}
+
+	q = `SELECT captured_at FROM link_snapshots WHERE id = ?`
+	if err = tx.QueryRowContext(ctx, q, snap.ID).Scan(&snap.CapturedAt); err != nil {
+		return wrap.E(pkg, "failed to insertSnapshot(), q="+q, err)
+	}
+	snap.LinkID = linkID
+
+	return nil
+}
+
+func (s *SQLite) GetSnapshots(ctx context.Context, userID int64, linkID int) ([]*models.Snapshot, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to BeginTx()", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = s.getLinkOwner(ctx, tx, userID, linkID); err != nil {
+		return nil, err
+	}
+
+	q := `SELECT id, title, byline, lead_image, word_count, captured_at FROM link_snapshots
+	WHERE link_id = ? ORDER BY captured_at DESC, id DESC`
+	rows, err := tx.QueryContext(ctx, q, linkID)
+	if err != nil {
+		return nil, wrap.E(pkg, "failed to GetSnapshots(), q="+q, err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.Snapshot
+	for rows.Next() {
+		snap := models.Snapshot{LinkID: linkID}
+		if err := rows.Scan(&snap.ID, &snap.Title, &snap.Byline, &snap.LeadImage, &snap.WordCount, &snap.CapturedAt); err != nil {
+			return nil, wrap.E(pkg, "failed to Scan()", err)
+		}
+		snapshots = append(snapshots, &snap)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrap.E(pkg, "error in rows.Err()", err)
+	}
+
+	return snapshots, nil
+}
+
+// nullTime is NULL for the zero time, so the column default applies,
+// other times are formatted like CURRENT_TIMESTAMP to compare as text.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(sqliteTime)
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+	"github.com/0x0FACED/link-saver-api/migrations"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var pkg = "storage/sqlite"
+
+type SQLite struct {
+	db     *sql.DB
+	config config.DatabaseConfig
+	// blobs is nil when content is kept in the blobs table
+	blobs storage.BlobStore
+}
+
+func New(cfg config.DatabaseConfig, blobs storage.BlobStore) *SQLite {
+	return &SQLite{
+		config: cfg,
+		blobs:  blobs,
+	}
+}
+
+func (s *SQLite) Connect() error {
+	db, err := sql.Open("sqlite3", s.getConnStr())
+	if err != nil {
+		return wrap.E(pkg, "failed to Open()", err)
+	}
+
+	if err = db.Ping(); err != nil {
+		return wrap.E(pkg, "failed to Ping()", err)
+	}
+
+	// sqlite allows only one writer at a time
+	db.SetMaxOpenConns(1)
+
+	s.db = db
+
+	err = migrations.UpSQLite("sqlite3://"+s.getConnStr(), migrations.Step{
+		Version: contentBlobsVersion,
+		Run: func() error {
+			return s.convertContent(context.Background())
+		},
+	})
+	if err != nil {
+		return wrap.E(pkg, "failed to Up()", err)
+	}
+
+	if err = s.moveBlobs(context.Background()); err != nil {
+		return wrap.E(pkg, "failed to moveBlobs()", err)
+	}
+
+	return nil
+}
+
+func (s *SQLite) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s SQLite) getConnStr() string {
+	path := s.config.Path
+	if path == "" {
+		path = "link_saver.db"
+	}
+	return path + "?_foreign_keys=on&_busy_timeout=5000"
+}
@@ -0,0 +1,271 @@
+package sqlite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/config"
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/link-saver-api/internal/storage/blobstore"
+	"github.com/0x0FACED/link-saver-api/internal/storage/storagetest"
+	"github.com/0x0FACED/link-saver-api/migrations"
+)
+
+func TestMain(m *testing.M) {
+	// migrations are looked up relative to the module root
+	if err := os.Chdir("../../.."); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func newTestDB(t *testing.T, path string, blobs storage.BlobStore) *SQLite {
+	t.Helper()
+
+	db := New(config.DatabaseConfig{Path: path}, blobs)
+	if err := db.Connect(); err != nil {
+		t.Fatalf("Connect(): %v", err)
+	}
+	t.Cleanup(func() { db.db.Close() })
+	return db
+}
+
+func newFS(t *testing.T) *blobstore.FS {
+	t.Helper()
+
+	fs, err := blobstore.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS(): %v", err)
+	}
+	return fs
+}
+
+func TestSQLite(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Database {
+		return newTestDB(t, filepath.Join(t.TempDir(), "test.db"), nil)
+	})
+}
+
+func TestSQLiteBlobStore(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Database {
+		return newTestDB(t, filepath.Join(t.TempDir(), "test.db"), newFS(t))
+	})
+}
+
+func blobRefs(t *testing.T, db *SQLite) map[string]int {
+	t.Helper()
+
+	rows, err := db.db.Query(`SELECT encoding, refs FROM blobs`)
+	if err != nil {
+		t.Fatalf("failed to query blobs: %v", err)
+	}
+	defer rows.Close()
+
+	refs := make(map[string]int)
+	for rows.Next() {
+		var encoding string
+		var n int
+		if err := rows.Scan(&encoding, &n); err != nil {
+			t.Fatalf("failed to scan blobs: %v", err)
+		}
+		refs[encoding] += n
+	}
+	return refs
+}
+
+func TestBlobRefs(t *testing.T) {
+	db := newTestDB(t, filepath.Join(t.TempDir(), "test.db"), nil)
+	ctx := context.Background()
+	page := []byte(strings.Repeat("<p>compressible</p>", 100))
+
+	var ids []int
+	for _, userID := range []int64{1, 2} {
+		l := &models.Link{OriginalURL: "https://example.com", UserID: userID, Description: "example", Content: page}
+		if err := db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(%d): %v", userID, err)
+		}
+		ids = append(ids, l.ID)
+	}
+
+	if got := blobRefs(t, db); len(got) != 1 || got[storage.EncodingZstd] != 2 {
+		t.Errorf("blobs after saving the same page twice = %v, want one zstd blob with 2 refs", got)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, ids[0]); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if got := blobRefs(t, db); got[storage.EncodingZstd] != 1 {
+		t.Errorf("blobs after deleting one link = %v, want 1 ref", got)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, ids[1]); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if got := blobRefs(t, db); len(got) != 0 {
+		t.Errorf("blobs after deleting both links = %v, want none", got)
+	}
+}
+
+// TestGzipBlob checks that blobs compressed before zstd are still read.
+func TestGzipBlob(t *testing.T) {
+	db := newTestDB(t, filepath.Join(t.TempDir(), "test.db"), nil)
+	ctx := context.Background()
+	page := []byte(strings.Repeat("<p>compressed with gzip</p>", 100))
+
+	l := &models.Link{OriginalURL: "https://example.com", UserID: 1, Description: "example", Content: page}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write(page)
+	w.Close()
+	if _, err := db.db.Exec(`UPDATE blobs SET encoding = ?, data = ?`, storage.EncodingGzip, buf.Bytes()); err != nil {
+		t.Fatalf("failed to update blobs: %v", err)
+	}
+
+	content, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, "https://example.com", time.Time{})
+	if err != nil || !bytes.Equal(content, page) {
+		t.Errorf("GetContentByTelegramIDOriginalURL() of a gzip blob = %q, %v", content, err)
+	}
+}
+
+func TestWARCBlobRefs(t *testing.T) {
+	db := newTestDB(t, filepath.Join(t.TempDir(), "test.db"), nil)
+	ctx := context.Background()
+	archive := []byte("WARC/1.1")
+
+	l := &models.Link{OriginalURL: "https://example.com", UserID: 1, Description: "example", Content: []byte("<html></html>"), WARC: archive}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	// the same WARC file is stored once
+	if err := db.AddSnapshot(ctx, 1, l.ID, &models.Snapshot{Content: []byte("<html></html>"), WARC: archive}); err != nil {
+		t.Fatalf("AddSnapshot(): %v", err)
+	}
+	if got := blobRefs(t, db); got[storage.EncodingIdentity] != 4 {
+		t.Errorf("blobs of two snapshots with WARC files = %v, want 4 refs", got)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, l.ID); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if got := blobRefs(t, db); len(got) != 0 {
+		t.Errorf("blobs after deleting the link = %v, want none", got)
+	}
+}
+
+func TestConvertContent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	page := []byte(strings.Repeat("<p>saved before blobs</p>", 100))
+
+	// a database of a version that kept content in snapshots, the step
+	// stops the migrations before it is converted
+	raw, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open(): %v", err)
+	}
+	defer raw.Close()
+	stop := errors.New("stop")
+	err = migrations.UpSQLite("sqlite3://"+path, migrations.Step{
+		Version: contentBlobsVersion,
+		Run: func() error {
+			_, err := raw.Exec(`INSERT INTO users (telegram_user_id) VALUES (1), (2);
+				INSERT INTO links (original_url, user_id, description) SELECT 'https://example.com', id, 'example' FROM users`)
+			if err != nil {
+				t.Fatalf("failed to save links: %v", err)
+			}
+			if _, err := raw.Exec(`INSERT INTO link_snapshots (link_id, content) SELECT id, ? FROM links`, page); err != nil {
+				t.Fatalf("failed to save content: %v", err)
+			}
+			return stop
+		},
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("UpSQLite() err = %v, want the error of the step", err)
+	}
+
+	db := newTestDB(t, path, nil)
+
+	if got := blobRefs(t, db); len(got) != 1 || got[storage.EncodingZstd] != 2 {
+		t.Errorf("blobs after conversion = %v, want one zstd blob with 2 refs", got)
+	}
+	content, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, "https://example.com", time.Time{})
+	if err != nil || !bytes.Equal(content, page) {
+		t.Errorf("GetContentByTelegramIDOriginalURL() after conversion = %q, %v", content, err)
+	}
+
+	// the content column is gone once converted
+	if _, err := db.db.Exec(`SELECT content FROM link_snapshots`); err == nil {
+		t.Error("link_snapshots still has the content column")
+	}
+}
+
+func storedKeys(t *testing.T, db *SQLite) []string {
+	t.Helper()
+
+	var keys []string
+	rows, err := db.db.Query(`SELECT key FROM blobs WHERE key IS NOT NULL AND length(data) = 0`)
+	if err != nil {
+		t.Fatalf("failed to query blobs: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			t.Fatalf("failed to scan blobs: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestMoveBlobs(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	page := []byte(strings.Repeat("<p>kept in the database</p>", 100))
+
+	db := newTestDB(t, path, nil)
+	l := &models.Link{OriginalURL: "https://example.com", UserID: 1, Description: "example", Content: page}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	db.db.Close()
+
+	// connecting with a store moves the content there
+	fs := newFS(t)
+	db = newTestDB(t, path, fs)
+	keys := storedKeys(t, db)
+	if len(keys) != 1 {
+		t.Fatalf("blobs in the store after Connect() = %v, want 1", keys)
+	}
+
+	r, err := db.OpenContentByTelegramIDOriginalURL(ctx, 1, "https://example.com", time.Time{})
+	if err != nil {
+		t.Fatalf("OpenContentByTelegramIDOriginalURL(): %v", err)
+	}
+	content, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(content, page) {
+		t.Errorf("OpenContentByTelegramIDOriginalURL() = %q, %v", content, err)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, l.ID); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := fs.Open(ctx, keys[0]); !errors.Is(err, storage.ErrBlobNotFound) {
+		t.Errorf("blob of a deleted link is still in the store: %v", err)
+	}
+}
@@ -4,24 +4,46 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/0x0FACED/link-saver-api/internal/domain/models"
 	"github.com/0x0FACED/proto-files/link_service/gen"
 )
 
 var (
-	ErrConnectDB      = errors.New("err connect db")
-	ErrUserNotFound   = errors.New("user not found")
-	ErrLinksNotFound  = errors.New("links not found")
-	ErrNoRowsAffected = errors.New("no rows affected")
-	ErrBeginTx        = "Cant begin tx"
+	ErrConnectDB          = errors.New("err connect db")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrLinksNotFound      = errors.New("links not found")
+	ErrNoRowsAffected     = errors.New("no rows affected")
+	ErrLinkExists         = errors.New("link already exists")
+	ErrNoJobs             = errors.New("no capture jobs")
+	ErrJobNotFound        = errors.New("capture job not found")
+	ErrJobLeaseLost       = errors.New("capture job lease lost")
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrCollectionExists   = errors.New("collection already exists")
+	ErrSnapshotNotFound   = errors.New("snapshot not found")
+	ErrBlobNotFound       = errors.New("blob not found")
+	ErrImportNotFound     = errors.New("import not found")
+	ErrWARCNotFound       = errors.New("warc not found")
+	ErrShareNotFound      = errors.New("share not found")
+	ErrShareUsed          = errors.New("share already used")
+	ErrBeginTx            = "Cant begin tx"
 )
 
 type Database interface {
 	Connect() error
+	// Close closes the connection, once nothing uses the database anymore.
+	Close() error
 
 	LinkWorker
 	UserWorker
+	JobWorker
+	TagWorker
+	SnapshotWorker
+	CheckWorker
+	ImportWorker
+	ShareWorker
 }
 
 type UserWorker interface {
@@ -31,11 +53,147 @@ type UserWorker interface {
 	GetTelegramIDByID(ctx context.Context, tx *sql.Tx, id int) (int64, error)
 }
 
+// JobLeaseExpired is the error of capture jobs that never finished
+// within their lease, e.g. pages that crash or hang the worker.
+const JobLeaseExpired = "capture did not finish in time"
+
+// JobWorker is the persistent queue of page captures.
+type JobWorker interface {
+	EnqueueCapture(ctx context.Context, j *models.CaptureJob) (int64, error)
+	// ClaimCaptureJob marks the next due job as running for lease,
+	// after that the job is due again unless finished. Jobs whose lease
+	// expired on the last of maxAttempts claims are failed with
+	// JobLeaseExpired instead. ErrNoJobs means nothing is due.
+	ClaimCaptureJob(ctx context.Context, lease time.Duration, maxAttempts int) (*models.CaptureJob, error)
+	// CompleteCaptureJob, RetryCaptureJob and FailCaptureJob finish the claim
+	// with the attempt in Attempts, only while it still holds the job.
+	// Otherwise they return ErrJobLeaseLost.
+	CompleteCaptureJob(ctx context.Context, id int64, attempt int, linkID int) error
+	RetryCaptureJob(ctx context.Context, id int64, attempt int, after time.Duration, reason string) error
+	FailCaptureJob(ctx context.Context, id int64, attempt int, reason string) error
+	GetCaptureJob(ctx context.Context, id int64) (*models.CaptureJob, error)
+}
+
+// TagWorker organizes links of a user with tags.
+// Tag names are expected to be normalized, see NormalizeTags.
+type TagWorker interface {
+	// AddTags and RemoveTags return the tags of the link after the change,
+	// ErrLinksNotFound means the user has no such link.
+	AddTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error)
+	RemoveTags(ctx context.Context, userID int64, linkID int, tags []string) ([]string, error)
+	// GetLinksByTags returns links having all of tags when matchAll is set, any of them otherwise.
+	GetLinksByTags(ctx context.Context, userID int64, tags []string, matchAll bool) ([]*gen.Link, error)
+	// GetTagCounts returns tags in use ordered by the number of links.
+	GetTagCounts(ctx context.Context, userID int64) ([]*gen.TagCount, error)
+}
+
+// SnapshotWorker keeps the capture history of links.
+type SnapshotWorker interface {
+	// AddSnapshot sets s.ID, s.CapturedAt is set to now when zero. The link
+	// takes the text of the snapshot if it is the latest one.
+	// ErrLinksNotFound means the user has no such link.
+	AddSnapshot(ctx context.Context, userID int64, linkID int, s *models.Snapshot) error
+	// GetSnapshots returns snapshots of the link newest first, without the page content.
+	GetSnapshots(ctx context.Context, userID int64, linkID int) ([]*models.Snapshot, error)
+}
+
+// LinkCheckHistory is the number of recent checks kept per link.
+const LinkCheckHistory = 30
+
+// CheckWorker keeps the link-rot state of saved links.
+type CheckWorker interface {
+	// ClaimLinkChecks returns up to limit links not checked for interval, the
+	// ones never checked first, with the state of their last check.
+	// They are due again after interval even if the check is never recorded.
+	ClaimLinkChecks(ctx context.Context, interval time.Duration, limit int) ([]*models.LinkCheck, error)
+	// RecordLinkCheck saves the check of c.LinkID as the state of the link,
+	// ErrLinksNotFound means the link was deleted.
+	RecordLinkCheck(ctx context.Context, c *models.LinkCheck) error
+	// GetBrokenLinks returns dead and moved links of the user with their last check.
+	GetBrokenLinks(ctx context.Context, userID int64) ([]*models.LinkCheck, error)
+}
+
+// ImportWorker queues bookmark files for capture.
+type ImportWorker interface {
+	// GetTakenLinks returns the urls and descriptions the user can't use
+	// for a new link: the saved ones and the ones waiting for capture.
+	GetTakenLinks(ctx context.Context, userID int64) (urls, descriptions map[string]bool, err error)
+	// CreateImport sets imp.ID and imp.CreatedAt and enqueues jobs of the
+	// import at once, the jobs are expected to belong to imp.UserID.
+	CreateImport(ctx context.Context, imp *models.Import, jobs []*models.CaptureJob) error
+	// GetImport returns the import with the status counts of its jobs,
+	// ErrImportNotFound means the user has no such import.
+	GetImport(ctx context.Context, userID int64, id int64) (*models.Import, error)
+}
+
+// ShareWorker keeps share links of saved pages to revoke them.
+type ShareWorker interface {
+	// CreateShare sets s.ID, s.UserID, s.OriginalURL and s.CreatedAt for a share
+	// of s.LinkID, ErrLinksNotFound means the user has no such link.
+	// s.UsedAt is ignored.
+	CreateShare(ctx context.Context, userID int64, s *models.Share) error
+	// GetShare returns the share with the link it opens, revoked, expired
+	// and used ones included. ErrShareNotFound means there is none, shares are deleted
+	// with their link.
+	GetShare(ctx context.Context, id int64) (*models.Share, error)
+	// RevokeShares revokes the share shareID of the link, all shares of it
+	// when shareID is 0, and returns how many were revoked.
+	// ErrLinksNotFound means the user has no such link.
+	RevokeShares(ctx context.Context, userID int64, linkID int, shareID int64) (int, error)
+	// UseShare sets the UsedAt of a one-time share, ErrShareUsed means
+	// it was already used.
+	UseShare(ctx context.Context, id int64) error
+}
+
+// BlobStore keeps page content out of the database, which then stores
+// only the key. Keys are made by NewBlobKey.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Open returns ErrBlobNotFound for a missing key.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete does nothing for a missing key.
+	Delete(ctx context.Context, key string) error
+}
+
 type LinkWorker interface {
+	// SaveLink sets l.ID and saves the content as the first snapshot of the link,
+	// ErrLinkExists means the user already saved this url or description.
 	SaveLink(ctx context.Context, l *models.Link) error
-	GetUserLinks(ctx context.Context, userID int64) ([]*gen.Link, error)
-	GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string) ([]byte, error)
-	GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string) ([]*gen.Link, error)
+	// GetUserLinks, GetLinksByTelegramIDDesc and GetCollectionLinks return
+	// a single page of links and the cursor of the next one, nil on the last page.
+	GetUserLinks(ctx context.Context, userID int64, page Page) ([]*gen.Link, *Cursor, error)
+	// GetContentByTelegramIDOriginalURL and GetArticleByTelegramIDOriginalURL read
+	// the latest snapshot captured before at, zero at means the latest one.
+	// ErrSnapshotNotFound means there is none.
+	GetContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) ([]byte, error)
+	// OpenContentByTelegramIDOriginalURL is GetContentByTelegramIDOriginalURL
+	// that streams the content, the caller must close it.
+	OpenContentByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error)
+	// OpenWARCByTelegramIDOriginalURL streams the WARC file of the same snapshot,
+	// the caller must close it. ErrWARCNotFound means the snapshot has none.
+	OpenWARCByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (io.ReadCloser, error)
+	// GetLinksForExport returns all links of the user by id with the tags,
+	// collection and title of each, the page content is left out.
+	GetLinksForExport(ctx context.Context, userID int64) ([]*models.Link, error)
+	GetArticleByTelegramIDOriginalURL(ctx context.Context, userID int64, originalURL string, at time.Time) (*models.Link, error)
+	GetLinksByTelegramIDDesc(ctx context.Context, userID int64, desc string, page Page) ([]*gen.Link, *Cursor, error)
 	GetLinkByID(ctx context.Context, id int) (*models.Link, error)
+	// DeleteLink deletes the link with its snapshots, content is deleted
+	// with the last snapshot of any link referring to it.
 	DeleteLink(ctx context.Context, id int) (string, int64, error)
+	SearchLinks(ctx context.Context, userID int64, query string) ([]*gen.SearchResult, error)
+
+	// CreateCollection sets c.ID and c.CreatedAt. ErrCollectionExists means the
+	// parent already has a collection with this name, ErrCollectionNotFound
+	// means the user has no such parent.
+	CreateCollection(ctx context.Context, c *models.Collection) error
+	RenameCollection(ctx context.Context, userID int64, id int, name string) error
+	// DeleteCollection deletes the collection with the nested ones,
+	// their links are moved to the top level.
+	DeleteCollection(ctx context.Context, userID int64, id int) error
+	GetCollections(ctx context.Context, userID int64) ([]*gen.Collection, error)
+	// MoveLinks puts distinct linkIDs into the collection, 0 is the top level.
+	// Nothing is moved if any of the links is not the user's.
+	MoveLinks(ctx context.Context, userID int64, linkIDs []int, collectionID int) error
+	GetCollectionLinks(ctx context.Context, userID int64, collectionID int, page Page) ([]*gen.Link, *Cursor, error)
 }
@@ -0,0 +1,1530 @@
+// Package storagetest is the behavioral test suite shared by all
+// storage.Database drivers.
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/domain/models"
+	"github.com/0x0FACED/link-saver-api/internal/storage"
+	"github.com/0x0FACED/proto-files/link_service/gen"
+)
+
+// Factory returns a connected, empty database for a single test.
+type Factory func(t *testing.T) storage.Database
+
+// Run runs the suite against the driver produced by newDB.
+func Run(t *testing.T, newDB Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db storage.Database)
+	}{
+		{"UserNotFound", testUserNotFound},
+		{"SaveLinkCreatesUser", testSaveLinkCreatesUser},
+		{"SaveLinkDuplicate", testSaveLinkDuplicate},
+		{"GetUserLinks", testGetUserLinks},
+		{"GetContent", testGetContent},
+		{"SharedContent", testSharedContent},
+		{"GetArticle", testGetArticle},
+		{"Snapshots", testSnapshots},
+		{"SnapshotWARC", testSnapshotWARC},
+		{"RecaptureJob", testRecaptureJob},
+		{"LinkChecks", testLinkChecks},
+		{"GetLinksByDesc", testGetLinksByDesc},
+		{"Pagination", testPagination},
+		{"GetLinkByID", testGetLinkByID},
+		{"DeleteLink", testDeleteLink},
+		{"SearchLinks", testSearchLinks},
+		{"SearchWords", testSearchWords},
+		{"SearchSnippet", testSearchSnippet},
+		{"CaptureJobs", testCaptureJobs},
+		{"CaptureJobLease", testCaptureJobLease},
+		{"Tags", testTags},
+		{"GetLinksByTags", testGetLinksByTags},
+		{"Collections", testCollections},
+		{"MoveLinks", testMoveLinks},
+		{"SaveLinkDateAdded", testSaveLinkDateAdded},
+		{"Imports", testImports},
+		{"GetLinksForExport", testGetLinksForExport},
+		{"Shares", testShares},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newDB(t))
+		})
+	}
+}
+
+func saveLink(t *testing.T, db storage.Database, userID int64, url, desc string) {
+	t.Helper()
+
+	l := &models.Link{
+		OriginalURL: url,
+		UserID:      userID,
+		Description: desc,
+		Content:     []byte("<html>" + url + "</html>"),
+	}
+	if err := db.SaveLink(context.Background(), l); err != nil {
+		t.Fatalf("SaveLink(%q): %v", url, err)
+	}
+	if l.ID <= 0 {
+		t.Fatalf("SaveLink(%q) did not set the link id", url)
+	}
+}
+
+func linkID(t *testing.T, db storage.Database, userID int64, url string) int {
+	t.Helper()
+
+	links, _, err := db.GetUserLinks(context.Background(), userID, storage.Page{Limit: storage.MaxPageSize})
+	if err != nil {
+		t.Fatalf("GetUserLinks(): %v", err)
+	}
+	for _, l := range links {
+		if l.OriginalUrl == url {
+			return int(l.LinkId)
+		}
+	}
+
+	t.Fatalf("link %q not found", url)
+	return -1
+}
+
+func testUserNotFound(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	if _, err := db.GetUserIDByTelegramID(ctx, nil, 42); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("GetUserIDByTelegramID() err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := db.GetUserByTelegramID(ctx, nil, 42); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("GetUserByTelegramID() err = %v, want ErrUserNotFound", err)
+	}
+	if _, err := db.GetTelegramIDByID(ctx, nil, 42); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("GetTelegramIDByID() err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func testSaveLinkCreatesUser(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 100, "https://example.com", "example")
+
+	u, err := db.GetUserByTelegramID(ctx, nil, 100)
+	if err != nil {
+		t.Fatalf("GetUserByTelegramID(): %v", err)
+	}
+	if u.UserID != 100 {
+		t.Errorf("UserID = %d, want 100", u.UserID)
+	}
+
+	id, err := db.GetUserIDByTelegramID(ctx, nil, 100)
+	if err != nil {
+		t.Fatalf("GetUserIDByTelegramID(): %v", err)
+	}
+	if id != u.ID {
+		t.Errorf("GetUserIDByTelegramID() = %d, want %d", id, u.ID)
+	}
+
+	tgID, err := db.GetTelegramIDByID(ctx, nil, id)
+	if err != nil {
+		t.Fatalf("GetTelegramIDByID(): %v", err)
+	}
+	if tgID != 100 {
+		t.Errorf("GetTelegramIDByID() = %d, want 100", tgID)
+	}
+}
+
+func testSaveLinkDuplicate(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://example.com", "first")
+
+	dup := &models.Link{
+		OriginalURL: "https://example.com",
+		UserID:      1,
+		Description: "second",
+		Content:     []byte("<html></html>"),
+	}
+	if err := db.SaveLink(ctx, dup); !errors.Is(err, storage.ErrLinkExists) {
+		t.Errorf("SaveLink() of the same url for the same user err = %v, want ErrLinkExists", err)
+	}
+
+	// the same url is fine for another user
+	saveLink(t, db, 2, "https://example.com", "first")
+}
+
+func testGetUserLinks(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	links, _, err := db.GetUserLinks(ctx, 1, storage.Page{})
+	if err != nil {
+		t.Fatalf("GetUserLinks() for a new user: %v", err)
+	}
+	if len(links) != 0 {
+		t.Errorf("GetUserLinks() for a new user returned %d links", len(links))
+	}
+	if _, err := db.GetUserIDByTelegramID(ctx, nil, 1); err != nil {
+		t.Errorf("GetUserLinks() did not create the user: %v", err)
+	}
+
+	saveLink(t, db, 1, "https://a.example.com", "a")
+	saveLink(t, db, 1, "https://b.example.com", "b")
+	saveLink(t, db, 2, "https://c.example.com", "c")
+
+	links, _, err = db.GetUserLinks(ctx, 1, storage.Page{})
+	if err != nil {
+		t.Fatalf("GetUserLinks(): %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("GetUserLinks() returned %d links, want 2", len(links))
+	}
+	for _, l := range links {
+		if l.OriginalUrl == "https://c.example.com" {
+			t.Error("GetUserLinks() returned a link of another user")
+		}
+		if l.LinkId == 0 || l.Description == "" {
+			t.Errorf("GetUserLinks() returned incomplete link %+v", l)
+		}
+	}
+}
+
+func testGetContent(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://example.com", "example")
+
+	content, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, "https://example.com", time.Time{})
+	if err != nil {
+		t.Fatalf("GetContentByTelegramIDOriginalURL(): %v", err)
+	}
+	if string(content) != "<html>https://example.com</html>" {
+		t.Errorf("content = %q", content)
+	}
+
+	r, err := db.OpenContentByTelegramIDOriginalURL(ctx, 1, "https://example.com", time.Time{})
+	if err != nil {
+		t.Fatalf("OpenContentByTelegramIDOriginalURL(): %v", err)
+	}
+	defer r.Close()
+	if streamed, err := io.ReadAll(r); err != nil || string(streamed) != string(content) {
+		t.Errorf("streamed content = %q, %v, want %q", streamed, err, content)
+	}
+	if _, err := db.OpenContentByTelegramIDOriginalURL(ctx, 1, "https://example.org", time.Time{}); !errors.Is(err, storage.ErrSnapshotNotFound) {
+		t.Errorf("OpenContentByTelegramIDOriginalURL() of a missing link = %v, want ErrSnapshotNotFound", err)
+	}
+
+	if _, err := db.GetContentByTelegramIDOriginalURL(ctx, 2, "https://example.com", time.Time{}); err == nil {
+		t.Error("GetContentByTelegramIDOriginalURL() returned content of another user")
+	}
+}
+
+func testSharedContent(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+	url := "https://example.com"
+	page := strings.Repeat("<p>the same page</p>", 100)
+
+	for _, userID := range []int64{1, 2} {
+		l := &models.Link{OriginalURL: url, UserID: userID, Description: "example", Content: []byte(page)}
+		if err := db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(%d): %v", userID, err)
+		}
+		// an unchanged recapture refers to the same content
+		if err := db.AddSnapshot(ctx, userID, l.ID, &models.Snapshot{Content: []byte(page)}); err != nil {
+			t.Fatalf("AddSnapshot(%d): %v", userID, err)
+		}
+	}
+
+	if _, _, err := db.DeleteLink(ctx, linkID(t, db, 1, url)); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+
+	// content stays as long as any snapshot refers to it
+	content, err := db.GetContentByTelegramIDOriginalURL(ctx, 2, url, time.Time{})
+	if err != nil {
+		t.Fatalf("GetContentByTelegramIDOriginalURL() after the other link was deleted: %v", err)
+	}
+	if string(content) != page {
+		t.Errorf("content = %q, want %q", content, page)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, linkID(t, db, 2, url)); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+
+	// the same content saved again after the last reference was gone
+	l := &models.Link{OriginalURL: url, UserID: 1, Description: "example", Content: []byte(page)}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink() after all links were deleted: %v", err)
+	}
+	if content, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, url, time.Time{}); err != nil || string(content) != page {
+		t.Errorf("GetContentByTelegramIDOriginalURL() = %q, %v", content, err)
+	}
+}
+
+func testGetArticle(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	l := &models.Link{
+		OriginalURL: "https://example.com/post",
+		UserID:      1,
+		Description: "post",
+		Content:     []byte("<html><nav>menu</nav><article><p>Hello, reader</p></article></html>"),
+		ContentText: "Hello, reader",
+		Title:       "A post",
+		Byline:      "Jane Doe",
+		LeadImage:   "https://example.com/cover.png",
+		WordCount:   2,
+		Article:     "<div><p>Hello, reader</p></div>",
+	}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	got, err := db.GetArticleByTelegramIDOriginalURL(ctx, 1, l.OriginalURL, time.Time{})
+	if err != nil {
+		t.Fatalf("GetArticleByTelegramIDOriginalURL(): %v", err)
+	}
+	if got.Title != l.Title || got.Byline != l.Byline || got.LeadImage != l.LeadImage ||
+		got.WordCount != l.WordCount || got.Article != l.Article || got.UserID != 1 {
+		t.Errorf("GetArticleByTelegramIDOriginalURL() = %+v", got)
+	}
+	if got.DateAdded.IsZero() || got.CapturedAt.IsZero() {
+		t.Error("GetArticleByTelegramIDOriginalURL() has no DateAdded or CapturedAt")
+	}
+
+	if _, err := db.GetArticleByTelegramIDOriginalURL(ctx, 2, l.OriginalURL, time.Time{}); err == nil {
+		t.Error("GetArticleByTelegramIDOriginalURL() returned the article of another user")
+	}
+}
+
+func testSnapshots(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+	url := "https://example.com"
+
+	saveLink(t, db, 1, url, "example")
+	id := linkID(t, db, 1, url)
+
+	fresh := &models.Snapshot{Content: []byte("<html>fresh</html>"), ContentText: "fresh copy", Title: "Fresh"}
+	if err := db.AddSnapshot(ctx, 1, id, fresh); err != nil {
+		t.Fatalf("AddSnapshot(): %v", err)
+	}
+	if fresh.ID <= 0 || fresh.LinkID != id || fresh.CapturedAt.IsZero() {
+		t.Errorf("AddSnapshot() = %+v, want id and capture time set", fresh)
+	}
+
+	old := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	backdated := &models.Snapshot{Content: []byte("<html>old</html>"), ContentText: "old copy", Title: "Old", CapturedAt: old}
+	if err := db.AddSnapshot(ctx, 1, id, backdated); err != nil {
+		t.Fatalf("AddSnapshot() back-dated: %v", err)
+	}
+
+	if err := db.AddSnapshot(ctx, 2, id, &models.Snapshot{}); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("AddSnapshot() to the link of another user err = %v, want ErrLinksNotFound", err)
+	}
+
+	snapshots, err := db.GetSnapshots(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("GetSnapshots(): %v", err)
+	}
+	var titles []string
+	for _, s := range snapshots {
+		titles = append(titles, s.Title)
+		if s.Content != nil {
+			t.Errorf("GetSnapshots() returned the content of snapshot %d", s.ID)
+		}
+	}
+	if want := []string{"Fresh", "", "Old"}; !slices.Equal(titles, want) {
+		t.Errorf("GetSnapshots() titles = %q, want %q", titles, want)
+	}
+	if len(snapshots) == 3 && !snapshots[2].CapturedAt.Equal(old) {
+		t.Errorf("back-dated snapshot captured at %v, want %v", snapshots[2].CapturedAt, old)
+	}
+	if _, err := db.GetSnapshots(ctx, 2, id); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("GetSnapshots() of the link of another user err = %v, want ErrLinksNotFound", err)
+	}
+
+	for _, tt := range []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Time{}, "<html>fresh</html>"},
+		{old.Add(time.Second), "<html>old</html>"},
+	} {
+		content, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, url, tt.at)
+		if err != nil {
+			t.Fatalf("GetContentByTelegramIDOriginalURL(at %v): %v", tt.at, err)
+		}
+		if string(content) != tt.want {
+			t.Errorf("GetContentByTelegramIDOriginalURL(at %v) = %q, want %q", tt.at, content, tt.want)
+		}
+	}
+	if _, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, url, old); !errors.Is(err, storage.ErrSnapshotNotFound) {
+		t.Errorf("GetContentByTelegramIDOriginalURL() before the first snapshot err = %v, want ErrSnapshotNotFound", err)
+	}
+
+	// the link follows the latest snapshot, not the back-dated one
+	article, err := db.GetArticleByTelegramIDOriginalURL(ctx, 1, url, time.Time{})
+	if err != nil {
+		t.Fatalf("GetArticleByTelegramIDOriginalURL(): %v", err)
+	}
+	if article.Title != "Fresh" {
+		t.Errorf("latest article title = %q, want Fresh", article.Title)
+	}
+	article, err = db.GetArticleByTelegramIDOriginalURL(ctx, 1, url, old.Add(time.Second))
+	if err != nil {
+		t.Fatalf("GetArticleByTelegramIDOriginalURL(at): %v", err)
+	}
+	if article.Title != "Old" || !article.CapturedAt.Equal(old) {
+		t.Errorf("article at %v = %+v", old, article)
+	}
+	if results, _ := db.SearchLinks(ctx, 1, "fresh"); len(results) != 1 {
+		t.Errorf("SearchLinks() by the text of the latest snapshot returned %d results, want 1", len(results))
+	}
+
+	if _, _, err := db.DeleteLink(ctx, id); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := db.GetContentByTelegramIDOriginalURL(ctx, 1, url, time.Time{}); err == nil {
+		t.Error("GetContentByTelegramIDOriginalURL() returned content of a deleted link")
+	}
+}
+
+func testSnapshotWARC(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+	url := "https://example.com"
+	archive := []byte("WARC/1.1 of the first capture")
+
+	l := &models.Link{OriginalURL: url, UserID: 1, Description: "example", Content: []byte("<html>first</html>"), WARC: archive}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	first, err := db.GetArticleByTelegramIDOriginalURL(ctx, 1, url, time.Time{})
+	if err != nil {
+		t.Fatalf("GetArticleByTelegramIDOriginalURL(): %v", err)
+	}
+
+	// a capture made without a WARC file hides the older one
+	later := &models.Snapshot{Content: []byte("<html>later</html>"), CapturedAt: first.CapturedAt.Add(time.Hour)}
+	if err := db.AddSnapshot(ctx, 1, l.ID, later); err != nil {
+		t.Fatalf("AddSnapshot(): %v", err)
+	}
+	if _, err := db.OpenWARCByTelegramIDOriginalURL(ctx, 1, url, time.Time{}); !errors.Is(err, storage.ErrWARCNotFound) {
+		t.Errorf("OpenWARCByTelegramIDOriginalURL() of a snapshot without one err = %v, want ErrWARCNotFound", err)
+	}
+
+	r, err := db.OpenWARCByTelegramIDOriginalURL(ctx, 1, url, later.CapturedAt)
+	if err != nil {
+		t.Fatalf("OpenWARCByTelegramIDOriginalURL(at): %v", err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(got, archive) {
+		t.Errorf("OpenWARCByTelegramIDOriginalURL(at) = %q, %v, want %q", got, err, archive)
+	}
+
+	if _, err := db.OpenWARCByTelegramIDOriginalURL(ctx, 1, url, first.CapturedAt); !errors.Is(err, storage.ErrSnapshotNotFound) {
+		t.Errorf("OpenWARCByTelegramIDOriginalURL() before the first snapshot err = %v, want ErrSnapshotNotFound", err)
+	}
+	if _, err := db.OpenWARCByTelegramIDOriginalURL(ctx, 2, url, time.Time{}); err == nil {
+		t.Error("OpenWARCByTelegramIDOriginalURL() returned the WARC file of another user")
+	}
+
+	snapshots, err := db.GetSnapshots(ctx, 1, l.ID)
+	if err != nil {
+		t.Fatalf("GetSnapshots(): %v", err)
+	}
+	for _, s := range snapshots {
+		if s.WARC != nil {
+			t.Errorf("GetSnapshots() returned the WARC file of snapshot %d", s.ID)
+		}
+	}
+
+	if _, _, err := db.DeleteLink(ctx, l.ID); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := db.OpenWARCByTelegramIDOriginalURL(ctx, 1, url, time.Time{}); err == nil {
+		t.Error("OpenWARCByTelegramIDOriginalURL() returned the WARC file of a deleted link")
+	}
+}
+
+func testGetLinksByDesc(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://go.dev", "golang docs")
+	saveLink(t, db, 1, "https://pkg.go.dev", "golang packages")
+	saveLink(t, db, 1, "https://rust-lang.org", "rust")
+	saveLink(t, db, 2, "https://go.dev", "golang")
+
+	links, _, err := db.GetLinksByTelegramIDDesc(ctx, 1, "golang", storage.Page{})
+	if err != nil {
+		t.Fatalf("GetLinksByTelegramIDDesc(): %v", err)
+	}
+	if len(links) != 2 {
+		t.Errorf("GetLinksByTelegramIDDesc() returned %d links, want 2", len(links))
+	}
+
+	links, _, err = db.GetLinksByTelegramIDDesc(ctx, 1, "python", storage.Page{})
+	if err != nil {
+		t.Fatalf("GetLinksByTelegramIDDesc(): %v", err)
+	}
+	if len(links) != 0 {
+		t.Errorf("GetLinksByTelegramIDDesc() returned %d links, want 0", len(links))
+	}
+}
+
+func testPagination(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	var ids []int
+	for _, l := range []struct{ url, title string }{
+		{"https://www.b.com/x", "c"},
+		{"https://a.com", "a"},
+		{"http://c.org", "e"},
+		{"https://A.com/y", "b"},
+		{"https://user@d.net:8080/z?q", "d"},
+	} {
+		link := &models.Link{
+			OriginalURL: l.url,
+			UserID:      1,
+			Description: "link " + l.title,
+			Title:       l.title,
+			Content:     []byte("<html></html>"),
+		}
+		if err := db.SaveLink(ctx, link); err != nil {
+			t.Fatalf("SaveLink(%q): %v", l.url, err)
+		}
+		ids = append(ids, link.ID)
+	}
+	saveLink(t, db, 2, "https://e.com", "other user")
+
+	tests := []struct {
+		sort storage.Sort
+		want []int
+	}{
+		{storage.SortNewest, []int{ids[4], ids[3], ids[2], ids[1], ids[0]}},
+		{storage.SortOldest, ids},
+		{storage.SortTitle, []int{ids[1], ids[3], ids[0], ids[4], ids[2]}},
+		{storage.SortDomain, []int{ids[1], ids[3], ids[0], ids[2], ids[4]}},
+	}
+	for _, tt := range tests {
+		var got []int
+		page := storage.Page{Sort: tt.sort, Limit: 2}
+		for i := 0; ; i++ {
+			if i > len(ids) {
+				t.Fatalf("sort %d: pages never end", tt.sort)
+			}
+			links, next, err := db.GetUserLinks(ctx, 1, page)
+			if err != nil {
+				t.Fatalf("sort %d: GetUserLinks(): %v", tt.sort, err)
+			}
+			if len(links) > 2 {
+				t.Fatalf("sort %d: page has %d links, want at most 2", tt.sort, len(links))
+			}
+			got = append(got, linkIDs(links)...)
+			if next == nil {
+				break
+			}
+			if next.Sort != tt.sort {
+				t.Fatalf("sort %d: next cursor has sort %d", tt.sort, next.Sort)
+			}
+			page.After = next
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sort %d: pages = %v, want %v", tt.sort, got, tt.want)
+		}
+	}
+
+	// a full page that is the last one has no next cursor
+	links, next, err := db.GetLinksByTelegramIDDesc(ctx, 1, "link", storage.Page{Sort: storage.SortOldest, Limit: 5})
+	if err != nil {
+		t.Fatalf("GetLinksByTelegramIDDesc(): %v", err)
+	}
+	if len(links) != 5 || next != nil {
+		t.Errorf("GetLinksByTelegramIDDesc() = %d links, next %+v, want 5 links and no next page", len(links), next)
+	}
+}
+
+func testGetLinkByID(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 7, "https://example.com", "example")
+	id := linkID(t, db, 7, "https://example.com")
+
+	l, err := db.GetLinkByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetLinkByID(): %v", err)
+	}
+	if l.ID != id || l.OriginalURL != "https://example.com" || l.Description != "example" {
+		t.Errorf("GetLinkByID() = %+v", l)
+	}
+	if l.UserID != 7 {
+		t.Errorf("GetLinkByID() UserID = %d, want telegram id 7", l.UserID)
+	}
+
+	if _, err := db.GetLinkByID(ctx, id+1000); err == nil {
+		t.Error("GetLinkByID() of a missing link succeeded")
+	}
+}
+
+func testDeleteLink(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 7, "https://example.com", "example")
+	id := linkID(t, db, 7, "https://example.com")
+
+	url, tgID, err := db.DeleteLink(ctx, id)
+	if err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if url != "https://example.com" || tgID != 7 {
+		t.Errorf("DeleteLink() = %q, %d", url, tgID)
+	}
+
+	if _, err := db.GetLinkByID(ctx, id); err == nil {
+		t.Error("link still exists after DeleteLink()")
+	}
+	if _, _, err := db.DeleteLink(ctx, id); err == nil {
+		t.Error("second DeleteLink() succeeded")
+	}
+
+	// the url can be saved again once deleted
+	saveLink(t, db, 7, "https://example.com", "example")
+}
+
+func testSearchLinks(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	pages := []struct {
+		userID int64
+		url    string
+		desc   string
+		text   string
+	}{
+		{1, "https://go.dev", "go", "The Go gopher is the mascot of the Go project"},
+		{1, "https://blog.example.com", "blog", "A post about gardening, not about any gopher"},
+		{1, "https://rust-lang.org", "rust", "Ferris the crab is the mascot of Rust"},
+		{2, "https://go.dev", "go", "The Go gopher is the mascot of the Go project"},
+	}
+	for _, p := range pages {
+		l := &models.Link{
+			OriginalURL: p.url,
+			UserID:      p.userID,
+			Description: p.desc,
+			Content:     []byte("<html><script>var gopher;</script>" + p.text + "</html>"),
+			ContentText: p.text,
+		}
+		if err := db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(%q): %v", p.url, err)
+		}
+	}
+
+	results, err := db.SearchLinks(ctx, 1, "gopher")
+	if err != nil {
+		t.Fatalf("SearchLinks(): %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("SearchLinks() returned %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if r.Rank <= 0 {
+			t.Errorf("result %q has rank %v", r.Link.OriginalUrl, r.Rank)
+		}
+		if !strings.Contains(r.Snippet, storage.StartSel+"gopher"+storage.StopSel) {
+			t.Errorf("result %q snippet %q has no highlighted term", r.Link.OriginalUrl, r.Snippet)
+		}
+	}
+
+	results, err = db.SearchLinks(ctx, 1, "mascot crab")
+	if err != nil {
+		t.Fatalf("SearchLinks(): %v", err)
+	}
+	if len(results) != 1 || results[0].Link.OriginalUrl != "https://rust-lang.org" {
+		t.Errorf("SearchLinks() with two terms = %v, want only rust-lang.org", results)
+	}
+
+	results, err = db.SearchLinks(ctx, 1, "python")
+	if err != nil {
+		t.Fatalf("SearchLinks(): %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("SearchLinks() returned %d results, want 0", len(results))
+	}
+}
+
+// testSearchWords pins the matching all drivers share: whole words of any
+// case, punctuation ignored, no stemming and every term must match.
+func testSearchWords(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	for _, p := range []struct{ url, desc, text string }{
+		{"https://go.dev", "go", "Gophers, the Go mascots."},
+		{"https://gopher.example.com", "gopher", "A GOPHER digs tunnels."},
+	} {
+		l := &models.Link{OriginalURL: p.url, UserID: 1, Description: p.desc, Content: []byte(p.text), ContentText: p.text}
+		if err := db.SaveLink(ctx, l); err != nil {
+			t.Fatalf("SaveLink(%q): %v", p.url, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  []string
+	}{
+		{"gopher", []string{"https://gopher.example.com"}},
+		{"GO", []string{"https://go.dev"}},
+		{"mascots.", []string{"https://go.dev"}},
+		{"gophers", []string{"https://go.dev"}},
+		{"tunnel", nil},
+		{"digs gopher", []string{"https://gopher.example.com"}},
+		{"digs mascots", nil},
+	} {
+		results, err := db.SearchLinks(ctx, 1, tt.query)
+		if err != nil {
+			t.Fatalf("SearchLinks(%q): %v", tt.query, err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Link.OriginalUrl)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SearchLinks(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	results, err := db.SearchLinks(ctx, 1, "go")
+	if err != nil || len(results) != 1 {
+		t.Fatalf("SearchLinks(): %v, %v", results, err)
+	}
+	if snippet := results[0].Snippet; !strings.Contains(snippet, storage.StartSel+"Go"+storage.StopSel) ||
+		strings.Contains(snippet, storage.StartSel+"Gophers") {
+		t.Errorf("snippet %q, want only the word Go highlighted", snippet)
+	}
+}
+
+// testSearchSnippet checks that snippets are html with the page text
+// escaped, so markup of the page never gets into them.
+func testSearchSnippet(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	text := `Tags like <script>alert(1)</script> & "<b>bold</b>" show up on gopher pages` + storage.StartMark
+	l := &models.Link{OriginalURL: "https://go.dev", UserID: 1, Description: "go", Content: []byte(text), ContentText: text}
+	if err := db.SaveLink(ctx, l); err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	results, err := db.SearchLinks(ctx, 1, "gopher")
+	if err != nil || len(results) != 1 {
+		t.Fatalf("SearchLinks() = %v, %v, want 1 result", results, err)
+	}
+	snippet := results[0].Snippet
+	if !strings.Contains(snippet, "&lt;script&gt;") || !strings.Contains(snippet, "&amp;") ||
+		!strings.Contains(snippet, storage.StartSel+"gopher"+storage.StopSel) {
+		t.Errorf("snippet %q, want the text escaped and the term highlighted", snippet)
+	}
+	if strings.Contains(snippet, "<script") || strings.Count(snippet, storage.StartSel) != 1 ||
+		strings.Contains(snippet, storage.StartMark) {
+		t.Errorf("snippet %q has markup of the page", snippet)
+	}
+}
+
+func enqueue(t *testing.T, db storage.Database, userID int64, url string) int64 {
+	t.Helper()
+
+	id, err := db.EnqueueCapture(context.Background(), &models.CaptureJob{
+		UserID:      userID,
+		OriginalURL: url,
+		Description: "queued",
+	})
+	if err != nil {
+		t.Fatalf("EnqueueCapture(%q): %v", url, err)
+	}
+	return id
+}
+
+// maxAttempts is the limit of claims of a capture job in the tests.
+const maxAttempts = 3
+
+func claim(t *testing.T, db storage.Database, lease time.Duration) *models.CaptureJob {
+	t.Helper()
+
+	j, err := db.ClaimCaptureJob(context.Background(), lease, maxAttempts)
+	if err != nil {
+		t.Fatalf("ClaimCaptureJob(): %v", err)
+	}
+	if j.Status != models.CaptureStatusRunning {
+		t.Errorf("claimed job status = %q, want running", j.Status)
+	}
+	return j
+}
+
+func testCaptureJobs(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	first := enqueue(t, db, 5, "https://a.example.com")
+	second := enqueue(t, db, 5, "https://b.example.com")
+
+	j, err := db.GetCaptureJob(ctx, first)
+	if err != nil {
+		t.Fatalf("GetCaptureJob(): %v", err)
+	}
+	if j.Status != models.CaptureStatusPending || j.Attempts != 0 || j.UserID != 5 ||
+		j.OriginalURL != "https://a.example.com" || j.Description != "queued" {
+		t.Errorf("GetCaptureJob() = %+v", j)
+	}
+
+	if j := claim(t, db, time.Minute); j.ID != first || j.Attempts != 1 || j.UserID != 5 {
+		t.Errorf("first claim = %+v, want job %d with 1 attempt", j, first)
+	}
+	if j := claim(t, db, time.Minute); j.ID != second {
+		t.Errorf("second claim = %+v, want job %d", j, second)
+	}
+	if _, err := db.ClaimCaptureJob(ctx, time.Minute, maxAttempts); !errors.Is(err, storage.ErrNoJobs) {
+		t.Errorf("ClaimCaptureJob() with nothing due err = %v, want ErrNoJobs", err)
+	}
+
+	if err := db.RetryCaptureJob(ctx, first, 1, 0, "timeout"); err != nil {
+		t.Fatalf("RetryCaptureJob(): %v", err)
+	}
+	j = claim(t, db, time.Minute)
+	if j.ID != first || j.Attempts != 2 || j.Error != "timeout" {
+		t.Errorf("claim after retry = %+v, want job %d with 2 attempts", j, first)
+	}
+
+	saveLink(t, db, 5, "https://a.example.com", "a")
+	id := linkID(t, db, 5, "https://a.example.com")
+	if err := db.CompleteCaptureJob(ctx, first, 2, id); err != nil {
+		t.Fatalf("CompleteCaptureJob(): %v", err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, first); j.Status != models.CaptureStatusDone || j.LinkID != id || j.Error != "" {
+		t.Errorf("completed job = %+v", j)
+	}
+
+	if err := db.FailCaptureJob(ctx, second, 1, "not found"); err != nil {
+		t.Fatalf("FailCaptureJob(): %v", err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, second); j.Status != models.CaptureStatusFailed || j.Error != "not found" {
+		t.Errorf("failed job = %+v", j)
+	}
+	if _, err := db.ClaimCaptureJob(ctx, time.Minute, maxAttempts); !errors.Is(err, storage.ErrNoJobs) {
+		t.Errorf("ClaimCaptureJob() after all jobs finished err = %v, want ErrNoJobs", err)
+	}
+
+	if _, err := db.GetCaptureJob(ctx, second+1000); !errors.Is(err, storage.ErrJobNotFound) {
+		t.Errorf("GetCaptureJob() of a missing job err = %v, want ErrJobNotFound", err)
+	}
+	if err := db.CompleteCaptureJob(ctx, second+1000, 1, id); !errors.Is(err, storage.ErrJobNotFound) {
+		t.Errorf("CompleteCaptureJob() of a missing job err = %v, want ErrJobNotFound", err)
+	}
+}
+
+func testCaptureJobLease(t *testing.T, db storage.Database) {
+	id := enqueue(t, db, 5, "https://a.example.com")
+
+	// a worker that died with the job leaves it running,
+	// it is claimed again once the lease is over
+	claim(t, db, -time.Minute)
+	if j := claim(t, db, time.Minute); j.ID != id || j.Attempts != 2 {
+		t.Errorf("claim after the lease = %+v, want job %d with 2 attempts", j, id)
+	}
+
+	// the first claim can't finish the job of the second
+	ctx := context.Background()
+	if err := db.CompleteCaptureJob(ctx, id, 1, 0); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("CompleteCaptureJob() of a lost lease err = %v, want ErrJobLeaseLost", err)
+	}
+	if err := db.FailCaptureJob(ctx, id, 1, "timeout"); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("FailCaptureJob() of a lost lease err = %v, want ErrJobLeaseLost", err)
+	}
+	if err := db.RetryCaptureJob(ctx, id, 1, 0, "timeout"); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("RetryCaptureJob() of a lost lease err = %v, want ErrJobLeaseLost", err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, id); j.Status != models.CaptureStatusRunning || j.Attempts != 2 {
+		t.Errorf("job after a lost lease = %+v, want it running", j)
+	}
+
+	saveLink(t, db, 5, "https://a.example.com", "a")
+	if err := db.CompleteCaptureJob(ctx, id, 2, linkID(t, db, 5, "https://a.example.com")); err != nil {
+		t.Fatalf("CompleteCaptureJob(): %v", err)
+	}
+	// a finished job is not running anymore
+	if err := db.FailCaptureJob(ctx, id, 2, "timeout"); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("FailCaptureJob() of a finished job err = %v, want ErrJobLeaseLost", err)
+	}
+
+	// a page that keeps killing the worker is not claimed forever
+	hang := enqueue(t, db, 5, "https://hang.example.com")
+	for i := 0; i < maxAttempts; i++ {
+		if j := claim(t, db, -time.Minute); j.ID != hang {
+			t.Fatalf("claim %d = %+v, want job %d", i+1, j, hang)
+		}
+	}
+	if j, err := db.ClaimCaptureJob(ctx, time.Minute, maxAttempts); !errors.Is(err, storage.ErrNoJobs) {
+		t.Errorf("ClaimCaptureJob() after the last lease expired = %+v, %v, want ErrNoJobs", j, err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, hang); j.Status != models.CaptureStatusFailed || j.Error != storage.JobLeaseExpired || j.Attempts != maxAttempts {
+		t.Errorf("job after the last lease expired = %+v, want it failed", j)
+	}
+	if err := db.CompleteCaptureJob(ctx, hang, maxAttempts, 0); !errors.Is(err, storage.ErrJobLeaseLost) {
+		t.Errorf("CompleteCaptureJob() of the last claim err = %v, want ErrJobLeaseLost", err)
+	}
+}
+
+func testRecaptureJob(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 5, "https://a.example.com", "a")
+	id := linkID(t, db, 5, "https://a.example.com")
+
+	jobID, err := db.EnqueueCapture(ctx, &models.CaptureJob{
+		UserID:      5,
+		OriginalURL: "https://a.example.com",
+		Description: "a",
+		LinkID:      id,
+		Recapture:   true,
+	})
+	if err != nil {
+		t.Fatalf("EnqueueCapture(): %v", err)
+	}
+
+	if j := claim(t, db, time.Minute); j.ID != jobID || !j.Recapture || j.LinkID != id {
+		t.Errorf("claimed recapture job = %+v, want job %d of link %d", j, jobID, id)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, id); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, jobID); j.LinkID != 0 || !j.Recapture {
+		t.Errorf("recapture job of a deleted link = %+v, want no link", j)
+	}
+}
+
+func claimChecks(t *testing.T, db storage.Database, interval time.Duration) map[int]*models.LinkCheck {
+	t.Helper()
+
+	checks, err := db.ClaimLinkChecks(context.Background(), interval, 10)
+	if err != nil {
+		t.Fatalf("ClaimLinkChecks(): %v", err)
+	}
+
+	claimed := make(map[int]*models.LinkCheck, len(checks))
+	for _, c := range checks {
+		claimed[c.LinkID] = c
+	}
+	return claimed
+}
+
+func testLinkChecks(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 5, "https://a.example.com", "a")
+	saveLink(t, db, 5, "https://b.example.com", "b")
+	saveLink(t, db, 5, "https://c.example.com", "c")
+	saveLink(t, db, 6, "https://d.example.com", "d")
+	a := linkID(t, db, 5, "https://a.example.com")
+	b := linkID(t, db, 5, "https://b.example.com")
+	c := linkID(t, db, 5, "https://c.example.com")
+
+	claimed := claimChecks(t, db, time.Hour)
+	if len(claimed) != 4 {
+		t.Fatalf("first ClaimLinkChecks() = %d links, want 4", len(claimed))
+	}
+	if got := claimed[a]; got == nil || got.OriginalURL != "https://a.example.com" || got.Description != "a" ||
+		got.Status != models.LinkStatusUnknown || got.Failures != 0 {
+		t.Errorf("claimed check of a new link = %+v", got)
+	}
+	if claimed := claimChecks(t, db, time.Hour); len(claimed) != 0 {
+		t.Errorf("ClaimLinkChecks() right after a claim = %d links, want 0", len(claimed))
+	}
+
+	record := func(c *models.LinkCheck) {
+		t.Helper()
+		if err := db.RecordLinkCheck(ctx, c); err != nil {
+			t.Fatalf("RecordLinkCheck(%d): %v", c.LinkID, err)
+		}
+	}
+	record(&models.LinkCheck{LinkID: a, Status: models.LinkStatusUnknown, StatusCode: 404, Error: "Not Found", Failures: 1})
+	record(&models.LinkCheck{LinkID: a, Status: models.LinkStatusDead, StatusCode: 404, Error: "Not Found", Failures: 2})
+	record(&models.LinkCheck{LinkID: b, Status: models.LinkStatusMoved, StatusCode: 200, Location: "https://new.example.com"})
+	record(&models.LinkCheck{LinkID: c, Status: models.LinkStatusOK, StatusCode: 200})
+
+	broken, err := db.GetBrokenLinks(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetBrokenLinks(): %v", err)
+	}
+	if len(broken) != 2 {
+		t.Fatalf("GetBrokenLinks() = %d links, want 2", len(broken))
+	}
+	dead, moved := broken[0], broken[1]
+	if dead.LinkID != a || dead.Status != models.LinkStatusDead || dead.StatusCode != 404 ||
+		dead.Failures != 2 || dead.OriginalURL != "https://a.example.com" || dead.CheckedAt.IsZero() || !dead.LastOKAt.IsZero() {
+		t.Errorf("dead link = %+v", dead)
+	}
+	if moved.LinkID != b || moved.Status != models.LinkStatusMoved || moved.Location != "https://new.example.com" ||
+		moved.LastOKAt.IsZero() {
+		t.Errorf("moved link = %+v", moved)
+	}
+
+	if broken, err := db.GetBrokenLinks(ctx, 6); err != nil || len(broken) != 0 {
+		t.Errorf("GetBrokenLinks() of another user = %v, %v, want none", broken, err)
+	}
+	if broken, err := db.GetBrokenLinks(ctx, 7); err != nil || len(broken) != 0 {
+		t.Errorf("GetBrokenLinks() of an unknown user = %v, %v, want none", broken, err)
+	}
+
+	// claims carry the state over to the next check
+	claimed = claimChecks(t, db, -time.Minute)
+	if got := claimed[a]; got == nil || got.Status != models.LinkStatusDead || got.Failures != 2 {
+		t.Errorf("claimed check of a dead link = %+v", got)
+	}
+
+	// a link that is back is no longer listed
+	record(&models.LinkCheck{LinkID: a, Status: models.LinkStatusOK, StatusCode: 200})
+	if broken, _ := db.GetBrokenLinks(ctx, 5); len(broken) != 1 || broken[0].LinkID != b {
+		t.Errorf("GetBrokenLinks() after recovery = %v, want only link %d", broken, b)
+	}
+
+	if _, _, err := db.DeleteLink(ctx, b); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	err = db.RecordLinkCheck(ctx, &models.LinkCheck{LinkID: b, Status: models.LinkStatusOK, StatusCode: 200})
+	if !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("RecordLinkCheck() of a deleted link = %v, want ErrLinksNotFound", err)
+	}
+}
+
+func linkIDs(links []*gen.Link) []int {
+	ids := make([]int, 0, len(links))
+	for _, l := range links {
+		ids = append(ids, int(l.LinkId))
+	}
+	return ids
+}
+
+func testTags(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://a.example.com", "a")
+	saveLink(t, db, 2, "https://b.example.com", "b")
+	a := linkID(t, db, 1, "https://a.example.com")
+	b := linkID(t, db, 2, "https://b.example.com")
+
+	tags, err := db.AddTags(ctx, 1, a, []string{"go", "db"})
+	if err != nil {
+		t.Fatalf("AddTags(): %v", err)
+	}
+	if !slices.Equal(tags, []string{"db", "go"}) {
+		t.Errorf("AddTags() = %v, want [db go]", tags)
+	}
+
+	// adding a tag twice is fine
+	if tags, err = db.AddTags(ctx, 1, a, []string{"go", "web"}); err != nil || !slices.Equal(tags, []string{"db", "go", "web"}) {
+		t.Errorf("AddTags() = %v, %v, want [db go web]", tags, err)
+	}
+
+	if _, err := db.AddTags(ctx, 1, b, []string{"go"}); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("AddTags() to a link of another user err = %v, want ErrLinksNotFound", err)
+	}
+	if _, err := db.RemoveTags(ctx, 1, b, []string{"go"}); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("RemoveTags() from a link of another user err = %v, want ErrLinksNotFound", err)
+	}
+
+	if tags, err = db.RemoveTags(ctx, 1, a, []string{"db", "missing"}); err != nil || !slices.Equal(tags, []string{"go", "web"}) {
+		t.Errorf("RemoveTags() = %v, %v, want [go web]", tags, err)
+	}
+
+	if tags, err = db.RemoveTags(ctx, 1, a, []string{"go", "web"}); err != nil || len(tags) != 0 {
+		t.Errorf("RemoveTags() of all tags = %v, %v, want none", tags, err)
+	}
+	if counts, err := db.GetTagCounts(ctx, 1); err != nil || len(counts) != 0 {
+		t.Errorf("GetTagCounts() after removing all tags = %v, %v, want none", counts, err)
+	}
+
+	// tags of deleted links are not counted
+	if _, err := db.AddTags(ctx, 2, b, []string{"go"}); err != nil {
+		t.Fatalf("AddTags(): %v", err)
+	}
+	if _, _, err := db.DeleteLink(ctx, b); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if counts, err := db.GetTagCounts(ctx, 2); err != nil || len(counts) != 0 {
+		t.Errorf("GetTagCounts() after DeleteLink() = %v, %v, want none", counts, err)
+	}
+}
+
+func testGetLinksByTags(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://a.example.com", "a")
+	saveLink(t, db, 1, "https://b.example.com", "b")
+	saveLink(t, db, 1, "https://c.example.com", "c")
+	saveLink(t, db, 2, "https://d.example.com", "d")
+	a := linkID(t, db, 1, "https://a.example.com")
+	b := linkID(t, db, 1, "https://b.example.com")
+	c := linkID(t, db, 1, "https://c.example.com")
+	d := linkID(t, db, 2, "https://d.example.com")
+
+	for id, tags := range map[int][]string{
+		a: {"go", "db"},
+		b: {"go"},
+		c: {"db", "web"},
+	} {
+		if _, err := db.AddTags(ctx, 1, id, tags); err != nil {
+			t.Fatalf("AddTags(): %v", err)
+		}
+	}
+	if _, err := db.AddTags(ctx, 2, d, []string{"go", "db"}); err != nil {
+		t.Fatalf("AddTags(): %v", err)
+	}
+
+	tests := []struct {
+		tags     []string
+		matchAll bool
+		want     []int
+	}{
+		{[]string{"go"}, false, []int{a, b}},
+		{[]string{"go", "db"}, false, []int{a, b, c}},
+		{[]string{"go", "db"}, true, []int{a}},
+		{[]string{"go", "web"}, true, nil},
+		{[]string{"missing"}, false, nil},
+	}
+	for _, tt := range tests {
+		links, err := db.GetLinksByTags(ctx, 1, tt.tags, tt.matchAll)
+		if err != nil {
+			t.Fatalf("GetLinksByTags(%v, %v): %v", tt.tags, tt.matchAll, err)
+		}
+		if got := linkIDs(links); !slices.Equal(got, tt.want) {
+			t.Errorf("GetLinksByTags(%v, %v) = %v, want %v", tt.tags, tt.matchAll, got, tt.want)
+		}
+	}
+
+	counts, err := db.GetTagCounts(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetTagCounts(): %v", err)
+	}
+	var got []string
+	for _, c := range counts {
+		got = append(got, fmt.Sprintf("%s:%d", c.Name, c.Count))
+	}
+	if want := []string{"db:2", "go:2", "web:1"}; !slices.Equal(got, want) {
+		t.Errorf("GetTagCounts() = %v, want %v", got, want)
+	}
+
+	if counts, err := db.GetTagCounts(ctx, 3); err != nil || len(counts) != 0 {
+		t.Errorf("GetTagCounts() of an unknown user = %v, %v, want none", counts, err)
+	}
+}
+
+func createCollection(t *testing.T, db storage.Database, userID int64, parentID int, name string) int {
+	t.Helper()
+
+	c := &models.Collection{UserID: userID, ParentID: parentID, Name: name}
+	if err := db.CreateCollection(context.Background(), c); err != nil {
+		t.Fatalf("CreateCollection(%q): %v", name, err)
+	}
+	if c.ID <= 0 {
+		t.Fatalf("CreateCollection(%q) did not set the collection id", name)
+	}
+	return c.ID
+}
+
+func testCollections(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	work := createCollection(t, db, 1, 0, "work")
+	docs := createCollection(t, db, 1, work, "docs")
+	createCollection(t, db, 1, docs, "api")
+	// names are unique per parent only
+	createCollection(t, db, 1, 0, "docs")
+	other := createCollection(t, db, 2, 0, "work")
+
+	dup := &models.Collection{UserID: 1, ParentID: work, Name: "docs"}
+	if err := db.CreateCollection(ctx, dup); !errors.Is(err, storage.ErrCollectionExists) {
+		t.Errorf("CreateCollection() of a duplicate err = %v, want ErrCollectionExists", err)
+	}
+	foreign := &models.Collection{UserID: 1, ParentID: other, Name: "stolen"}
+	if err := db.CreateCollection(ctx, foreign); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("CreateCollection() under a collection of another user err = %v, want ErrCollectionNotFound", err)
+	}
+
+	if err := db.RenameCollection(ctx, 1, docs, "manuals"); err != nil {
+		t.Fatalf("RenameCollection(): %v", err)
+	}
+	if err := db.RenameCollection(ctx, 1, work, "docs"); !errors.Is(err, storage.ErrCollectionExists) {
+		t.Errorf("RenameCollection() to a taken name err = %v, want ErrCollectionExists", err)
+	}
+	if err := db.RenameCollection(ctx, 1, other, "mine"); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("RenameCollection() of a collection of another user err = %v, want ErrCollectionNotFound", err)
+	}
+
+	collections, err := db.GetCollections(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCollections(): %v", err)
+	}
+	var got []string
+	for _, c := range collections {
+		got = append(got, fmt.Sprintf("%s:%d", c.Name, c.ParentId))
+	}
+	want := []string{"api:" + fmt.Sprint(docs), "docs:0", "manuals:" + fmt.Sprint(work), "work:0"}
+	if !slices.Equal(got, want) {
+		t.Errorf("GetCollections() = %v, want %v", got, want)
+	}
+
+	if err := db.DeleteCollection(ctx, 1, other); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("DeleteCollection() of a collection of another user err = %v, want ErrCollectionNotFound", err)
+	}
+
+	// nested collections go together with the parent
+	if err := db.DeleteCollection(ctx, 1, work); err != nil {
+		t.Fatalf("DeleteCollection(): %v", err)
+	}
+	collections, err = db.GetCollections(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCollections(): %v", err)
+	}
+	if len(collections) != 1 || collections[0].Name != "docs" {
+		t.Errorf("GetCollections() after DeleteCollection() = %v, want only docs", collections)
+	}
+	if err := db.DeleteCollection(ctx, 1, work); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("second DeleteCollection() err = %v, want ErrCollectionNotFound", err)
+	}
+}
+
+func testMoveLinks(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://a.example.com", "a")
+	saveLink(t, db, 1, "https://b.example.com", "b")
+	saveLink(t, db, 2, "https://c.example.com", "c")
+	a := linkID(t, db, 1, "https://a.example.com")
+	b := linkID(t, db, 1, "https://b.example.com")
+	c := linkID(t, db, 2, "https://c.example.com")
+
+	reading := createCollection(t, db, 1, 0, "reading")
+	nested := createCollection(t, db, 1, reading, "later")
+	other := createCollection(t, db, 2, 0, "reading")
+
+	if err := db.MoveLinks(ctx, 1, []int{a, b}, reading); err != nil {
+		t.Fatalf("MoveLinks(): %v", err)
+	}
+	links, _, err := db.GetCollectionLinks(ctx, 1, reading, storage.Page{Sort: storage.SortOldest})
+	if err != nil {
+		t.Fatalf("GetCollectionLinks(): %v", err)
+	}
+	if got := linkIDs(links); !slices.Equal(got, []int{a, b}) {
+		t.Errorf("GetCollectionLinks() = %v, want %v", got, []int{a, b})
+	}
+
+	// nothing moves when a link is not the user's
+	if err := db.MoveLinks(ctx, 1, []int{a, c}, nested); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("MoveLinks() with a link of another user err = %v, want ErrLinksNotFound", err)
+	}
+	if links, _, _ := db.GetCollectionLinks(ctx, 1, nested, storage.Page{}); len(links) != 0 {
+		t.Errorf("GetCollectionLinks() after a failed MoveLinks() = %v, want none", linkIDs(links))
+	}
+	if err := db.MoveLinks(ctx, 1, []int{a}, other); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("MoveLinks() into a collection of another user err = %v, want ErrCollectionNotFound", err)
+	}
+	if _, _, err := db.GetCollectionLinks(ctx, 1, other, storage.Page{}); !errors.Is(err, storage.ErrCollectionNotFound) {
+		t.Errorf("GetCollectionLinks() of a collection of another user err = %v, want ErrCollectionNotFound", err)
+	}
+
+	if err := db.MoveLinks(ctx, 1, []int{b}, nested); err != nil {
+		t.Fatalf("MoveLinks(): %v", err)
+	}
+	collections, err := db.GetCollections(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetCollections(): %v", err)
+	}
+	for _, col := range collections {
+		if col.LinkCount != 1 {
+			t.Errorf("LinkCount of %q = %d, want 1", col.Name, col.LinkCount)
+		}
+	}
+
+	// links of a deleted collection stay saved
+	if err := db.DeleteCollection(ctx, 1, reading); err != nil {
+		t.Fatalf("DeleteCollection(): %v", err)
+	}
+	links, _, err = db.GetUserLinks(ctx, 1, storage.Page{})
+	if err != nil {
+		t.Fatalf("GetUserLinks(): %v", err)
+	}
+	if len(links) != 2 {
+		t.Errorf("GetUserLinks() after DeleteCollection() returned %d links, want 2", len(links))
+	}
+
+	if err := db.MoveLinks(ctx, 1, []int{a}, 0); err != nil {
+		t.Errorf("MoveLinks() to the top level: %v", err)
+	}
+}
+
+func testSaveLinkDateAdded(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	added := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	err := db.SaveLink(ctx, &models.Link{
+		OriginalURL: "https://old.example.com",
+		UserID:      5,
+		Description: "old",
+		Content:     []byte("<html>old</html>"),
+		DateAdded:   added,
+	})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+
+	l, err := db.GetArticleByTelegramIDOriginalURL(ctx, 5, "https://old.example.com", time.Time{})
+	if err != nil {
+		t.Fatalf("GetArticleByTelegramIDOriginalURL(): %v", err)
+	}
+	if !l.DateAdded.Equal(added) {
+		t.Errorf("DateAdded = %v, want %v", l.DateAdded, added)
+	}
+	// the page itself is captured now
+	if time.Since(l.CapturedAt) > time.Hour {
+		t.Errorf("CapturedAt = %v, want about now", l.CapturedAt)
+	}
+}
+
+func testImports(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 5, "https://saved.example.com", "saved")
+	enqueue(t, db, 5, "https://queued.example.com")
+	saveLink(t, db, 6, "https://other.example.com", "other")
+
+	urls, descriptions, err := db.GetTakenLinks(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetTakenLinks(): %v", err)
+	}
+	if len(urls) != 2 || !urls["https://saved.example.com"] || !urls["https://queued.example.com"] {
+		t.Errorf("GetTakenLinks() urls = %v", urls)
+	}
+	if len(descriptions) != 2 || !descriptions["saved"] || !descriptions["queued"] {
+		t.Errorf("GetTakenLinks() descriptions = %v", descriptions)
+	}
+
+	if urls, _, err := db.GetTakenLinks(ctx, 7); err != nil || len(urls) != 0 {
+		t.Errorf("GetTakenLinks() of a new user = %v, %v, want nothing", urls, err)
+	}
+
+	collectionID := createCollection(t, db, 5, 0, "imported")
+	added := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	jobs := []*models.CaptureJob{
+		{
+			UserID:       5,
+			OriginalURL:  "https://a.example.com",
+			Description:  "a",
+			DateAdded:    added,
+			Tags:         []string{"go", "docs"},
+			CollectionID: collectionID,
+		},
+		{UserID: 5, OriginalURL: "https://b.example.com", Description: "b"},
+	}
+	imp := &models.Import{UserID: 5, Total: 5, Queued: 2, Duplicates: 2, Invalid: 1}
+	if err := db.CreateImport(ctx, imp, jobs); err != nil {
+		t.Fatalf("CreateImport(): %v", err)
+	}
+	if imp.ID <= 0 || imp.CreatedAt.IsZero() {
+		t.Fatalf("CreateImport() did not set the id and time: %+v", imp)
+	}
+	for _, j := range jobs {
+		if j.ID <= 0 || j.ImportID != imp.ID {
+			t.Errorf("CreateImport() job = %+v, want an id and import %d", j, imp.ID)
+		}
+	}
+
+	j, err := db.GetCaptureJob(ctx, jobs[0].ID)
+	if err != nil {
+		t.Fatalf("GetCaptureJob(): %v", err)
+	}
+	if j.ImportID != imp.ID || !j.DateAdded.Equal(added) || !slices.Equal(j.Tags, []string{"go", "docs"}) ||
+		j.CollectionID != collectionID {
+		t.Errorf("imported job = %+v", j)
+	}
+	if j, _ := db.GetCaptureJob(ctx, jobs[1].ID); !j.DateAdded.IsZero() || j.Tags != nil || j.CollectionID != 0 {
+		t.Errorf("imported job without extras = %+v", j)
+	}
+
+	// the queued job claimed first is not a part of the import
+	claim(t, db, time.Minute)
+	if j := claim(t, db, time.Minute); j.ID != jobs[0].ID || !j.DateAdded.Equal(added) || len(j.Tags) != 2 {
+		t.Errorf("claimed imported job = %+v, want job %d with its extras", j, jobs[0].ID)
+	}
+	if err := db.FailCaptureJob(ctx, jobs[0].ID, 1, "not found"); err != nil {
+		t.Fatalf("FailCaptureJob(): %v", err)
+	}
+
+	got, err := db.GetImport(ctx, 5, imp.ID)
+	if err != nil {
+		t.Fatalf("GetImport(): %v", err)
+	}
+	if got.ID != imp.ID || got.UserID != 5 || got.Total != 5 || got.Queued != 2 || got.Duplicates != 2 ||
+		got.Invalid != 1 || got.Pending != 1 || got.Running != 0 || got.Done != 0 || got.Failed != 1 {
+		t.Errorf("GetImport() = %+v", got)
+	}
+
+	// pending imported links are taken too
+	if urls, descriptions, _ := db.GetTakenLinks(ctx, 5); !urls["https://b.example.com"] || !descriptions["b"] ||
+		urls["https://a.example.com"] {
+		t.Errorf("GetTakenLinks() after import = %v, %v", urls, descriptions)
+	}
+
+	if _, err := db.GetImport(ctx, 6, imp.ID); !errors.Is(err, storage.ErrImportNotFound) {
+		t.Errorf("GetImport() of another user err = %v, want ErrImportNotFound", err)
+	}
+	if _, err := db.GetImport(ctx, 5, imp.ID+1000); !errors.Is(err, storage.ErrImportNotFound) {
+		t.Errorf("GetImport() of a missing import err = %v, want ErrImportNotFound", err)
+	}
+
+	// jobs keep going when their collection is deleted
+	if err := db.DeleteCollection(ctx, 5, collectionID); err != nil {
+		t.Fatalf("DeleteCollection(): %v", err)
+	}
+	if j, _ := db.GetCaptureJob(ctx, jobs[0].ID); j.CollectionID != 0 {
+		t.Errorf("job of a deleted collection = %+v, want no collection", j)
+	}
+}
+
+func testGetLinksForExport(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	if links, err := db.GetLinksForExport(ctx, 5); err != nil || len(links) != 0 {
+		t.Fatalf("GetLinksForExport() of a new user = %v, %v, want nothing", links, err)
+	}
+
+	added := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+	err := db.SaveLink(ctx, &models.Link{
+		OriginalURL: "https://a.example.com",
+		UserID:      5,
+		Description: "a",
+		Title:       "Page A",
+		Content:     []byte("<html>a</html>"),
+		DateAdded:   added,
+	})
+	if err != nil {
+		t.Fatalf("SaveLink(): %v", err)
+	}
+	saveLink(t, db, 5, "https://b.example.com", "b")
+	saveLink(t, db, 6, "https://other.example.com", "other")
+	a := linkID(t, db, 5, "https://a.example.com")
+	b := linkID(t, db, 5, "https://b.example.com")
+
+	if _, err := db.AddTags(ctx, 5, a, []string{"go", "docs"}); err != nil {
+		t.Fatalf("AddTags(): %v", err)
+	}
+	collectionID := createCollection(t, db, 5, 0, "reading")
+	if err := db.MoveLinks(ctx, 5, []int{b}, collectionID); err != nil {
+		t.Fatalf("MoveLinks(): %v", err)
+	}
+
+	links, err := db.GetLinksForExport(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetLinksForExport(): %v", err)
+	}
+	if len(links) != 2 || links[0].ID != a || links[1].ID != b {
+		t.Fatalf("GetLinksForExport() = %v, want links %d and %d", links, a, b)
+	}
+
+	if l := links[0]; l.OriginalURL != "https://a.example.com" || l.Description != "a" || l.Title != "Page A" ||
+		l.UserID != 5 || !l.DateAdded.Equal(added) || !slices.Equal(l.Tags, []string{"docs", "go"}) ||
+		l.CollectionID != 0 || l.Content != nil {
+		t.Errorf("exported link a = %+v", l)
+	}
+	if l := links[1]; l.Tags != nil || l.CollectionID != collectionID || l.DateAdded.IsZero() {
+		t.Errorf("exported link b = %+v", l)
+	}
+}
+
+func testShares(t *testing.T, db storage.Database) {
+	ctx := context.Background()
+
+	saveLink(t, db, 1, "https://a.example.com", "a")
+	saveLink(t, db, 2, "https://b.example.com", "b")
+	a := linkID(t, db, 1, "https://a.example.com")
+	b := linkID(t, db, 2, "https://b.example.com")
+
+	if err := db.CreateShare(ctx, 1, &models.Share{LinkID: b}); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("CreateShare() of a link of another user err = %v, want ErrLinksNotFound", err)
+	}
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	first := &models.Share{LinkID: a, ExpiresAt: expires}
+	if err := db.CreateShare(ctx, 1, first); err != nil {
+		t.Fatalf("CreateShare(): %v", err)
+	}
+	second := &models.Share{LinkID: a}
+	if err := db.CreateShare(ctx, 1, second); err != nil {
+		t.Fatalf("CreateShare(): %v", err)
+	}
+	if first.ID <= 0 || second.ID == first.ID || first.CreatedAt.IsZero() ||
+		first.UserID != 1 || first.OriginalURL != "https://a.example.com" {
+		t.Errorf("CreateShare() = %+v, %+v", first, second)
+	}
+
+	got, err := db.GetShare(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("GetShare(): %v", err)
+	}
+	if got.LinkID != a || got.UserID != 1 || got.OriginalURL != "https://a.example.com" ||
+		!got.ExpiresAt.Equal(expires) || !got.RevokedAt.IsZero() {
+		t.Errorf("GetShare() = %+v", got)
+	}
+	if got, err := db.GetShare(ctx, second.ID); err != nil || !got.ExpiresAt.IsZero() {
+		t.Errorf("GetShare() of a share without expiry = %+v, %v", got, err)
+	}
+	if _, err := db.GetShare(ctx, second.ID+100); !errors.Is(err, storage.ErrShareNotFound) {
+		t.Errorf("GetShare() of a missing share err = %v, want ErrShareNotFound", err)
+	}
+
+	once := &models.Share{LinkID: a, OneTime: true, PasswordHash: "hash"}
+	if err := db.CreateShare(ctx, 1, once); err != nil {
+		t.Fatalf("CreateShare() of a one-time share: %v", err)
+	}
+	if got, err := db.GetShare(ctx, once.ID); err != nil || !got.OneTime || got.PasswordHash != "hash" || !got.UsedAt.IsZero() {
+		t.Errorf("GetShare() of a one-time share = %+v, %v", got, err)
+	}
+	if err := db.UseShare(ctx, once.ID); err != nil {
+		t.Fatalf("UseShare(): %v", err)
+	}
+	if err := db.UseShare(ctx, once.ID); !errors.Is(err, storage.ErrShareUsed) {
+		t.Errorf("UseShare() of a used share err = %v, want ErrShareUsed", err)
+	}
+	if got, err := db.GetShare(ctx, once.ID); err != nil || got.UsedAt.IsZero() {
+		t.Errorf("GetShare() of a used share = %+v, %v", got, err)
+	}
+
+	if _, err := db.RevokeShares(ctx, 1, b, 0); !errors.Is(err, storage.ErrLinksNotFound) {
+		t.Errorf("RevokeShares() of a link of another user err = %v, want ErrLinksNotFound", err)
+	}
+	if n, err := db.RevokeShares(ctx, 1, a, first.ID); err != nil || n != 1 {
+		t.Errorf("RevokeShares() of one share = %d, %v, want 1", n, err)
+	}
+	if got, err := db.GetShare(ctx, first.ID); err != nil || got.RevokedAt.IsZero() {
+		t.Errorf("GetShare() of a revoked share = %+v, %v", got, err)
+	}
+	if got, err := db.GetShare(ctx, second.ID); err != nil || !got.RevokedAt.IsZero() {
+		t.Errorf("GetShare() of another share of the link = %+v, %v, want it not revoked", got, err)
+	}
+	// shares already revoked are not counted again
+	if n, err := db.RevokeShares(ctx, 1, a, 0); err != nil || n != 2 {
+		t.Errorf("RevokeShares() of all shares = %d, %v, want 2", n, err)
+	}
+
+	// shares are deleted with the link
+	if _, _, err := db.DeleteLink(ctx, a); err != nil {
+		t.Fatalf("DeleteLink(): %v", err)
+	}
+	if _, err := db.GetShare(ctx, first.ID); !errors.Is(err, storage.ErrShareNotFound) {
+		t.Errorf("GetShare() of a deleted link err = %v, want ErrShareNotFound", err)
+	}
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxTagLength is the limit of a tag name in characters.
+const MaxTagLength = 32
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// NormalizeTags lowercases tags, strips a leading '#' and drops duplicates.
+// Tags must be non-empty, without whitespace and commas, and not longer than MaxTagLength.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if name == "" || utf8.RuneCountInString(name) > MaxTagLength ||
+			strings.ContainsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized, nil
+}
+
+// JoinTags and SplitTags keep normalized tags in a single column,
+// tag names can't have commas.
+func JoinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+func SplitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,170 @@
+// Package warc writes captures as WARC 1.1 files, the format of web
+// archives that replay tools such as pywb open. Every record is a gzip
+// member of its own, so the file is a standard .warc.gz.
+package warc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0x0FACED/link-saver-api/internal/wrap"
+)
+
+var pkg = "warc"
+
+const (
+	// ContentType is the media type of a WARC file.
+	ContentType = "application/warc"
+	// Extension is the file extension of the files written by Write.
+	Extension = ".warc.gz"
+
+	version  = "WARC/1.1"
+	software = "link-saver-api"
+)
+
+// Exchange is an HTTP request and the response to it.
+type Exchange struct {
+	// URL is the address the response came from, after redirects
+	URL    *url.URL
+	Date   time.Time
+	Method string
+	// RequestHeader is sent as is, Host is taken from URL
+	RequestHeader  http.Header
+	StatusCode     int
+	ResponseHeader http.Header
+	// Body is the payload as decoded by the client, the response is
+	// recorded with the matching Content-Length and without gzip encoding
+	Body []byte
+}
+
+// Write writes a WARC file with a warcinfo record and the request and
+// response records of ex. The client follows redirects by itself, so only
+// the final exchange is recorded.
+func Write(w io.Writer, ex *Exchange) error {
+	date := ex.Date.UTC()
+
+	info := "software: " + software + "\r\nformat: WARC File Format 1.1\r\n"
+	err := writeRecord(w, []field{
+		{"WARC-Type", "warcinfo"},
+		{"WARC-Record-ID", newRecordID()},
+		{"WARC-Date", formatDate(date)},
+		{"Content-Type", "application/warc-fields"},
+	}, []byte(info), nil)
+	if err != nil {
+		return err
+	}
+
+	responseID := newRecordID()
+	err = writeRecord(w, []field{
+		{"WARC-Type", "request"},
+		{"WARC-Record-ID", newRecordID()},
+		{"WARC-Date", formatDate(date)},
+		{"WARC-Target-URI", ex.URL.String()},
+		{"WARC-Concurrent-To", responseID},
+		{"Content-Type", "application/http;msgtype=request"},
+	}, requestBlock(ex), nil)
+	if err != nil {
+		return err
+	}
+
+	return writeRecord(w, []field{
+		{"WARC-Type", "response"},
+		{"WARC-Record-ID", responseID},
+		{"WARC-Date", formatDate(date)},
+		{"WARC-Target-URI", ex.URL.String()},
+		{"Content-Type", "application/http;msgtype=response"},
+	}, responseBlock(ex), ex.Body)
+}
+
+type field struct {
+	name, value string
+}
+
+// writeRecord writes a record with the digests of block and of payload,
+// nil payload means the record has none.
+func writeRecord(w io.Writer, fields []field, block, payload []byte) error {
+	var head bytes.Buffer
+	head.WriteString(version + "\r\n")
+	for _, f := range fields {
+		fmt.Fprintf(&head, "%s: %s\r\n", f.name, f.value)
+	}
+	fmt.Fprintf(&head, "WARC-Block-Digest: %s\r\n", digest(block))
+	if payload != nil {
+		fmt.Fprintf(&head, "WARC-Payload-Digest: %s\r\n", digest(payload))
+	}
+	fmt.Fprintf(&head, "Content-Length: %d\r\n\r\n", len(block))
+
+	zw := gzip.NewWriter(w)
+	for _, p := range [][]byte{head.Bytes(), block, []byte("\r\n\r\n")} {
+		if _, err := zw.Write(p); err != nil {
+			return wrap.E(pkg, "failed to write record", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return wrap.E(pkg, "failed to write record", err)
+	}
+	return nil
+}
+
+func requestBlock(ex *Exchange) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\r\n", ex.Method, ex.URL.RequestURI())
+	fmt.Fprintf(&b, "Host: %s\r\n", ex.URL.Host)
+
+	h := ex.RequestHeader.Clone()
+	h.Del("Host")
+	h.Write(&b)
+	b.WriteString("\r\n")
+
+	return b.Bytes()
+}
+
+func responseBlock(ex *Exchange) []byte {
+	var b bytes.Buffer
+	// http/2 responses are recorded as http/1.1, as other archivers do
+	fmt.Fprintf(&b, "HTTP/1.1 %d %s\r\n", ex.StatusCode, http.StatusText(ex.StatusCode))
+
+	h := ex.ResponseHeader.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	h.Del("Transfer-Encoding")
+	if strings.EqualFold(h.Get("Content-Encoding"), "gzip") {
+		h.Del("Content-Encoding")
+	}
+	h.Set("Content-Length", strconv.Itoa(len(ex.Body)))
+	h.Write(&b)
+	b.WriteString("\r\n")
+	b.Write(ex.Body)
+
+	return b.Bytes()
+}
+
+// digest is the SHA-1 of data in the base32 form used by archivers.
+func digest(data []byte) string {
+	sum := sha1.Sum(data)
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
+
+func formatDate(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// newRecordID returns a random version 4 uuid urn.
+func newRecordID() string {
+	var u [16]byte
+	rand.Read(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return fmt.Sprintf("<urn:uuid:%x-%x-%x-%x-%x>", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
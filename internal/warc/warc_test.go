@@ -0,0 +1,143 @@
+package warc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type record struct {
+	header textproto.MIMEHeader
+	block  []byte
+}
+
+// readRecords parses a WARC file written by Write.
+func readRecords(t *testing.T, data []byte) []record {
+	t.Helper()
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip.NewReader(): %v", err)
+	}
+	r := bufio.NewReader(zr)
+	tr := textproto.NewReader(r)
+
+	var records []record
+	for {
+		line, err := tr.ReadLine()
+		if err == io.EOF {
+			return records
+		}
+		if err != nil || line != version {
+			t.Fatalf("record %d starts with %q, %v", len(records), line, err)
+		}
+
+		header, err := tr.ReadMIMEHeader()
+		if err != nil {
+			t.Fatalf("ReadMIMEHeader(): %v", err)
+		}
+		n, _ := strconv.Atoi(header.Get("Content-Length"))
+		block := make([]byte, n+4)
+		if _, err := io.ReadFull(r, block); err != nil {
+			t.Fatalf("failed to read block: %v", err)
+		}
+		if string(block[n:]) != "\r\n\r\n" {
+			t.Fatalf("record %d ends with %q", len(records), block[n:])
+		}
+
+		records = append(records, record{header: header, block: block[:n]})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	u, _ := url.Parse("https://example.com/page?q=1")
+	body := []byte("<html><body>page</body></html>")
+	ex := &Exchange{
+		URL:            u,
+		Date:           time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Method:         http.MethodGet,
+		RequestHeader:  http.Header{"User-Agent": {"test"}},
+		StatusCode:     http.StatusOK,
+		ResponseHeader: http.Header{"Content-Type": {"text/html"}, "Content-Encoding": {"gzip"}, "Content-Length": {"10"}},
+		Body:           body,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, ex); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+
+	// every record is a gzip member of its own
+	br := bufio.NewReader(bytes.NewReader(buf.Bytes()))
+	zr, _ := gzip.NewReader(br)
+	members := 0
+	for {
+		zr.Multistream(false)
+		if _, err := io.Copy(io.Discard, zr); err != nil {
+			t.Fatalf("failed to read member: %v", err)
+		}
+		members++
+		if err := zr.Reset(br); err == io.EOF {
+			break
+		}
+	}
+
+	records := readRecords(t, buf.Bytes())
+	if len(records) != 3 || members != 3 {
+		t.Fatalf("Write() wrote %d records in %d members, want 3", len(records), members)
+	}
+
+	for i, typ := range []string{"warcinfo", "request", "response"} {
+		h := records[i].header
+		if got := h.Get("WARC-Type"); got != typ {
+			t.Errorf("record %d has type %q, want %q", i, got, typ)
+		}
+		if got := h.Get("WARC-Date"); got != "2024-05-01T12:00:00Z" {
+			t.Errorf("record %d has date %q", i, got)
+		}
+		if got, want := h.Get("WARC-Block-Digest"), digest(records[i].block); got != want {
+			t.Errorf("record %d has block digest %q, want %q", i, got, want)
+		}
+	}
+
+	request, response := records[1], records[2]
+	if got := request.header.Get("WARC-Concurrent-To"); got != response.header.Get("WARC-Record-ID") {
+		t.Errorf("request is concurrent to %q, want the response", got)
+	}
+	for _, r := range []record{request, response} {
+		if got := r.header.Get("WARC-Target-URI"); got != u.String() {
+			t.Errorf("target uri = %q, want %q", got, u)
+		}
+	}
+
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(request.block)))
+	if err != nil {
+		t.Fatalf("ReadRequest(): %v", err)
+	}
+	if req.Host != "example.com" || req.RequestURI != "/page?q=1" || req.Header.Get("User-Agent") != "test" {
+		t.Errorf("request = %s %s %s %v", req.Method, req.Host, req.RequestURI, req.Header)
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(response.block)), nil)
+	if err != nil {
+		t.Fatalf("ReadResponse(): %v", err)
+	}
+	got, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || !bytes.Equal(got, body) {
+		t.Errorf("response = %d %q, want 200 %q", res.StatusCode, got, body)
+	}
+	// the body is decoded, the headers must say so
+	if res.Header.Get("Content-Encoding") != "" || res.ContentLength != int64(len(body)) {
+		t.Errorf("response headers = %v", res.Header)
+	}
+	if got, want := response.header.Get("WARC-Payload-Digest"), digest(body); got != want {
+		t.Errorf("payload digest = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS links_search_vector_idx;
+
+ALTER TABLE links
+DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE links
+DROP COLUMN IF EXISTS content_text;
@@ -0,0 +1,33 @@
+ALTER TABLE links
+ADD COLUMN content_text TEXT NOT NULL DEFAULT '';
+
+-- rough html to text conversion, only used to backfill rows saved
+-- before the service started to extract page text itself
+CREATE FUNCTION links_html_to_text(content BYTEA) RETURNS TEXT AS $$
+DECLARE
+    html TEXT;
+BEGIN
+    html := convert_from(content, 'UTF8');
+    html := regexp_replace(html, '<script.*?</script>', ' ', 'gi');
+    html := regexp_replace(html, '<style.*?</style>', ' ', 'gi');
+    html := regexp_replace(html, '<noscript.*?</noscript>', ' ', 'gi');
+    html := regexp_replace(html, '<[^>]*>', ' ', 'g');
+    RETURN btrim(regexp_replace(html, '\s+', ' ', 'g'));
+EXCEPTION WHEN others THEN
+    RETURN '';
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+UPDATE links SET content_text = links_html_to_text(content);
+
+DROP FUNCTION links_html_to_text(BYTEA);
+
+-- 'simple' keeps the index language agnostic, the text is cut
+-- because tsvector is limited to 1MB
+ALTER TABLE links
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', description), 'A') ||
+    setweight(to_tsvector('simple', left(content_text, 200000)), 'B')
+) STORED;
+
+CREATE INDEX links_search_vector_idx ON links USING GIN (search_vector);
@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS links_search_vector_idx;
+
+ALTER TABLE links
+DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE links
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', description), 'A') ||
+    setweight(to_tsvector('simple', left(content_text, 200000)), 'B')
+) STORED;
+
+CREATE INDEX links_search_vector_idx ON links USING GIN (search_vector);
+
+ALTER TABLE links
+DROP COLUMN IF EXISTS title,
+DROP COLUMN IF EXISTS byline,
+DROP COLUMN IF EXISTS lead_image,
+DROP COLUMN IF EXISTS word_count,
+DROP COLUMN IF EXISTS article;
@@ -0,0 +1,22 @@
+ALTER TABLE links
+ADD COLUMN title TEXT NOT NULL DEFAULT '',
+ADD COLUMN byline TEXT NOT NULL DEFAULT '',
+ADD COLUMN lead_image TEXT NOT NULL DEFAULT '',
+ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN article TEXT NOT NULL DEFAULT '';
+
+-- a generated column can't be altered, so the search index
+-- is recreated to take the page title into account
+DROP INDEX IF EXISTS links_search_vector_idx;
+
+ALTER TABLE links
+DROP COLUMN search_vector;
+
+ALTER TABLE links
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', description), 'A') ||
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', left(content_text, 200000)), 'B')
+) STORED;
+
+CREATE INDEX links_search_vector_idx ON links USING GIN (search_vector);
@@ -0,0 +1,17 @@
+CREATE TABLE capture_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    original_url TEXT NOT NULL,
+    description VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    link_id BIGINT REFERENCES links(id) ON DELETE SET NULL,
+    -- when a pending job is due, or when the lease of a running job ends
+    run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX capture_jobs_run_at_idx ON capture_jobs (run_at)
+WHERE status IN ('pending', 'running');
@@ -0,0 +1,14 @@
+CREATE TABLE tags (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(32) NOT NULL,
+    CONSTRAINT unique_user_tag UNIQUE (user_id, name)
+);
+
+CREATE TABLE link_tags (
+    link_id BIGINT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (link_id, tag_id)
+);
+
+CREATE INDEX link_tags_tag_id_idx ON link_tags (tag_id);
@@ -0,0 +1,12 @@
+ALTER TABLE links ADD COLUMN domain TEXT NOT NULL DEFAULT '';
+
+-- the same as storage.Domain: host of the url in lowercase without "www."
+UPDATE links SET domain = COALESCE(regexp_replace(
+    lower(substring(original_url FROM '^[A-Za-z][A-Za-z0-9+.-]*://(?:[^@/?#]*@)?([^:/?#]+)')),
+    '^www\.', ''
+), '');
+
+-- keyset pagination, see storage.Sort
+CREATE INDEX links_user_date_added_idx ON links (user_id, date_added, id);
+CREATE INDEX links_user_title_idx ON links (user_id, title, id);
+CREATE INDEX links_user_domain_idx ON links (user_id, domain, id);
@@ -0,0 +1,16 @@
+ALTER TABLE capture_jobs DROP COLUMN IF EXISTS recapture;
+
+ALTER TABLE links ADD COLUMN content BYTEA NOT NULL DEFAULT '';
+
+UPDATE links l SET content = s.content
+FROM (
+    SELECT DISTINCT ON (link_id) link_id, content
+    FROM link_snapshots
+    ORDER BY link_id, captured_at DESC, id DESC
+) s
+WHERE s.link_id = l.id;
+
+ALTER TABLE links ALTER COLUMN content DROP DEFAULT;
+
+DROP INDEX IF EXISTS link_snapshots_link_captured_at_idx;
+DROP TABLE IF EXISTS link_snapshots;
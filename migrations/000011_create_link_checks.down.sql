@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS link_checks_link_id_idx;
+DROP TABLE IF EXISTS link_checks;
+
+DROP INDEX IF EXISTS links_checked_at_idx;
+
+ALTER TABLE links
+DROP COLUMN IF EXISTS last_ok_at,
+DROP COLUMN IF EXISTS checked_at,
+DROP COLUMN IF EXISTS check_failures,
+DROP COLUMN IF EXISTS check_status;
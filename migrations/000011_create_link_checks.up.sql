@@ -0,0 +1,23 @@
+-- link-rot monitoring: the state of the last check is kept on links,
+-- the recent checks in link_checks
+ALTER TABLE links
+ADD COLUMN check_status VARCHAR(16) NOT NULL DEFAULT 'unknown',
+ADD COLUMN check_failures INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN checked_at TIMESTAMP,
+ADD COLUMN last_ok_at TIMESTAMP;
+
+CREATE INDEX links_checked_at_idx ON links (checked_at NULLS FIRST, id);
+
+CREATE TABLE link_checks (
+    id BIGSERIAL PRIMARY KEY,
+    link_id BIGINT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    -- 0 when the server did not answer
+    status_code INTEGER NOT NULL DEFAULT 0,
+    -- where a moved link redirects to
+    location TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX link_checks_link_id_idx ON link_checks (link_id, id);
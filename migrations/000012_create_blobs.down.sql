@@ -0,0 +1,21 @@
+-- This migration can't be reversed once blobs hold compressed content:
+-- sql can't decode it and dropping the blobs would lose the pages, so it
+-- fails instead. Only a database saved with nothing compressed goes back,
+-- otherwise export the links with ExportLinks into a fresh database.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM blobs WHERE encoding <> 'identity') THEN
+        RAISE EXCEPTION 'blobs hold compressed content';
+    END IF;
+END $$;
+
+UPDATE link_snapshots s SET content = b.data
+FROM blobs b
+WHERE b.hash = s.content_hash;
+
+DROP INDEX IF EXISTS link_snapshots_content_hash_idx;
+
+ALTER TABLE link_snapshots ALTER COLUMN content DROP DEFAULT;
+ALTER TABLE link_snapshots DROP COLUMN IF EXISTS content_hash;
+
+DROP TABLE IF EXISTS blobs;
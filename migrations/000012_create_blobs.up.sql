@@ -0,0 +1,17 @@
+-- page bodies are stored once per distinct content, link_snapshots refer
+-- to them by the sha-256 of the uncompressed body. refs counts the
+-- snapshots referring to a blob, it is deleted with the last of them.
+CREATE TABLE blobs (
+    hash BYTEA PRIMARY KEY,
+    encoding VARCHAR(16) NOT NULL,
+    size BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    refs INTEGER NOT NULL DEFAULT 0
+);
+
+-- content is converted to blobs when the service connects, compression
+-- needs go. Converted rows keep an empty content.
+ALTER TABLE link_snapshots ADD COLUMN content_hash BYTEA REFERENCES blobs(hash);
+ALTER TABLE link_snapshots ALTER COLUMN content SET DEFAULT '';
+
+CREATE INDEX link_snapshots_content_hash_idx ON link_snapshots (content_hash) WHERE content_hash IS NULL;
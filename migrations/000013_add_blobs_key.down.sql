@@ -0,0 +1,11 @@
+-- content in a blob store can't be brought back in sql, run the service
+-- without a store before going back
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM blobs WHERE key IS NOT NULL) THEN
+        RAISE EXCEPTION 'blobs hold content in a blob store';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS blobs_key_null_idx;
+ALTER TABLE blobs DROP COLUMN IF EXISTS key;
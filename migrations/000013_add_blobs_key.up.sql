@@ -0,0 +1,4 @@
+-- content of a blob with a key is in the blob store, data is empty then
+ALTER TABLE blobs ADD COLUMN key TEXT;
+
+CREATE INDEX blobs_key_null_idx ON blobs (hash) WHERE key IS NULL;
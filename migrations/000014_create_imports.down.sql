@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS capture_jobs_import_id_idx;
+
+ALTER TABLE capture_jobs
+    DROP COLUMN IF EXISTS collection_id,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS date_added,
+    DROP COLUMN IF EXISTS import_id;
+
+DROP TABLE IF EXISTS imports;
@@ -0,0 +1,22 @@
+-- bookmark files queued for capture, the progress is counted from
+-- the status of their capture jobs
+CREATE TABLE imports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total INTEGER NOT NULL DEFAULT 0,
+    queued INTEGER NOT NULL DEFAULT 0,
+    duplicates INTEGER NOT NULL DEFAULT 0,
+    invalid INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- date_added, tags and collection_id are applied to the saved link,
+-- tags are comma separated as tag names can't have commas
+ALTER TABLE capture_jobs
+    ADD COLUMN import_id BIGINT REFERENCES imports(id) ON DELETE SET NULL,
+    ADD COLUMN date_added TIMESTAMP,
+    ADD COLUMN tags TEXT NOT NULL DEFAULT '',
+    ADD COLUMN collection_id BIGINT REFERENCES collections(id) ON DELETE SET NULL;
+
+CREATE INDEX capture_jobs_import_id_idx ON capture_jobs (import_id)
+WHERE import_id IS NOT NULL;
@@ -0,0 +1,13 @@
+-- WARC files are dropped with their references, files of a blob store
+-- are left behind
+UPDATE blobs b SET refs = b.refs - w.n
+FROM (
+    SELECT warc_hash, COUNT(*) AS n FROM link_snapshots
+    WHERE warc_hash IS NOT NULL
+    GROUP BY warc_hash
+) w
+WHERE b.hash = w.warc_hash;
+
+ALTER TABLE link_snapshots DROP COLUMN IF EXISTS warc_hash;
+
+DELETE FROM blobs WHERE refs <= 0;
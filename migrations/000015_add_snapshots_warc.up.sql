@@ -0,0 +1,3 @@
+-- the WARC file of a capture is kept in a blob like the page, refs counts
+-- the references from both columns. Snapshots captured before have none.
+ALTER TABLE link_snapshots ADD COLUMN warc_hash BYTEA REFERENCES blobs(hash);
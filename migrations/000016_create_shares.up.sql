@@ -0,0 +1,11 @@
+-- share links of saved pages, the links are signed tokens
+-- and the rows are kept to revoke them
+CREATE TABLE shares (
+    id BIGSERIAL PRIMARY KEY,
+    link_id BIGINT NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX shares_link_id_idx ON shares (link_id);
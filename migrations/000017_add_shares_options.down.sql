@@ -0,0 +1,4 @@
+ALTER TABLE shares
+    DROP COLUMN IF EXISTS password_hash,
+    DROP COLUMN IF EXISTS used_at,
+    DROP COLUMN IF EXISTS one_time;
@@ -0,0 +1,6 @@
+-- one-time shares stop working once used_at is set, password_hash
+-- is a bcrypt hash, empty for shares without a password
+ALTER TABLE shares
+    ADD COLUMN one_time BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN used_at TIMESTAMP,
+    ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
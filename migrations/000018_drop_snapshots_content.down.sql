@@ -0,0 +1,4 @@
+-- the content stays in blobs, 000012 brings it back
+ALTER TABLE link_snapshots ADD COLUMN content BYTEA NOT NULL DEFAULT '';
+
+CREATE INDEX link_snapshots_content_hash_idx ON link_snapshots (content_hash) WHERE content_hash IS NULL;
@@ -0,0 +1,12 @@
+-- snapshot content is in blobs since 000012, the service converts what
+-- was saved before right before this migration. The check fails it while
+-- unconverted content remains.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM link_snapshots WHERE content_hash IS NULL) THEN
+        RAISE EXCEPTION 'snapshots hold content not converted to blobs';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS link_snapshots_content_hash_idx;
+ALTER TABLE link_snapshots DROP COLUMN content;
@@ -2,22 +2,64 @@ package migrations
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/0x0FACED/link-saver-api/internal/wrap"
 	"github.com/golang-migrate/migrate"
 	_ "github.com/golang-migrate/migrate/database/postgres"
+	_ "github.com/golang-migrate/migrate/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/source/file"
 )
 
 var pkg = "migrations"
 
-func Up(url string) error {
+// Step is a data conversion sql can't do, such as compressing content.
+// Run is called once with the schema at Version, before the migrations
+// after it, which may depend on it. It runs again if it fails or the
+// schema is migrated back to Version, so it must be safe to repeat.
+type Step struct {
+	Version uint
+	Run     func() error
+}
+
+func Up(url string, steps ...Step) error {
+	return up("file://./migrations/", url, steps)
+}
+
+// UpSQLite applies the sqlite migrations set, url must use the sqlite3:// scheme.
+func UpSQLite(url string, steps ...Step) error {
+	return up("file://./migrations/sqlite/", url, steps)
+}
+
+func up(source, url string, steps []Step) error {
 	m, err := migrate.New(
-		"file://./migrations/",
+		source,
 		url)
 	if err != nil {
 		return err
 	}
+	defer m.Close()
+
+	for _, step := range steps {
+		version, dirty, err := m.Version()
+		switch {
+		case errors.Is(err, migrate.ErrNilVersion):
+		case err != nil:
+			return wrap.E(pkg, "failed to Version()", err)
+		case dirty:
+			return wrap.E(pkg, "failed to Version()", fmt.Errorf("schema version %d is dirty", version))
+		case version > step.Version:
+			// done before
+			continue
+		}
+
+		if err := m.Migrate(step.Version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return wrap.E(pkg, "failed to Migrate()", err)
+		}
+		if err := step.Run(); err != nil {
+			return wrap.E(pkg, fmt.Sprintf("failed to run step of version %d", step.Version), err)
+		}
+	}
 
 	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return wrap.E(pkg, "failed to Up()", err)
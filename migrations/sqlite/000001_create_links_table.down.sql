@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS links;
+
+DROP TABLE IF EXISTS users;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE links ADD COLUMN content_text TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE links DROP COLUMN article;
+ALTER TABLE links DROP COLUMN word_count;
+ALTER TABLE links DROP COLUMN lead_image;
+ALTER TABLE links DROP COLUMN byline;
+ALTER TABLE links DROP COLUMN title;
@@ -0,0 +1,5 @@
+ALTER TABLE links ADD COLUMN title TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN byline TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN lead_image TEXT NOT NULL DEFAULT '';
+ALTER TABLE links ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE links ADD COLUMN article TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS links_collection_id_idx;
+ALTER TABLE links DROP COLUMN collection_id;
+DROP TABLE IF EXISTS collections;
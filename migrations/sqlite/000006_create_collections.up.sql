@@ -0,0 +1,16 @@
+CREATE TABLE collections (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- nested collections are deleted together with their parent
+    parent_id INTEGER REFERENCES collections(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- top level collections have no parent, NULL is folded so their names are unique too
+CREATE UNIQUE INDEX collections_user_parent_name_idx ON collections (user_id, COALESCE(parent_id, 0), name);
+
+-- links of a deleted collection are moved to the top level
+ALTER TABLE links ADD COLUMN collection_id INTEGER REFERENCES collections(id) ON DELETE SET NULL;
+
+CREATE INDEX links_collection_id_idx ON links (collection_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS links_user_domain_idx;
+DROP INDEX IF EXISTS links_user_title_idx;
+DROP INDEX IF EXISTS links_user_date_added_idx;
+
+ALTER TABLE links DROP COLUMN domain;
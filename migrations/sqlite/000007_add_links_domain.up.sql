@@ -0,0 +1,19 @@
+ALTER TABLE links ADD COLUMN domain TEXT NOT NULL DEFAULT '';
+
+-- the same as storage.Domain: host of the url in lowercase without "www.",
+-- sqlite has no regular expressions so the url is cut step by step
+UPDATE links SET domain = lower(CASE
+    WHEN instr(original_url, '://') > 0 THEN substr(original_url, instr(original_url, '://') + 3)
+    ELSE original_url
+END);
+UPDATE links SET domain = substr(domain, 1, instr(domain, '/') - 1) WHERE instr(domain, '/') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, '?') - 1) WHERE instr(domain, '?') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, '#') - 1) WHERE instr(domain, '#') > 0;
+UPDATE links SET domain = substr(domain, instr(domain, '@') + 1) WHERE instr(domain, '@') > 0;
+UPDATE links SET domain = substr(domain, 1, instr(domain, ':') - 1) WHERE instr(domain, ':') > 0;
+UPDATE links SET domain = substr(domain, 5) WHERE domain LIKE 'www.%';
+
+-- keyset pagination, see storage.Sort
+CREATE INDEX links_user_date_added_idx ON links (user_id, date_added, id);
+CREATE INDEX links_user_title_idx ON links (user_id, title, id);
+CREATE INDEX links_user_domain_idx ON links (user_id, domain, id);
@@ -0,0 +1,14 @@
+ALTER TABLE capture_jobs DROP COLUMN recapture;
+
+ALTER TABLE links ADD COLUMN content BLOB NOT NULL DEFAULT '';
+
+UPDATE links SET content = (
+    SELECT content FROM link_snapshots s
+    WHERE s.link_id = links.id
+    ORDER BY s.captured_at DESC, s.id DESC
+    LIMIT 1
+)
+WHERE EXISTS (SELECT 1 FROM link_snapshots s WHERE s.link_id = links.id);
+
+DROP INDEX IF EXISTS link_snapshots_link_captured_at_idx;
+DROP TABLE IF EXISTS link_snapshots;
@@ -0,0 +1,22 @@
+CREATE TABLE link_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    link_id INTEGER NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    content BLOB NOT NULL,
+    content_text TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    byline TEXT NOT NULL DEFAULT '',
+    lead_image TEXT NOT NULL DEFAULT '',
+    word_count INTEGER NOT NULL DEFAULT 0,
+    article TEXT NOT NULL DEFAULT '',
+    captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX link_snapshots_link_captured_at_idx ON link_snapshots (link_id, captured_at, id);
+
+INSERT INTO link_snapshots (link_id, content, content_text, title, byline, lead_image, word_count, article, captured_at)
+SELECT id, content, content_text, title, byline, lead_image, word_count, article, COALESCE(date_added, CURRENT_TIMESTAMP)
+FROM links;
+
+ALTER TABLE links DROP COLUMN content;
+
+ALTER TABLE capture_jobs ADD COLUMN recapture BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS link_checks_link_id_idx;
+DROP TABLE IF EXISTS link_checks;
+
+DROP INDEX IF EXISTS links_checked_at_idx;
+
+ALTER TABLE links DROP COLUMN last_ok_at;
+ALTER TABLE links DROP COLUMN checked_at;
+ALTER TABLE links DROP COLUMN check_failures;
+ALTER TABLE links DROP COLUMN check_status;
@@ -0,0 +1,18 @@
+ALTER TABLE links ADD COLUMN check_status VARCHAR(16) NOT NULL DEFAULT 'unknown';
+ALTER TABLE links ADD COLUMN check_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE links ADD COLUMN checked_at TIMESTAMP;
+ALTER TABLE links ADD COLUMN last_ok_at TIMESTAMP;
+
+CREATE INDEX links_checked_at_idx ON links (checked_at, id);
+
+CREATE TABLE link_checks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    link_id INTEGER NOT NULL REFERENCES links(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    location TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX link_checks_link_id_idx ON link_checks (link_id, id);
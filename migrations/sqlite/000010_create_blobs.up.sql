@@ -0,0 +1,11 @@
+CREATE TABLE blobs (
+    hash BLOB PRIMARY KEY,
+    encoding VARCHAR(16) NOT NULL,
+    size INTEGER NOT NULL,
+    data BLOB NOT NULL,
+    refs INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE link_snapshots ADD COLUMN content_hash BLOB REFERENCES blobs(hash);
+
+CREATE INDEX link_snapshots_content_hash_idx ON link_snapshots (content_hash) WHERE content_hash IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE blobs ADD COLUMN key TEXT;
+
+CREATE INDEX blobs_key_null_idx ON blobs (hash) WHERE key IS NULL;
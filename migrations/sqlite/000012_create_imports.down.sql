@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS capture_jobs_import_id_idx;
+
+ALTER TABLE capture_jobs DROP COLUMN collection_id;
+ALTER TABLE capture_jobs DROP COLUMN tags;
+ALTER TABLE capture_jobs DROP COLUMN date_added;
+ALTER TABLE capture_jobs DROP COLUMN import_id;
+
+DROP TABLE IF EXISTS imports;
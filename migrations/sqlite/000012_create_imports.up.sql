@@ -0,0 +1,16 @@
+CREATE TABLE imports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total INTEGER NOT NULL DEFAULT 0,
+    queued INTEGER NOT NULL DEFAULT 0,
+    duplicates INTEGER NOT NULL DEFAULT 0,
+    invalid INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE capture_jobs ADD COLUMN import_id INTEGER REFERENCES imports(id) ON DELETE SET NULL;
+ALTER TABLE capture_jobs ADD COLUMN date_added TIMESTAMP;
+ALTER TABLE capture_jobs ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+ALTER TABLE capture_jobs ADD COLUMN collection_id INTEGER REFERENCES collections(id) ON DELETE SET NULL;
+
+CREATE INDEX capture_jobs_import_id_idx ON capture_jobs (import_id) WHERE import_id IS NOT NULL;
@@ -0,0 +1,8 @@
+-- WARC files are dropped with their references, files of a blob store
+-- are left behind
+UPDATE blobs SET refs = refs - (SELECT COUNT(*) FROM link_snapshots WHERE warc_hash = blobs.hash)
+WHERE hash IN (SELECT warc_hash FROM link_snapshots);
+
+ALTER TABLE link_snapshots DROP COLUMN warc_hash;
+
+DELETE FROM blobs WHERE refs <= 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS shares_link_id_idx;
+
+DROP TABLE IF EXISTS shares;
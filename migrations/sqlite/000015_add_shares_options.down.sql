@@ -0,0 +1,3 @@
+ALTER TABLE shares DROP COLUMN password_hash;
+ALTER TABLE shares DROP COLUMN used_at;
+ALTER TABLE shares DROP COLUMN one_time;
@@ -0,0 +1,3 @@
+ALTER TABLE shares ADD COLUMN one_time BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE shares ADD COLUMN used_at TIMESTAMP;
+ALTER TABLE shares ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- snapshot content is in blobs since 000010, the service converts what
+-- was saved before right before this migration. The check fails it while
+-- unconverted content remains.
+CREATE TEMP TABLE content_check (unconverted INTEGER CHECK (unconverted = 0));
+INSERT INTO content_check SELECT COUNT(*) FROM link_snapshots WHERE content_hash IS NULL;
+DROP TABLE content_check;
+
+DROP INDEX IF EXISTS link_snapshots_content_hash_idx;
+ALTER TABLE link_snapshots DROP COLUMN content;